@@ -63,6 +63,23 @@ func (c *CryptoCompareClient) GetCUDOSPrice(currency string) (string, error) {
 	return price, err
 }
 
+// GetHistoricalCUDOSPrice queries the remote APIs to get the CUDOS price in the given currency at the given time
+func (c *CryptoCompareClient) GetHistoricalCUDOSPrice(currency string, timestamp time.Time) (string, error) {
+	var resStruct map[string]map[string]float64
+	query := fmt.Sprintf("/data/pricehistorical?fsym=CUDOS&tsyms=%s&ts=%d", strings.ToUpper(currency), timestamp.Unix())
+	err := c.queryCryptoCompare(query, &resStruct)
+	if err != nil {
+		return "", err
+	}
+
+	price, ok := resStruct["CUDOS"][strings.ToUpper(currency)]
+	if !ok {
+		return "", fmt.Errorf("no historical CUDOS price in %s found at %d", currency, timestamp.Unix())
+	}
+
+	return fmt.Sprintf("%g", price), nil
+}
+
 // queryCryptoCompare queries the CoinGecko APIs for the given endpoint
 func (c *CryptoCompareClient) queryCryptoCompare(endpoint string, ptr interface{}) error {
 	req, err := http.NewRequest("GET", "https://min-api.cryptocompare.com"+endpoint, nil)
@@ -16,6 +16,7 @@ import (
 
 	"github.com/forbole/bdjuno/v2/cmd/actions/handlers"
 	actionstypes "github.com/forbole/bdjuno/v2/cmd/actions/types"
+	"github.com/forbole/bdjuno/v2/database"
 	"github.com/forbole/bdjuno/v2/modules"
 )
 
@@ -74,7 +75,8 @@ func NewActionsCmd(parseCfg *parse.Config) *cobra.Command {
 			}
 
 			// Build the worker
-			context := actionstypes.NewContext(node, sources, parseCtx.EncodingConfig.Marshaler)
+			context := actionstypes.NewContext(
+				node, sources, parseCtx.EncodingConfig.Marshaler, database.Cast(parseCtx.Database))
 			worker := actionstypes.NewActionsWorker(context)
 
 			// Register the endpoints
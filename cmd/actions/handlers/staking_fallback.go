@@ -0,0 +1,134 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"net"
+
+	"github.com/cosmos/cosmos-sdk/types/query"
+	stakingtypes "github.com/cosmos/cosmos-sdk/x/staking/types"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	actionstypes "github.com/forbole/bdjuno/v2/cmd/actions/types"
+	"github.com/forbole/bdjuno/v2/types"
+)
+
+// defaultPageLimit is the page limit used by the node when none is given
+const defaultPageLimit = 100
+
+// isNodeUnavailableError tells whether the given error has been caused by the node not being reachable,
+// which is the only case in which the entries stored inside the database are returned instead
+func isNodeUnavailableError(err error) bool {
+	var netErr net.Error
+	if errors.As(err, &netErr) || errors.Is(err, context.DeadlineExceeded) {
+		return true
+	}
+
+	var grpcErr interface{ GRPCStatus() *status.Status }
+	if errors.As(err, &grpcErr) {
+		switch grpcErr.GRPCStatus().Code() {
+		case codes.Unavailable, codes.DeadlineExceeded:
+			return true
+		}
+	}
+
+	return false
+}
+
+// buildUnbondingDelegationResponse groups the given unbonding delegations stored inside the database by
+// delegator and validator, returning the page identified by the given pagination
+func buildUnbondingDelegationResponse(
+	delegations []types.UnbondingDelegation, pagination *query.PageRequest,
+) actionstypes.UnbondingDelegationResponse {
+	list := []actionstypes.UnbondingDelegation{}
+	for _, delegation := range delegations {
+		last := len(list) - 1
+		if last < 0 ||
+			list[last].DelegatorAddress != delegation.DelegatorAddress ||
+			list[last].ValidatorAddress != delegation.ValidatorAddress {
+			list = append(list, actionstypes.UnbondingDelegation{
+				DelegatorAddress: delegation.DelegatorAddress,
+				ValidatorAddress: delegation.ValidatorAddress,
+			})
+			last++
+		}
+
+		list[last].Entries = append(list[last].Entries, stakingtypes.NewUnbondingDelegationEntry(
+			delegation.Height,
+			delegation.CompletionTimestamp,
+			delegation.Amount.Amount,
+		))
+	}
+
+	start, end, pageResponse := paginate(len(list), pagination)
+	return actionstypes.UnbondingDelegationResponse{
+		UnbondingDelegations: list[start:end],
+		Pagination:           pageResponse,
+	}
+}
+
+// buildRedelegationResponse groups the given redelegations stored inside the database by
+// delegator, source and destination validator, returning the page identified by the given pagination
+func buildRedelegationResponse(
+	redelegations []types.Redelegation, pagination *query.PageRequest,
+) actionstypes.RedelegationResponse {
+	list := []actionstypes.Redelegation{}
+	for _, redelegation := range redelegations {
+		last := len(list) - 1
+		if last < 0 ||
+			list[last].DelegatorAddress != redelegation.DelegatorAddress ||
+			list[last].ValidatorSrcAddress != redelegation.SrcValidator ||
+			list[last].ValidatorDstAddress != redelegation.DstValidator {
+			list = append(list, actionstypes.Redelegation{
+				DelegatorAddress:    redelegation.DelegatorAddress,
+				ValidatorSrcAddress: redelegation.SrcValidator,
+				ValidatorDstAddress: redelegation.DstValidator,
+			})
+			last++
+		}
+
+		list[last].RedelegationEntries = append(list[last].RedelegationEntries, actionstypes.RedelegationEntry{
+			CompletionTime: redelegation.CompletionTime,
+			Balance:        redelegation.Amount.Amount,
+		})
+	}
+
+	start, end, pageResponse := paginate(len(list), pagination)
+	return actionstypes.RedelegationResponse{
+		Redelegations: list[start:end],
+		Pagination:    pageResponse,
+	}
+}
+
+// paginate returns the bounds of the page identified by the given pagination
+// inside a list having the given length, along with the page response
+func paginate(length int, pagination *query.PageRequest) (start int, end int, response *query.PageResponse) {
+	limit := defaultPageLimit
+	offset := 0
+	countTotal := false
+	if pagination != nil {
+		if pagination.Limit > 0 {
+			limit = int(pagination.Limit)
+		}
+		offset = int(pagination.Offset)
+		countTotal = pagination.CountTotal
+	}
+
+	start = offset
+	if start > length {
+		start = length
+	}
+
+	end = start + limit
+	if end > length {
+		end = length
+	}
+
+	response = &query.PageResponse{}
+	if countTotal {
+		response.Total = uint64(length)
+	}
+
+	return start, end, response
+}
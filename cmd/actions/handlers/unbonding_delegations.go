@@ -3,10 +3,30 @@ package handlers
 import (
 	"fmt"
 
+	"github.com/rs/zerolog/log"
+
 	actionstypes "github.com/forbole/bdjuno/v2/cmd/actions/types"
 )
 
 func UnbondingDelegationsHandler(ctx *actionstypes.Context, payload *actionstypes.Payload) (interface{}, error) {
+	response, err := getUnbondingDelegations(ctx, payload)
+	if err != nil && isNodeUnavailableError(err) {
+		// Fall back to the entries stored inside the database when the node is not available
+		log.Warn().Err(err).Str("address", payload.GetAddress()).
+			Msg("error while getting delegator unbonding delegations from the node, using the database instead")
+
+		entries, dbErr := ctx.Db.GetDelegatorUnbondingDelegations(payload.GetAddress())
+		if dbErr != nil {
+			return nil, fmt.Errorf("error while getting delegator unbonding delegations from the database: %s", dbErr)
+		}
+
+		return buildUnbondingDelegationResponse(entries, payload.GetPagination()), nil
+	}
+
+	return response, err
+}
+
+func getUnbondingDelegations(ctx *actionstypes.Context, payload *actionstypes.Payload) (interface{}, error) {
 	height, err := ctx.GetHeight(payload)
 	if err != nil {
 		return nil, err
@@ -15,7 +35,7 @@ func UnbondingDelegationsHandler(ctx *actionstypes.Context, payload *actionstype
 	// Get all unbonding delegations for given delegator address
 	unbondingDelegations, err := ctx.Sources.StakingSource.GetUnbondingDelegations(height, payload.GetAddress(), payload.GetPagination())
 	if err != nil {
-		return nil, fmt.Errorf("error while getting delegator delegations: %s", err)
+		return nil, fmt.Errorf("error while getting delegator delegations: %w", err)
 	}
 
 	unbondingDelegationsList := make([]actionstypes.UnbondingDelegation, len(unbondingDelegations.UnbondingResponses))
@@ -3,12 +3,32 @@ package handlers
 import (
 	"fmt"
 
+	"github.com/rs/zerolog/log"
+
 	stakingtypes "github.com/cosmos/cosmos-sdk/x/staking/types"
 
 	actionstypes "github.com/forbole/bdjuno/v2/cmd/actions/types"
 )
 
 func ValidatorRedelegationsFromHandler(ctx *actionstypes.Context, payload *actionstypes.Payload) (interface{}, error) {
+	response, err := getValidatorRedelegationsFrom(ctx, payload)
+	if err != nil && isNodeUnavailableError(err) {
+		// Fall back to the entries stored inside the database when the node is not available
+		log.Warn().Err(err).Str("address", payload.GetAddress()).
+			Msg("error while getting redelegations from validator from the node, using the database instead")
+
+		entries, dbErr := ctx.Db.GetValidatorRedelegationsFrom(payload.GetAddress())
+		if dbErr != nil {
+			return nil, fmt.Errorf("error while getting redelegations from validator from the database: %s", dbErr)
+		}
+
+		return buildRedelegationResponse(entries, payload.GetPagination()), nil
+	}
+
+	return response, err
+}
+
+func getValidatorRedelegationsFrom(ctx *actionstypes.Context, payload *actionstypes.Payload) (interface{}, error) {
 	height, err := ctx.GetHeight(payload)
 	if err != nil {
 		return nil, err
@@ -20,7 +40,7 @@ func ValidatorRedelegationsFromHandler(ctx *actionstypes.Context, payload *actio
 		Pagination:       payload.GetPagination(),
 	})
 	if err != nil {
-		return nil, fmt.Errorf("error while getting redelegations from validator: %s", err)
+		return nil, fmt.Errorf("error while getting redelegations from validator: %w", err)
 	}
 
 	redelegationsList := make([]actionstypes.Redelegation, len(redelegations.RedelegationResponses))
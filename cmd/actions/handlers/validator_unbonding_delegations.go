@@ -3,10 +3,30 @@ package handlers
 import (
 	"fmt"
 
+	"github.com/rs/zerolog/log"
+
 	actionstypes "github.com/forbole/bdjuno/v2/cmd/actions/types"
 )
 
 func ValidatorUnbondingDelegationsHandler(ctx *actionstypes.Context, payload *actionstypes.Payload) (interface{}, error) {
+	response, err := getValidatorUnbondingDelegations(ctx, payload)
+	if err != nil && isNodeUnavailableError(err) {
+		// Fall back to the entries stored inside the database when the node is not available
+		log.Warn().Err(err).Str("address", payload.GetAddress()).
+			Msg("error while getting validator unbonding delegations from the node, using the database instead")
+
+		entries, dbErr := ctx.Db.GetValidatorUnbondingDelegations(payload.GetAddress())
+		if dbErr != nil {
+			return nil, fmt.Errorf("error while getting validator unbonding delegations from the database: %s", dbErr)
+		}
+
+		return buildUnbondingDelegationResponse(entries, payload.GetPagination()), nil
+	}
+
+	return response, err
+}
+
+func getValidatorUnbondingDelegations(ctx *actionstypes.Context, payload *actionstypes.Payload) (interface{}, error) {
 	// Get latest node height
 	height, err := ctx.GetHeight(payload)
 	if err != nil {
@@ -20,7 +40,7 @@ func ValidatorUnbondingDelegationsHandler(ctx *actionstypes.Context, payload *ac
 		payload.GetPagination(),
 	)
 	if err != nil {
-		return nil, fmt.Errorf("error while getting all unbonding delegations from validator %s: %s",
+		return nil, fmt.Errorf("error while getting all unbonding delegations from validator %s: %w",
 			payload.GetAddress(), err)
 	}
 
@@ -6,6 +6,7 @@ import (
 	"github.com/cosmos/cosmos-sdk/codec"
 	"github.com/forbole/juno/v2/node"
 
+	"github.com/forbole/bdjuno/v2/database"
 	"github.com/forbole/bdjuno/v2/modules"
 )
 
@@ -14,14 +15,16 @@ type Context struct {
 	Node    node.Node
 	Sources *modules.Sources
 	Cdc     codec.Codec
+	Db      *database.Db
 }
 
 // NewContext returns a new Context instance
-func NewContext(node node.Node, sources *modules.Sources, cdc codec.Codec) *Context {
+func NewContext(node node.Node, sources *modules.Sources, cdc codec.Codec, db *database.Db) *Context {
 	return &Context{
 		Node:    node,
 		Sources: sources,
 		Cdc:     cdc,
+		Db:      db,
 	}
 }
 
@@ -30,7 +33,7 @@ func (c *Context) GetHeight(payload *Payload) (int64, error) {
 	if payload == nil || payload.Input.Height == 0 {
 		latestHeight, err := c.Node.LatestHeight()
 		if err != nil {
-			return 0, fmt.Errorf("error while getting chain latest block height: %s", err)
+			return 0, fmt.Errorf("error while getting chain latest block height: %w", err)
 		}
 		return latestHeight, nil
 	}
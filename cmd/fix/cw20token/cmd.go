@@ -0,0 +1,20 @@
+package cw20token
+
+import (
+	"github.com/forbole/juno/v2/cmd/parse"
+	"github.com/spf13/cobra"
+)
+
+// NewCW20TokenCmd returns the Cobra command allowing to fix various things related to the cw20token module
+func NewCW20TokenCmd(parseConfig *parse.Config) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "cw20token",
+		Short: "Fix things related to the cw20token module",
+	}
+
+	cmd.AddCommand(
+		typesCmd(parseConfig),
+	)
+
+	return cmd
+}
@@ -0,0 +1,102 @@
+package cw20token
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+
+	wasm "github.com/CosmWasm/wasmd/x/wasm/types"
+	"github.com/forbole/juno/v2/cmd/parse"
+	"github.com/spf13/cobra"
+
+	"github.com/forbole/bdjuno/v2/database"
+	mutils "github.com/forbole/bdjuno/v2/modules/utils"
+	"github.com/forbole/bdjuno/v2/types"
+	"github.com/forbole/bdjuno/v2/utils"
+)
+
+// typesCmd returns the Cobra command allowing to re-derive the type of all the stored tokens
+func typesCmd(parseConfig *parse.Config) *cobra.Command {
+	return &cobra.Command{
+		Use:   "types",
+		Short: "Re-derive the type of every stored token from its instantiate message",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			parseCtx, err := parse.GetParsingContext(parseConfig)
+			if err != nil {
+				return err
+			}
+
+			// Get the database
+			db := database.Cast(parseCtx.Database)
+
+			tokens, err := db.GetTokenAddresses()
+			if err != nil {
+				return fmt.Errorf("error while getting tokens: %s", err)
+			}
+
+			for _, token := range tokens {
+				info, err := getTokenInfo(parseCtx, token)
+				if err != nil {
+					return fmt.Errorf("error while getting type of token %s: %s", token, err)
+				}
+
+				err = db.ExecuteTx(func(dbTx *database.DbTx) error {
+					return dbTx.UpdateTokenType(token, info.Type, info.Mint.MaxSupply)
+				})
+				if err != nil {
+					return fmt.Errorf("error while updating type of token %s: %s", token, err)
+				}
+			}
+
+			return nil
+		},
+	}
+}
+
+// getTokenInfo decodes the message that instantiated the given token and derives its type and max supply
+// the same way it is done while parsing
+func getTokenInfo(parseCtx *parse.Context, token string) (types.TokenInfo, error) {
+	query := fmt.Sprintf("%s.%s='%s'", wasm.EventTypeInstantiate, wasm.AttributeKeyContractAddr, token)
+	txs, err := utils.QueryTxs(parseCtx.Node, query)
+	if err != nil {
+		return types.TokenInfo{}, err
+	}
+
+	if len(txs) != 1 {
+		return types.TokenInfo{}, fmt.Errorf("expecting only one instantiate transaction, found %d", len(txs))
+	}
+
+	tx, err := parseCtx.Node.Tx(hex.EncodeToString(txs[0].Tx.Hash()))
+	if err != nil {
+		return types.TokenInfo{}, err
+	}
+
+	for index, msg := range tx.GetMsgs() {
+		msgInstantiate, ok := msg.(*wasm.MsgInstantiateContract)
+		if !ok {
+			continue
+		}
+
+		// The same transaction might instantiate more contracts
+		contractAddr := mutils.GetValueFromLogs(uint32(index), tx.Logs, wasm.EventTypeInstantiate, wasm.AttributeKeyContractAddr)
+		if contractAddr != token {
+			continue
+		}
+
+		instantiate := types.MsgInstantiate{}
+		if err := json.Unmarshal(msgInstantiate.Msg, &instantiate); err != nil {
+			return types.TokenInfo{}, err
+		}
+
+		// The total supply of a token right after its instantiation is its initial one
+		info := types.TokenInfo{TotalSupply: instantiate.InitialSupply()}
+		if instantiate.Mint != nil {
+			info.Mint = *instantiate.Mint
+		}
+
+		info.SetType(instantiate)
+		return info, nil
+	}
+
+	return types.TokenInfo{}, fmt.Errorf("instantiate message not found in tx %s", tx.TxHash)
+}
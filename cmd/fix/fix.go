@@ -7,6 +7,7 @@ import (
 	fixblocks "github.com/forbole/juno/v2/cmd/fix/blocks"
 
 	fixauth "github.com/forbole/bdjuno/v2/cmd/fix/auth"
+	fixcw20token "github.com/forbole/bdjuno/v2/cmd/fix/cw20token"
 	fixfeegrant "github.com/forbole/bdjuno/v2/cmd/fix/feegrant"
 	fixgov "github.com/forbole/bdjuno/v2/cmd/fix/gov"
 	fixstaking "github.com/forbole/bdjuno/v2/cmd/fix/staking"
@@ -23,6 +24,7 @@ func NewFixCmd(parseCfg *parse.Config) *cobra.Command {
 	cmd.AddCommand(
 		fixauth.NewAuthCmd(parseCfg),
 		fixblocks.NewBlocksCmd(parseCfg),
+		fixcw20token.NewCW20TokenCmd(parseCfg),
 		fixfeegrant.NewFeegrantCmd(parseCfg),
 		fixgov.NewGovCmd(parseCfg),
 		fixstaking.NewStakingCmd(parseCfg),
@@ -11,6 +11,7 @@ import (
 
 	"github.com/forbole/bdjuno/v2/database"
 	"github.com/forbole/bdjuno/v2/modules"
+	"github.com/forbole/bdjuno/v2/modules/alert"
 	"github.com/forbole/bdjuno/v2/modules/gov"
 	"github.com/forbole/bdjuno/v2/utils"
 )
@@ -37,7 +38,9 @@ func proposalCmd(parseConfig *parse.Config) *cobra.Command {
 			db := database.Cast(parseCtx.Database)
 
 			// Build the gov module
-			govModule := gov.NewModule(sources.GovSource, nil, nil, nil, nil, parseCtx.EncodingConfig.Marshaler, db)
+			// No alert is raised while fixing proposals
+			govModule := gov.NewModule(
+				sources.GovSource, nil, nil, nil, nil, alert.NewModule(nil, db), parseCtx.EncodingConfig.Marshaler, db)
 
 			err = refreshProposalDetails(parseCtx, proposalID, govModule)
 			if err != nil {
@@ -9,6 +9,7 @@ import (
 
 	"github.com/forbole/bdjuno/v2/database"
 	"github.com/forbole/bdjuno/v2/modules"
+	"github.com/forbole/bdjuno/v2/modules/alert"
 	"github.com/forbole/bdjuno/v2/modules/staking"
 )
 
@@ -32,7 +33,8 @@ func validatorsCmd(parseConfig *parse.Config) *cobra.Command {
 			db := database.Cast(parseCtx.Database)
 
 			// Build the staking module
-			stakingModule := staking.NewModule(sources.StakingSource, nil, nil, parseCtx.EncodingConfig.Marshaler, db)
+			stakingModule := staking.NewModule(
+				sources.StakingSource, nil, nil, alert.NewModule(nil, db), parseCtx.EncodingConfig.Marshaler, db)
 
 			// Get latest height
 			height, err := parseCtx.Node.LatestHeight()
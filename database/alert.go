@@ -0,0 +1,29 @@
+package database
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/forbole/bdjuno/v2/types"
+)
+
+// SaveAlert stores the given alert inside the database
+func (db *Db) SaveAlert(alert types.Alert) error {
+	data := alert.Data
+	if data == nil {
+		data = map[string]string{}
+	}
+
+	dataBz, err := json.Marshal(&data)
+	if err != nil {
+		return fmt.Errorf("error while marshaling alert data: %s", err)
+	}
+
+	stmt := `INSERT INTO alert (type, message, data, height, timestamp) VALUES ($1, $2, $3, $4, $5)`
+	_, err = db.Sql.Exec(stmt, alert.Type, alert.Message, string(dataBz), alert.Height, alert.Timestamp)
+	if err != nil {
+		return fmt.Errorf("error while storing alert: %s", err)
+	}
+
+	return nil
+}
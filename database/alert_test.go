@@ -0,0 +1,33 @@
+package database_test
+
+import (
+	"time"
+
+	dbtypes "github.com/forbole/bdjuno/v2/database/types"
+	"github.com/forbole/bdjuno/v2/types"
+)
+
+func (suite *DbTestSuite) TestSaveAlert() {
+	timestamp := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+	suite.Require().NoError(suite.database.SaveAlert(types.NewAlert(
+		types.AlertValidatorSlashed,
+		"validator cudosvalcons1 has been slashed",
+		map[string]string{"validator_address": "cudosvalcons1"},
+		10,
+		timestamp,
+	)))
+	suite.Require().NoError(suite.database.SaveAlert(types.NewAlert(
+		types.AlertProposalCreated, "proposal #1 has been submitted", nil, 11, timestamp)))
+
+	var rows []dbtypes.AlertRow
+	suite.Require().NoError(suite.database.Sqlx.Select(&rows, `SELECT * FROM alert ORDER BY id`))
+	suite.Require().Len(rows, 2)
+
+	suite.Require().Equal(types.AlertValidatorSlashed, rows[0].Type)
+	suite.Require().JSONEq(`{"validator_address": "cudosvalcons1"}`, rows[0].Data)
+	suite.Require().Equal(int64(10), rows[0].Height)
+	suite.Require().True(timestamp.Equal(rows[0].Timestamp))
+
+	suite.Require().Equal(types.AlertProposalCreated, rows[1].Type)
+	suite.Require().JSONEq(`{}`, rows[1].Data)
+}
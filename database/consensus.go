@@ -46,6 +46,13 @@ func (db *Db) GetLastBlockHeight() (int64, error) {
 	return block.Height, nil
 }
 
+// GetBlockTimestamp returns the timestamp of the block stored at the given height
+func (db *Db) GetBlockTimestamp(height int64) (time.Time, error) {
+	var timestamp time.Time
+	err := db.Sql.QueryRow(`SELECT timestamp FROM block WHERE height = $1`, height).Scan(&timestamp)
+	return timestamp, err
+}
+
 // -------------------------------------------------------------------------------------------------------------------
 
 // getBlockHeightTime retrieves the block at the specific time
@@ -25,17 +25,27 @@ func (dbTx *DbTx) SaveInfo(t types.TokenInfo) error {
 	return err
 }
 
-func (dbTx *DbTx) SaveBalances(token string, balances []types.TokenBalance) error {
-	stmt := "INSERT INTO cw20token_balance VALUES "
+// SaveBalances stores the given balances of the token as read at the given height,
+// without overriding the ones stored at a greater height
+func (dbTx *DbTx) SaveBalances(token string, balances []types.TokenBalance, height int64) error {
+	if len(balances) == 0 {
+		return nil
+	}
+
+	stmt := "INSERT INTO cw20token_balance (address, token, balance, height) VALUES "
 	var params []interface{}
 	for i, b := range balances {
-		n := i * 3
-		stmt += fmt.Sprintf("($%d, $%d, $%d),", n+1, n+2, n+3)
-		params = append(params, b.Address, token, b.Amount)
+		n := i * 4
+		stmt += fmt.Sprintf("($%d, $%d, $%d, $%d),", n+1, n+2, n+3, n+4)
+		params = append(params, b.Address, token, b.Amount, height)
 	}
 
 	stmt = stmt[:len(stmt)-1]
-	stmt += `ON CONFLICT (address, token) DO UPDATE SET balance = excluded.balance`
+	stmt += `
+ON CONFLICT (address, token) DO UPDATE 
+	SET balance = excluded.balance,
+	    height = excluded.height
+WHERE cw20token_balance.height <= excluded.height`
 	_, err := dbTx.Exec(stmt, params...)
 	if err != nil {
 		return err
@@ -45,6 +55,31 @@ func (dbTx *DbTx) SaveBalances(token string, balances []types.TokenBalance) erro
 	return err
 }
 
+func (dbTx *DbTx) SaveTransfer(token string, t types.TokenTransfer) error {
+	_, err := dbTx.Exec(
+		`INSERT INTO cw20token_transfer_history VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9) ON CONFLICT DO NOTHING`,
+		token, t.Type, t.From, t.To, t.Amount, t.Height, t.TxHash, t.MsgIndex, t.EventIndex,
+	)
+	return err
+}
+
+func (dbTx *DbTx) SaveAllowance(token string, a types.Allowance) error {
+	if a.Amount == "0" {
+		_, err := dbTx.Exec(
+			`DELETE FROM cw20token_allowance WHERE token = $1 AND owner = $2 AND spender = $3`,
+			token, a.Owner, a.Spender,
+		)
+		return err
+	}
+
+	_, err := dbTx.Exec(
+		`INSERT INTO cw20token_allowance VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (token, owner, spender) DO UPDATE SET amount = excluded.amount, expires = excluded.expires`,
+		token, a.Owner, a.Spender, a.Amount, toJSONB(a.Expires),
+	)
+	return err
+}
+
 func (dbTx *DbTx) UpdateSupply(token string, supply string) error {
 	_, err := dbTx.Exec(`UPDATE cw20token_info SET circulating_supply = $1 WHERE address = $2`, supply, token)
 	return err
@@ -68,6 +103,13 @@ func (dbTx *DbTx) UpdateLogo(token string, logo string) error {
 	return err
 }
 
+func (dbTx *DbTx) UpdateTokenType(token string, tokenType string, maxSupply string) error {
+	_, err := dbTx.Exec(
+		`UPDATE cw20token_info SET type = $1, max_supply = $2 WHERE address = $3`, tokenType, maxSupply, token,
+	)
+	return err
+}
+
 func (dbTx *DbTx) UpdateCodeID(token string, codeID uint64) error {
 	_, err := dbTx.Exec(`UPDATE cw20token_info SET code_id = $1 WHERE address = $2`, codeID, token)
 	return err
@@ -93,3 +135,15 @@ func (dbTx *DbTx) CodeIDExists(codeID uint64) (bool, error) {
 	).Scan(&found)
 	return found, err
 }
+
+func (db *Db) GetTokenAddresses() ([]string, error) {
+	var addresses []string
+	err := db.Sqlx.Select(&addresses, `SELECT address FROM cw20token_info`)
+	return addresses, err
+}
+
+func (db *Db) GetBalances(token string) ([]types.TokenBalance, error) {
+	var balances []types.TokenBalance
+	err := db.Sqlx.Select(&balances, `SELECT address, balance AS amount FROM cw20token_balance WHERE token = $1`, token)
+	return balances, err
+}
@@ -0,0 +1,146 @@
+package database
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/forbole/bdjuno/v2/types"
+)
+
+func (dbTx *DbTx) SaveCW721CodeID(codeID uint64) error {
+	_, err := dbTx.Exec(`INSERT INTO cw721token_code_id VALUES ($1) ON CONFLICT DO NOTHING`, codeID)
+	return err
+}
+
+func (dbTx *DbTx) SaveCW721Collection(c types.CW721Collection) error {
+	_, err := dbTx.Exec(
+		`INSERT INTO cw721token_collection VALUES ($1, $2, $3, $4, $5, $6, $7)
+		ON CONFLICT (address) DO UPDATE SET
+		code_id = excluded.code_id, name = excluded.name, symbol = excluded.symbol,
+		minter = excluded.minter, num_tokens = excluded.num_tokens`,
+		c.Address, c.CodeID, c.Name, c.Symbol, c.Minter, c.NumTokens, c.Creator,
+	)
+
+	return err
+}
+
+func (dbTx *DbTx) SaveCW721Nfts(collection string, nfts []types.CW721Nft) error {
+	for _, n := range nfts {
+		_, err := dbTx.Exec(
+			`INSERT INTO cw721token_nft VALUES ($1, $2, $3, $4, $5, false)
+			ON CONFLICT (collection, token_id) DO UPDATE SET
+			owner = excluded.owner, token_uri = excluded.token_uri, extension = excluded.extension, burned = false`,
+			collection, n.TokenID, n.Owner, n.TokenURI, toJSONB(n.Extension),
+		)
+		if err != nil {
+			return err
+		}
+
+		if err := dbTx.saveCW721Approvals(collection, n.TokenID, n.Approvals); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (dbTx *DbTx) saveCW721Approvals(collection string, tokenID string, approvals []types.CW721Approval) error {
+	_, err := dbTx.Exec(`DELETE FROM cw721token_approval WHERE collection = $1 AND token_id = $2`, collection, tokenID)
+	if err != nil {
+		return err
+	}
+
+	for _, a := range approvals {
+		_, err := dbTx.Exec(
+			`INSERT INTO cw721token_approval VALUES ($1, $2, $3, $4)`,
+			collection, tokenID, a.Spender, toJSONB(a.Expires),
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (dbTx *DbTx) BurnCW721Nft(collection string, tokenID string) error {
+	if err := dbTx.saveCW721Approvals(collection, tokenID, nil); err != nil {
+		return err
+	}
+
+	_, err := dbTx.Exec(`UPDATE cw721token_nft SET burned = true WHERE collection = $1 AND token_id = $2`, collection, tokenID)
+	return err
+}
+
+func (dbTx *DbTx) GetCW721NftOwner(collection string, tokenID string) (string, error) {
+	var owner string
+	err := dbTx.QueryRow(
+		`SELECT COALESCE((SELECT owner FROM cw721token_nft WHERE collection = $1 AND token_id = $2), '')`, collection, tokenID,
+	).Scan(&owner)
+	return owner, err
+}
+
+func (dbTx *DbTx) SaveCW721Operator(collection string, o types.CW721Operator) error {
+	_, err := dbTx.Exec(
+		`INSERT INTO cw721token_operator VALUES ($1, $2, $3, $4)
+		ON CONFLICT (collection, owner, operator) DO UPDATE SET expires = excluded.expires`,
+		collection, o.Owner, o.Operator, toJSONB(o.Expires),
+	)
+	return err
+}
+
+func (dbTx *DbTx) DeleteCW721Operator(collection string, owner string, operator string) error {
+	_, err := dbTx.Exec(
+		`DELETE FROM cw721token_operator WHERE collection = $1 AND owner = $2 AND operator = $3`,
+		collection, owner, operator,
+	)
+	return err
+}
+
+func (dbTx *DbTx) SaveCW721History(collection string, h types.CW721History) error {
+	_, err := dbTx.Exec(
+		`INSERT INTO cw721token_history VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9) ON CONFLICT DO NOTHING`,
+		collection, h.TokenID, h.Type, h.Sender, h.From, h.To, h.Height, h.TxHash, h.MsgIndex,
+	)
+	return err
+}
+
+func (dbTx *DbTx) UpdateCW721NumTokens(collection string, numTokens uint64) error {
+	_, err := dbTx.Exec(`UPDATE cw721token_collection SET num_tokens = $1 WHERE address = $2`, numTokens, collection)
+	return err
+}
+
+func (dbTx *DbTx) UpdateCW721CodeID(collection string, codeID uint64) error {
+	_, err := dbTx.Exec(`UPDATE cw721token_collection SET code_id = $1 WHERE address = $2`, codeID, collection)
+	return err
+}
+
+func (dbTx *DbTx) DeleteCW721Collection(collection string) error {
+	_, err := dbTx.Exec(`DELETE FROM cw721token_collection WHERE address = $1`, collection)
+	return err
+}
+
+func (dbTx *DbTx) CW721CollectionExists(collection string) (bool, error) {
+	var found bool
+	err := dbTx.QueryRow(
+		`SELECT EXISTS (SELECT 1 FROM cw721token_collection WHERE address = $1)`, collection,
+	).Scan(&found)
+	return found, err
+}
+
+func (dbTx *DbTx) CW721CodeIDExists(codeID uint64) (bool, error) {
+	var found bool
+	err := dbTx.QueryRow(
+		`SELECT EXISTS (SELECT 1 FROM cw721token_code_id WHERE id = $1)`, codeID,
+	).Scan(&found)
+	return found, err
+}
+
+// toJSONB returns the given raw message as a value that can be stored inside a JSONB column
+func toJSONB(raw json.RawMessage) string {
+	if len(raw) == 0 || string(raw) == "null" {
+		return "{}"
+	}
+
+	return strings.ToValidUTF8(string(raw), "")
+}
@@ -1,6 +1,7 @@
 package database
 
 import (
+	"database/sql"
 	"encoding/json"
 	"fmt"
 
@@ -204,6 +205,21 @@ func (db *Db) GetProposal(id uint64) (*types.Proposal, error) {
 	return &proposal, nil
 }
 
+// GetProposalStatus returns the status of the proposal having the given id, or an empty string if not found
+func (db *Db) GetProposalStatus(id uint64) (string, error) {
+	var statuses []sql.NullString
+	err := db.Sqlx.Select(&statuses, `SELECT status FROM proposal WHERE id = $1`, id)
+	if err != nil {
+		return "", err
+	}
+
+	if len(statuses) == 0 {
+		return "", nil
+	}
+
+	return dbtypes.ToString(statuses[0]), nil
+}
+
 // GetOpenProposalsIds returns all the ids of the proposals that are currently in deposit or voting period
 func (db *Db) GetOpenProposalsIds() ([]uint64, error) {
 	var ids []uint64
@@ -1,37 +1,281 @@
 package database
 
-func (db *Db) SaveOrchestrator(address string) error {
-	_, err := db.Sql.Exec(`INSERT INTO gravity_orchestrator (address) VALUES($1) ON CONFLICT DO NOTHING`, address)
-	return err
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+
+	"github.com/lib/pq"
+
+	dbtypes "github.com/forbole/bdjuno/v2/database/types"
+	"github.com/forbole/bdjuno/v2/types"
+)
+
+// SaveOrchestrator stores the given registration of an orchestrator, keeping the latest one as the validator
+// that currently owns the orchestrator
+func (db *Db) SaveOrchestrator(orchestrator types.GravityOrchestrator) error {
+	return db.ExecuteTx(func(dbTx *DbTx) error {
+		_, err := dbTx.Exec(`INSERT INTO gravity_orchestrator (address, validator_address, eth_address, height, transaction_hash) 
+			VALUES($1, $2, $3, $4, $5) ON CONFLICT (address) DO UPDATE SET 
+			validator_address = excluded.validator_address, eth_address = excluded.eth_address, 
+			height = excluded.height, transaction_hash = excluded.transaction_hash
+			WHERE gravity_orchestrator.height <= excluded.height`,
+			orchestrator.Address, orchestrator.ValidatorAddress, orchestrator.EthAddress, orchestrator.Height,
+			dbtypes.ToNullString(orchestrator.TxHash))
+		if err != nil {
+			return err
+		}
+
+		_, err = dbTx.Exec(`INSERT INTO gravity_orchestrator_registration (address, validator_address, eth_address, height, transaction_hash) 
+			VALUES($1, $2, $3, $4, $5) ON CONFLICT DO NOTHING`,
+			orchestrator.Address, orchestrator.ValidatorAddress, orchestrator.EthAddress, orchestrator.Height,
+			dbtypes.ToNullString(orchestrator.TxHash))
+		return err
+	})
 }
 
-func (db *Db) GetOrchestratorsCount() (int, error) {
-	var count int
-	err := db.Sql.QueryRow(`SELECT COUNT(*) FROM gravity_orchestrator`).Scan(&count)
-	if err != nil {
-		return 0, err
+// GetOrchestratorValidator returns the operator address of the validator that has registered the given
+// orchestrator most recently at or before the given height, or an empty string if no such registration exists
+func (db *Db) GetOrchestratorValidator(orchestrator string, height int64) (string, error) {
+	var validator string
+	err := db.Sql.QueryRow(`SELECT validator_address FROM gravity_orchestrator_registration 
+		WHERE address = $1 AND height <= $2 ORDER BY height DESC LIMIT 1`,
+		orchestrator, height).Scan(&validator)
+	if err == sql.ErrNoRows {
+		return "", nil
 	}
-	return count, nil
+	return validator, err
 }
 
-func (db *Db) SaveMsgSendToCosmosClaim(transactionHash, msgType, attestationID, receiver, orchestrator string, height int64) error {
-	_, err := db.Sql.Exec(`INSERT INTO gravity_transaction AS gt (type, attestation_id, orchestrator, receiver, votes, consensus, transaction_hash, height) 
-		VALUES($1, $2, $3, $4, $5, $6, $7, $8) ON CONFLICT (attestation_id) DO UPDATE SET 
-		orchestrator = excluded.orchestrator, transaction_hash = excluded.transaction_hash, votes = gt.votes + 1`,
-		msgType, attestationID, orchestrator, receiver, 1, false, transactionHash, height)
-	return err
+// SaveStaticValidators stores the operator addresses of the validators allowed to take part in the bridge
+func (db *Db) SaveStaticValidators(operators []string) error {
+	for _, operator := range operators {
+		_, err := db.Sql.Exec(`INSERT INTO gravity_static_validator (operator_address) VALUES($1) ON CONFLICT DO NOTHING`, operator)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// GetGravityValidators returns the operator addresses of the validators attestations are weighted against
+// at the given height. These are the static validators when they are known, otherwise the validators that
+// have registered an orchestrator at or before the given height
+func (db *Db) GetGravityValidators(height int64) ([]string, error) {
+	var validators []string
+	err := db.Sqlx.Select(&validators, `SELECT operator_address FROM gravity_static_validator`)
+	if err != nil || len(validators) > 0 {
+		return validators, err
+	}
+
+	err = db.Sqlx.Select(&validators, `SELECT DISTINCT validator_address FROM gravity_orchestrator_registration 
+		WHERE validator_address != '' AND height <= $1`, height)
+	return validators, err
+}
+
+// SaveGravityClaim stores the given vote and adds it to the votes and power of the attestation it refers to.
+// Votes that have already been stored are ignored
+func (db *Db) SaveGravityClaim(msgType, receiver string, vote types.GravityAttestationVote) error {
+	return db.ExecuteTx(func(dbTx *DbTx) error {
+		_, err := dbTx.Exec(`INSERT INTO gravity_transaction (type, attestation_id, orchestrator, receiver, votes, power, consensus, transaction_hash, height) 
+			VALUES($1, $2, $3, $4, 0, 0, false, $5, $6) ON CONFLICT (attestation_id) DO NOTHING`,
+			msgType, vote.AttestationID, vote.Orchestrator, receiver, vote.TxHash, vote.Height)
+		if err != nil {
+			return err
+		}
+
+		res, err := dbTx.Exec(`INSERT INTO gravity_attestation_vote (attestation_id, orchestrator, validator_address, power, height, transaction_hash) 
+			VALUES($1, $2, $3, $4, $5, $6) ON CONFLICT (attestation_id, orchestrator) DO NOTHING`,
+			vote.AttestationID, vote.Orchestrator, vote.ValidatorAddress, vote.Power, vote.Height, vote.TxHash)
+		if err != nil {
+			return err
+		}
+
+		if rows, err := res.RowsAffected(); err != nil || rows == 0 {
+			return err
+		}
+
+		_, err = dbTx.Exec(`UPDATE gravity_transaction SET orchestrator = $1, transaction_hash = $2, 
+			votes = votes + 1, power = power + $3 WHERE attestation_id = $4`,
+			vote.Orchestrator, vote.TxHash, vote.Power, vote.AttestationID)
+		return err
+	})
 }
 
-func (db *Db) GetGravityTransactionVotes(attestationID string) (int, error) {
-	var votes int
-	err := db.Sql.QueryRow(`SELECT votes FROM gravity_transaction WHERE attestation_id = $1`, attestationID).Scan(&votes)
+func (db *Db) GetGravityTransactionPower(attestationID string) (int64, error) {
+	var power int64
+	err := db.Sql.QueryRow(`SELECT power FROM gravity_transaction WHERE attestation_id = $1`, attestationID).Scan(&power)
 	if err != nil {
 		return 0, err
 	}
-	return votes, nil
+	return power, nil
 }
 
 func (db *Db) SetGravityTransactionConsensus(attestationID string, consensus bool) error {
 	_, err := db.Sql.Exec(`UPDATE gravity_transaction SET consensus = $1 WHERE attestation_id = $2`, consensus, attestationID)
 	return err
 }
+
+// SaveGravityValsetConfirm stores the signature of the given orchestrator over the valset with the given nonce
+func (db *Db) SaveGravityValsetConfirm(nonce uint64, orchestrator, ethAddress string, height int64, txHash string) error {
+	_, err := db.Sql.Exec(`INSERT INTO gravity_valset_confirm (nonce, orchestrator, eth_address, height, transaction_hash) 
+		VALUES($1, $2, $3, $4, $5) ON CONFLICT (nonce, orchestrator) DO NOTHING`,
+		nonce, orchestrator, ethAddress, height, txHash)
+	return err
+}
+
+// SaveGravityValsetUpdate stores the given valset update, keeping the first claim received for each attestation
+func (db *Db) SaveGravityValsetUpdate(update types.GravityValsetUpdate) error {
+	membersBz, err := json.Marshal(update.Members)
+	if err != nil {
+		return fmt.Errorf("error while marshaling valset members: %s", err)
+	}
+
+	_, err = db.Sql.Exec(`INSERT INTO gravity_valset_update (valset_nonce, attestation_id, members, reward_amount, reward_token, eth_block_height, height, transaction_hash) 
+		VALUES($1, $2, $3, $4, $5, $6, $7, $8) ON CONFLICT (valset_nonce, attestation_id) DO NOTHING`,
+		update.ValsetNonce, update.AttestationID, string(membersBz), update.RewardAmount, update.RewardToken,
+		update.EthBlockHeight, update.Height, update.TxHash)
+	return err
+}
+
+// SaveGravityOutgoingTransfer stores the given transfer as pending inside the outgoing pool
+func (db *Db) SaveGravityOutgoingTransfer(transfer types.GravityOutgoingTransfer) error {
+	_, err := db.Sql.Exec(`INSERT INTO gravity_outgoing_transfer (id, sender, eth_dest, denom, amount, bridge_fee, status, height, transaction_hash) 
+		VALUES($1, $2, $3, $4, $5, $6, $7, $8, $9) ON CONFLICT (id) DO NOTHING`,
+		transfer.ID, transfer.Sender, transfer.EthDest, transfer.Amount.Denom, transfer.Amount.Amount.String(),
+		transfer.BridgeFee.Amount.String(), types.GravityTransferStatusPending, transfer.Height, transfer.TxHash)
+	return err
+}
+
+// CancelGravityOutgoingTransfer marks the transfer with the given id as cancelled. Only unbatched transfers can be cancelled
+func (db *Db) CancelGravityOutgoingTransfer(id uint64, txHash string) error {
+	_, err := db.Sql.Exec(`UPDATE gravity_outgoing_transfer SET status = $1, cancel_transaction_hash = $2 WHERE id = $3 AND status = $4`,
+		types.GravityTransferStatusCancelled, txHash, id, types.GravityTransferStatusPending)
+	return err
+}
+
+// SaveGravityBatch stores the batch with the given nonce and links to it the pending transfers of the same denom,
+// picking them the same way the chain does: highest bridge fee first, newest first on equal fees
+func (db *Db) SaveGravityBatch(nonce uint64, denom, requester string, height int64, txHash string) error {
+	return db.ExecuteTx(func(dbTx *DbTx) error {
+		res, err := dbTx.Exec(`INSERT INTO gravity_batch (nonce, denom, requester, status, height, transaction_hash) 
+			VALUES($1, $2, $3, $4, $5, $6) ON CONFLICT (nonce) DO NOTHING`,
+			nonce, denom, requester, types.GravityBatchStatusPending, height, txHash)
+		if err != nil {
+			return err
+		}
+
+		// The batch has already been stored, its transfers must not be picked again
+		if rows, err := res.RowsAffected(); err != nil || rows == 0 {
+			return err
+		}
+
+		_, err = dbTx.Exec(`UPDATE gravity_outgoing_transfer SET status = $1, batch_nonce = $2 WHERE id IN (
+			SELECT id FROM gravity_outgoing_transfer WHERE denom = $3 AND status = $4 
+			ORDER BY bridge_fee DESC, id DESC LIMIT $5)`,
+			types.GravityTransferStatusBatched, nonce, denom, types.GravityTransferStatusPending, types.GravityOutgoingTxBatchSize)
+		return err
+	})
+}
+
+// SaveGravityBatchFromChain stores the given batch as read from the chain, linking to it the transfers it contains.
+// This is used for the batches the chain creates by itself, which have no requester nor transaction
+func (db *Db) SaveGravityBatchFromChain(batch types.GravityBatch) error {
+	return db.ExecuteTx(func(dbTx *DbTx) error {
+		res, err := dbTx.Exec(`INSERT INTO gravity_batch (nonce, denom, token_contract, requester, status, height) 
+			VALUES($1, $2, $3, '', $4, $5) ON CONFLICT (nonce) DO NOTHING`,
+			batch.Nonce, batch.Denom, batch.TokenContract, types.GravityBatchStatusPending, batch.Height)
+		if err != nil {
+			return err
+		}
+
+		if rows, err := res.RowsAffected(); err != nil || rows == 0 {
+			return err
+		}
+
+		transferIDs := make([]int64, len(batch.TransferIDs))
+		for i, id := range batch.TransferIDs {
+			transferIDs[i] = int64(id)
+		}
+
+		_, err = dbTx.Exec(`UPDATE gravity_outgoing_transfer SET status = $1, batch_nonce = $2 WHERE id = ANY($3)`,
+			types.GravityTransferStatusBatched, batch.Nonce, pq.Array(transferIDs))
+		return err
+	})
+}
+
+// HasGravityBatch tells whether the batch with the given nonce has been stored
+func (db *Db) HasGravityBatch(nonce uint64) (bool, error) {
+	var exists bool
+	err := db.Sql.QueryRow(`SELECT EXISTS(SELECT 1 FROM gravity_batch WHERE nonce = $1)`, nonce).Scan(&exists)
+	return exists, err
+}
+
+// CancelGravityBatch marks the pending batch with the given nonce as cancelled, returning its transfers to the pool
+func (db *Db) CancelGravityBatch(nonce uint64) error {
+	return db.ExecuteTx(func(dbTx *DbTx) error {
+		res, err := dbTx.Exec(`UPDATE gravity_batch SET status = $1 WHERE nonce = $2 AND status = $3`,
+			types.GravityBatchStatusCancelled, nonce, types.GravityBatchStatusPending)
+		if err != nil {
+			return err
+		}
+
+		if rows, err := res.RowsAffected(); err != nil || rows == 0 {
+			return err
+		}
+
+		_, err = dbTx.Exec(`UPDATE gravity_outgoing_transfer SET status = $1, batch_nonce = NULL WHERE batch_nonce = $2`,
+			types.GravityTransferStatusPending, nonce)
+		return err
+	})
+}
+
+// SaveGravityBatchConfirm stores the signature of the given orchestrator over the batch with the given nonce
+func (db *Db) SaveGravityBatchConfirm(nonce uint64, tokenContract, orchestrator, ethSigner string, height int64, txHash string) error {
+	return db.ExecuteTx(func(dbTx *DbTx) error {
+		_, err := dbTx.Exec(`INSERT INTO gravity_batch_confirm (batch_nonce, token_contract, orchestrator, eth_signer, height, transaction_hash) 
+			VALUES($1, $2, $3, $4, $5, $6) ON CONFLICT (batch_nonce, orchestrator) DO NOTHING`,
+			nonce, tokenContract, orchestrator, ethSigner, height, txHash)
+		if err != nil {
+			return err
+		}
+
+		_, err = dbTx.Exec(`UPDATE gravity_batch SET token_contract = $1 WHERE nonce = $2`, tokenContract, nonce)
+		return err
+	})
+}
+
+// SetGravityBatchExecuted marks the batch with the given nonce and its transfers as executed. As the chain does,
+// the older batches of the same token still pending are cancelled and their transfers are returned to the pool
+func (db *Db) SetGravityBatchExecuted(nonce uint64, tokenContract string, height int64) error {
+	return db.ExecuteTx(func(dbTx *DbTx) error {
+		var denom string
+		err := dbTx.QueryRow(`UPDATE gravity_batch SET status = $1, token_contract = $2, executed_height = $3 
+			WHERE nonce = $4 AND status = $5 RETURNING denom`,
+			types.GravityBatchStatusExecuted, tokenContract, height, nonce, types.GravityBatchStatusPending).Scan(&denom)
+		if err == sql.ErrNoRows {
+			// The batch is unknown or has already been executed
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		_, err = dbTx.Exec(`UPDATE gravity_outgoing_transfer SET status = $1, batch_nonce = NULL WHERE batch_nonce IN (
+			SELECT nonce FROM gravity_batch WHERE denom = $2 AND nonce < $3 AND status = $4)`,
+			types.GravityTransferStatusPending, denom, nonce, types.GravityBatchStatusPending)
+		if err != nil {
+			return err
+		}
+
+		_, err = dbTx.Exec(`UPDATE gravity_batch SET status = $1 WHERE denom = $2 AND nonce < $3 AND status = $4`,
+			types.GravityBatchStatusCancelled, denom, nonce, types.GravityBatchStatusPending)
+		if err != nil {
+			return err
+		}
+
+		_, err = dbTx.Exec(`UPDATE gravity_outgoing_transfer SET status = $1 WHERE batch_nonce = $2`,
+			types.GravityTransferStatusExecuted, nonce)
+		return err
+	})
+}
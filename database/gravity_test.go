@@ -1,28 +1,56 @@
 package database_test
 
-import dbtypes "github.com/forbole/bdjuno/v2/database/types"
+import (
+	gravitytypes "github.com/althea-net/cosmos-gravity-bridge/module/x/gravity/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	dbtypes "github.com/forbole/bdjuno/v2/database/types"
+	"github.com/forbole/bdjuno/v2/types"
+)
 
 const (
 	testOrchestrator1 = "cudos1a326k254fukx9jlp0h3fwcr2ymjgludzum67d1"
 	testOrchestrator2 = "cudos1a326k254fukx9jlp0h3fwcr2ymjgludzum67d2"
+
+	testGravityValidator1 = "cudosvaloper1validator1"
+	testGravityValidator2 = "cudosvaloper1validator2"
 )
 
 func (suite *DbTestSuite) TestGravity() {
-	testGravity_GetOrchestratorsCount(suite, 0)
+	testGravity_GetGravityValidators(suite, 10, nil)
 	testGravity_SaveOrchestrator(suite)
-	testGravity_GetOrchestratorsCount(suite, 2)
-	testGravity_SaveMsgSendToCosmosClaim(suite)
-	testGravity_GetGravityTransactionVotes(suite)
+	testGravity_GetOrchestratorValidator(suite)
+	testGravity_GetGravityValidators(suite, 1, []string{testGravityValidator1})
+	testGravity_GetGravityValidators(suite, 10, []string{testGravityValidator1, testGravityValidator2})
+	testGravity_SaveOrchestratorRegistrations(suite)
+	testGravity_SaveStaticValidators(suite)
+	testGravity_SaveGravityClaim(suite)
+	testGravity_GetGravityTransactionPower(suite)
 	testGravity_SetGravityTransactionConsensus(suite)
 }
 
 func testGravity_SaveOrchestrator(suite *DbTestSuite) {
-	err := suite.database.SaveOrchestrator(testOrchestrator1)
+	txHash := "txhash#orchestrator"
+	insertDummyTransaction(suite, 5, txHash)
+
+	orchestrator1 := types.GravityOrchestrator{
+		Address:          testOrchestrator1,
+		ValidatorAddress: testGravityValidator1,
+		EthAddress:       "0xeth1",
+		Height:           1,
+	}
+	err := suite.database.SaveOrchestrator(orchestrator1)
 	suite.Require().NoError(err)
-	err = suite.database.SaveOrchestrator(testOrchestrator1)
+	err = suite.database.SaveOrchestrator(orchestrator1)
 	suite.Require().NoError(err)
 
-	err = suite.database.SaveOrchestrator(testOrchestrator2)
+	err = suite.database.SaveOrchestrator(types.GravityOrchestrator{
+		Address:          testOrchestrator2,
+		ValidatorAddress: testGravityValidator2,
+		EthAddress:       "0xeth2",
+		Height:           5,
+		TxHash:           txHash,
+	})
 	suite.Require().NoError(err)
 
 	var rows []dbtypes.GravityOrchestratorRow
@@ -31,45 +59,129 @@ func testGravity_SaveOrchestrator(suite *DbTestSuite) {
 
 	suite.Require().Equal([]dbtypes.GravityOrchestratorRow{
 		{
-			Address: testOrchestrator1,
+			Address:          testOrchestrator1,
+			ValidatorAddress: testGravityValidator1,
+			EthAddress:       "0xeth1",
+			Height:           1,
 		},
 		{
-			Address: testOrchestrator2,
+			Address:          testOrchestrator2,
+			ValidatorAddress: testGravityValidator2,
+			EthAddress:       "0xeth2",
+			Height:           5,
+			TransactionHash:  dbtypes.ToNullString(txHash),
 		},
 	}, rows)
 }
 
-func testGravity_GetOrchestratorsCount(suite *DbTestSuite, expectedValue int) {
-	count, err := suite.database.GetOrchestratorsCount()
+func testGravity_GetOrchestratorValidator(suite *DbTestSuite) {
+	validator, err := suite.database.GetOrchestratorValidator(testOrchestrator2, 4)
 	suite.Require().NoError(err)
-	suite.Require().Equal(expectedValue, count)
+	suite.Require().Empty(validator)
+
+	validator, err = suite.database.GetOrchestratorValidator(testOrchestrator2, 5)
+	suite.Require().NoError(err)
+	suite.Require().Equal(testGravityValidator2, validator)
+
+	validator, err = suite.database.GetOrchestratorValidator("unknown", 5)
+	suite.Require().NoError(err)
+	suite.Require().Empty(validator)
 }
 
-func testGravity_SaveMsgSendToCosmosClaim(suite *DbTestSuite) {
+func testGravity_SaveOrchestratorRegistrations(suite *DbTestSuite) {
+	err := suite.database.SaveOrchestrator(types.GravityOrchestrator{
+		Address:          testOrchestrator1,
+		ValidatorAddress: testGravityValidator2,
+		EthAddress:       "0xeth3",
+		Height:           20,
+	})
+	suite.Require().NoError(err)
+
+	// Re-parsing the first registration must not replace the latest one
+	err = suite.database.SaveOrchestrator(types.GravityOrchestrator{
+		Address:          testOrchestrator1,
+		ValidatorAddress: testGravityValidator1,
+		EthAddress:       "0xeth1",
+		Height:           1,
+	})
+	suite.Require().NoError(err)
+
+	suite.Require().Equal(2, suite.countRows("SELECT COUNT(*) FROM gravity_orchestrator_registration WHERE address = '"+testOrchestrator1+"'"))
+
+	var row dbtypes.GravityOrchestratorRow
+	err = suite.database.Sqlx.Get(&row, "SELECT * FROM gravity_orchestrator WHERE address = $1", testOrchestrator1)
+	suite.Require().NoError(err)
+	suite.Require().Equal(testGravityValidator2, row.ValidatorAddress)
+	suite.Require().Equal(int64(20), row.Height)
+
+	validator, err := suite.database.GetOrchestratorValidator(testOrchestrator1, 19)
+	suite.Require().NoError(err)
+	suite.Require().Equal(testGravityValidator1, validator)
+
+	validator, err = suite.database.GetOrchestratorValidator(testOrchestrator1, 20)
+	suite.Require().NoError(err)
+	suite.Require().Equal(testGravityValidator2, validator)
+}
+
+func testGravity_GetGravityValidators(suite *DbTestSuite, height int64, expected []string) {
+	validators, err := suite.database.GetGravityValidators(height)
+	suite.Require().NoError(err)
+	suite.Require().ElementsMatch(expected, validators)
+}
+
+func testGravity_SaveStaticValidators(suite *DbTestSuite) {
+	err := suite.database.SaveStaticValidators([]string{testGravityValidator2})
+	suite.Require().NoError(err)
+	err = suite.database.SaveStaticValidators([]string{testGravityValidator2})
+	suite.Require().NoError(err)
+
+	// Static validators take precedence over the registered orchestrators
+	testGravity_GetGravityValidators(suite, 10, []string{testGravityValidator2})
+}
+
+func testGravity_SaveGravityClaim(suite *DbTestSuite) {
 	txHash := "txhash#31337"
 	var height int64 = 1
 	insertDummyTransaction(suite, height, txHash)
 
-	err := suite.database.SaveMsgSendToCosmosClaim(txHash, "SendToCosmosClaim", "1", "me", "you", height)
+	vote := func(attestationID, orchestrator, validator string, power int64) types.GravityAttestationVote {
+		return types.GravityAttestationVote{
+			AttestationID:    attestationID,
+			Orchestrator:     orchestrator,
+			ValidatorAddress: validator,
+			Power:            power,
+			Height:           height,
+			TxHash:           txHash,
+		}
+	}
+
+	err := suite.database.SaveGravityClaim("SendToCosmosClaim", "me", vote("1", "you", "", 10))
 	suite.Require().NotNil(err)
 
-	err = suite.database.SaveMsgSendToCosmosClaim(txHash, "SendToCosmosClaim", "1", "me", testOrchestrator1, height)
+	err = suite.database.SaveGravityClaim("SendToCosmosClaim", "me", vote("1", testOrchestrator1, testGravityValidator1, 10))
 	suite.Require().Nil(err)
-	err = suite.database.SaveMsgSendToCosmosClaim(txHash, "SendToCosmosClaim", "1", "me", testOrchestrator1, height)
+	err = suite.database.SaveGravityClaim("SendToCosmosClaim", "me", vote("1", testOrchestrator2, testGravityValidator2, 20))
 	suite.Require().Nil(err)
 
-	err = suite.database.SaveMsgSendToCosmosClaim(txHash, "SendToCosmosClaim", "2", "me", testOrchestrator1, height)
+	// Votes stored twice are counted once
+	err = suite.database.SaveGravityClaim("SendToCosmosClaim", "me", vote("1", testOrchestrator2, testGravityValidator2, 20))
 	suite.Require().Nil(err)
+
+	err = suite.database.SaveGravityClaim("SendToCosmosClaim", "me", vote("2", testOrchestrator1, testGravityValidator1, 10))
+	suite.Require().Nil(err)
+
+	suite.Require().Equal(3, suite.countRows("SELECT COUNT(*) FROM gravity_attestation_vote"))
+	suite.Require().Equal(2, suite.countRows(`SELECT COUNT(*) FROM gravity_attestation_vote WHERE attestation_id = '1'`))
 }
 
-func testGravity_GetGravityTransactionVotes(suite *DbTestSuite) {
-	votes, err := suite.database.GetGravityTransactionVotes("1")
+func testGravity_GetGravityTransactionPower(suite *DbTestSuite) {
+	power, err := suite.database.GetGravityTransactionPower("1")
 	suite.Require().Nil(err)
-	suite.Require().Equal(2, votes)
+	suite.Require().Equal(int64(30), power)
 
-	votes, err = suite.database.GetGravityTransactionVotes("2")
+	power, err = suite.database.GetGravityTransactionPower("2")
 	suite.Require().Nil(err)
-	suite.Require().Equal(1, votes)
+	suite.Require().Equal(int64(10), power)
 }
 
 func testGravity_SetGravityTransactionConsensus(suite *DbTestSuite) {
@@ -81,6 +193,134 @@ func testGravity_SetGravityTransactionConsensus(suite *DbTestSuite) {
 	suite.Require().Nil(err)
 	suite.Require().Equal("1", rows[0].AttestationID)
 	suite.Require().Equal(true, rows[0].Consensus)
+	suite.Require().Equal(2, rows[0].Votes)
 	suite.Require().Equal("2", rows[1].AttestationID)
 	suite.Require().Equal(false, rows[1].Consensus)
 }
+
+func (suite *DbTestSuite) TestGravity_OutgoingTransfers() {
+	const denom = "acudos"
+	sender := "cudos1sender"
+	txHash := "txhash#outgoing"
+	var height int64 = 1
+	insertDummyTransaction(suite, height, txHash)
+
+	for id, fee := range map[uint64]int64{1: 10, 2: 5, 3: 10, 4: 1} {
+		err := suite.database.SaveGravityOutgoingTransfer(types.GravityOutgoingTransfer{
+			ID:        id,
+			Sender:    sender,
+			EthDest:   "0xdest",
+			Amount:    sdk.NewInt64Coin(denom, 100),
+			BridgeFee: sdk.NewInt64Coin(denom, fee),
+			Height:    height,
+			TxHash:    txHash,
+		})
+		suite.Require().NoError(err)
+	}
+
+	suite.Require().NoError(suite.database.CancelGravityOutgoingTransfer(4, txHash))
+
+	// First batch, picking all the pending transfers of the denom
+	suite.Require().NoError(suite.database.SaveGravityBatch(1, denom, sender, height, txHash))
+	suite.Require().Equal(map[uint64]string{1: "batched", 2: "batched", 3: "batched", 4: "cancelled"}, gravityTransfersStatus(suite))
+
+	// Re-parsing the batch does not pick new transfers
+	suite.Require().NoError(suite.database.SaveGravityOutgoingTransfer(types.GravityOutgoingTransfer{
+		ID: 5, Sender: sender, EthDest: "0xdest", Amount: sdk.NewInt64Coin(denom, 100),
+		BridgeFee: sdk.NewInt64Coin(denom, 1), Height: height, TxHash: txHash,
+	}))
+	suite.Require().NoError(suite.database.SaveGravityBatch(1, denom, sender, height, txHash))
+	suite.Require().Equal("pending", gravityTransfersStatus(suite)[5])
+
+	// Batched transfers can not be cancelled
+	suite.Require().NoError(suite.database.CancelGravityOutgoingTransfer(1, txHash))
+	suite.Require().Equal("batched", gravityTransfersStatus(suite)[1])
+
+	// Second batch, confirmed and executed
+	suite.Require().NoError(suite.database.SaveGravityBatch(2, denom, sender, height, txHash))
+	suite.Require().NoError(suite.database.SaveGravityBatchConfirm(2, "0xtoken", testOrchestrator1, "0xsigner", height, txHash))
+	suite.Require().NoError(suite.database.SaveGravityBatchConfirm(2, "0xtoken", testOrchestrator1, "0xsigner", height, txHash))
+	suite.Require().NoError(suite.database.SetGravityBatchExecuted(2, "0xtoken", height))
+	suite.Require().NoError(suite.database.SetGravityBatchExecuted(2, "0xtoken", height))
+
+	// The older batch has been cancelled and its transfers returned to the pool
+	suite.Require().Equal(map[uint64]string{1: "pending", 2: "pending", 3: "pending", 4: "cancelled", 5: "executed"}, gravityTransfersStatus(suite))
+
+	var batches []dbtypes.GravityBatchRow
+	err := suite.database.Sqlx.Select(&batches, "SELECT * FROM gravity_batch ORDER BY nonce")
+	suite.Require().NoError(err)
+	suite.Require().Len(batches, 2)
+	suite.Require().Equal("cancelled", batches[0].Status)
+	suite.Require().Equal("executed", batches[1].Status)
+	suite.Require().Equal("0xtoken", batches[1].TokenContract)
+	suite.Require().Equal(height, batches[1].ExecutedHeight.Int64)
+
+	suite.Require().Equal(1, suite.countRows("SELECT COUNT(*) FROM gravity_batch_confirm"))
+
+	// Batch created by the chain, linking exactly the transfers it contains
+	batch := types.GravityBatch{Nonce: 3, Denom: denom, TokenContract: "0xtoken", TransferIDs: []uint64{1, 3}, Height: height}
+	suite.Require().NoError(suite.database.SaveGravityBatchFromChain(batch))
+	suite.Require().NoError(suite.database.SaveGravityBatchFromChain(batch))
+	suite.Require().Equal(map[uint64]string{1: "batched", 2: "pending", 3: "batched", 4: "cancelled", 5: "executed"}, gravityTransfersStatus(suite))
+
+	exists, err := suite.database.HasGravityBatch(3)
+	suite.Require().NoError(err)
+	suite.Require().True(exists)
+
+	// Cancelled by the chain, returning its transfers to the pool
+	suite.Require().NoError(suite.database.CancelGravityBatch(3))
+	suite.Require().NoError(suite.database.CancelGravityBatch(3))
+	suite.Require().Equal(map[uint64]string{1: "pending", 2: "pending", 3: "pending", 4: "cancelled", 5: "executed"}, gravityTransfersStatus(suite))
+}
+
+func gravityTransfersStatus(suite *DbTestSuite) map[uint64]string {
+	var rows []dbtypes.GravityOutgoingTransferRow
+	err := suite.database.Sqlx.Select(&rows, "SELECT * FROM gravity_outgoing_transfer")
+	suite.Require().NoError(err)
+
+	statuses := make(map[uint64]string, len(rows))
+	for _, row := range rows {
+		statuses[row.ID] = row.Status
+	}
+	return statuses
+}
+
+func (suite *DbTestSuite) TestGravity_Valset() {
+	txHash := "txhash#valset"
+	var height int64 = 1
+	insertDummyTransaction(suite, height, txHash)
+
+	err := suite.database.SaveOrchestrator(types.GravityOrchestrator{Address: testOrchestrator1, ValidatorAddress: testGravityValidator1})
+	suite.Require().NoError(err)
+
+	suite.Require().NoError(suite.database.SaveGravityValsetConfirm(1, testOrchestrator1, "0xeth1", height, txHash))
+	suite.Require().NoError(suite.database.SaveGravityValsetConfirm(1, testOrchestrator1, "0xeth1", height, txHash))
+	suite.Require().Equal(1, suite.countRows("SELECT COUNT(*) FROM gravity_valset_confirm"))
+
+	err = suite.database.SaveGravityClaim("valset_updated_claim", "", types.GravityAttestationVote{
+		AttestationID:    "valset",
+		Orchestrator:     testOrchestrator1,
+		ValidatorAddress: testGravityValidator1,
+		Power:            10,
+		Height:           height,
+		TxHash:           txHash,
+	})
+	suite.Require().NoError(err)
+
+	update := types.GravityValsetUpdate{
+		ValsetNonce:    1,
+		AttestationID:  "valset",
+		Members:        []*gravitytypes.BridgeValidator{{Power: 100, EthereumAddress: "0xeth1"}},
+		RewardAmount:   "0",
+		EthBlockHeight: 1000,
+		Height:         height,
+		TxHash:         txHash,
+	}
+	suite.Require().NoError(suite.database.SaveGravityValsetUpdate(update))
+	suite.Require().NoError(suite.database.SaveGravityValsetUpdate(update))
+
+	var members string
+	err = suite.database.Sql.QueryRow(`SELECT members::TEXT FROM gravity_valset_update WHERE valset_nonce = 1`).Scan(&members)
+	suite.Require().NoError(err)
+	suite.Require().JSONEq(`[{"power": 100, "ethereum_address": "0xeth1"}]`, members)
+}
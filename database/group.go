@@ -1,6 +1,7 @@
 package database
 
 import (
+	"database/sql"
 	"fmt"
 	"time"
 
@@ -11,14 +12,40 @@ import (
 
 func (dbTx *DbTx) SaveGroup(group *types.Group) error {
 	_, err := dbTx.Exec(
-		`INSERT INTO group_with_policy VALUES ($1, $2, $3, $4, $5, $6, $7) ON CONFLICT DO NOTHING`,
-		group.ID, group.Address, group.GroupMetadata, group.PolicyMetadata,
-		group.Threshold, group.VotingPeriod, group.MinExecutionPeriod,
+		`INSERT INTO group_with_policy (id, address, admin, group_metadata, policy_metadata, threshold, voting_period, min_execution_period) 
+		VALUES ($1, '', $2, $3, '', 0, 0, 0) ON CONFLICT DO NOTHING`,
+		group.ID, group.Admin, group.Metadata,
+	)
+	return err
+}
+
+// SaveGroupPolicy stores the given policy, mirroring it inside group_with_policy when it is the first policy of its group
+func (dbTx *DbTx) SaveGroupPolicy(policy *types.GroupPolicy) error {
+	percentage := dbtypes.ToNullString(policy.Percentage)
+	_, err := dbTx.Exec(
+		`INSERT INTO group_policy (address, group_id, admin, metadata, threshold, voting_period, min_execution_period, percentage) 
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8) ON CONFLICT DO NOTHING`,
+		policy.Address, policy.GroupID, policy.Admin, policy.Metadata,
+		policy.Threshold, policy.VotingPeriod, policy.MinExecutionPeriod, percentage,
+	)
+	if err != nil {
+		return err
+	}
+
+	_, err = dbTx.Exec(
+		`UPDATE group_with_policy 
+		SET address = $1, policy_metadata = $2, threshold = $3, voting_period = $4, min_execution_period = $5, percentage = $6 
+		WHERE id = $7 AND address = ''`,
+		policy.Address, policy.Metadata, policy.Threshold, policy.VotingPeriod, policy.MinExecutionPeriod, percentage, policy.GroupID,
 	)
 	return err
 }
 
 func (dbTx *DbTx) SaveMembers(groupID uint64, members []*types.Member, timestamp time.Time) error {
+	if len(members) == 0 {
+		return nil
+	}
+
 	stmt := "INSERT INTO group_member VALUES "
 	var params []interface{}
 	for i, m := range members {
@@ -38,9 +65,10 @@ func (dbTx *DbTx) SaveMembers(groupID uint64, members []*types.Member, timestamp
 
 func (dbTx *DbTx) SaveProposal(proposal *types.GroupProposal) error {
 	_, err := dbTx.Exec(
-		`INSERT INTO group_proposal VALUES ($1, $2, $3, $4, $5, $6, null, null, null, $7, $8, $9, null, $10) ON CONFLICT DO NOTHING`,
+		`INSERT INTO group_proposal VALUES ($1, $2, $3, $4, $5, $6, null, null, null, $7, $8, $9, null, $10, $11) ON CONFLICT DO NOTHING`,
 		proposal.ID, proposal.GroupID, proposal.Metadata, proposal.Proposer, proposal.Status,
 		proposal.ExecutorResult, proposal.Messages, proposal.BlockHeight, proposal.SubmitTime, proposal.MemberCount,
+		dbtypes.ToNullString(proposal.PolicyAddress),
 	)
 	return err
 }
@@ -77,6 +105,14 @@ func (dbTx *DbTx) UpdateActiveProposalStatusesByGroup(groupID uint64, status str
 	return err
 }
 
+func (dbTx *DbTx) UpdateActiveProposalStatusesByPolicy(policyAddress string, status string) error {
+	_, err := dbTx.Exec(
+		`UPDATE group_proposal SET status = $1 WHERE status = 'PROPOSAL_STATUS_SUBMITTED' AND policy_address = $2`,
+		status, policyAddress,
+	)
+	return err
+}
+
 func (dbTx *DbTx) UpdateProposalExecutorResult(result *types.ExecutionResult) error {
 	_, err := dbTx.Exec(
 		`UPDATE group_proposal SET executor_result = $1, transaction_hash = $2, executor = $3, execution_time = $4, execution_log = $5 WHERE id = $6`,
@@ -93,25 +129,67 @@ func (dbTx *DbTx) UpdateGroupMetadata(groupID uint64, metadata string) error {
 	return err
 }
 
-func (dbTx *DbTx) UpdateGroupPolicyMetadata(groupID uint64, metadata string) error {
+func (dbTx *DbTx) UpdateGroupAdmin(groupID uint64, admin string) error {
 	_, err := dbTx.Exec(
-		`UPDATE group_with_policy SET policy_metadata = $1 WHERE id = $2`,
-		metadata, groupID,
+		`UPDATE group_with_policy SET admin = $1 WHERE id = $2`,
+		admin, groupID,
+	)
+	return err
+}
+
+func (dbTx *DbTx) UpdateGroupPolicyAdmin(policyAddress string, admin string) error {
+	_, err := dbTx.Exec(
+		`UPDATE group_policy SET admin = $1 WHERE address = $2`,
+		admin, policyAddress,
 	)
 	return err
 }
 
-func (dbTx *DbTx) UpdateDecisionPolicy(groupID uint64, threshold uint64, votingPeriod uint64, minExecutionPeriod uint64) error {
+func (dbTx *DbTx) UpdateGroupPolicyMetadata(policyAddress string, metadata string) error {
 	_, err := dbTx.Exec(
-		`UPDATE group_with_policy SET threshold = $1, voting_period = $2, min_execution_period = $3 WHERE id = $4`,
-		threshold, votingPeriod, minExecutionPeriod, groupID,
+		`UPDATE group_policy SET metadata = $1 WHERE address = $2`,
+		metadata, policyAddress,
+	)
+	if err != nil {
+		return err
+	}
+
+	_, err = dbTx.Exec(
+		`UPDATE group_with_policy SET policy_metadata = $1 WHERE address = $2`,
+		metadata, policyAddress,
+	)
+	return err
+}
+
+func (dbTx *DbTx) UpdateDecisionPolicy(policyAddress string, policy types.GroupDecisionPolicy) error {
+	percentage := dbtypes.ToNullString(policy.Percentage)
+	_, err := dbTx.Exec(
+		`UPDATE group_policy SET threshold = $1, voting_period = $2, min_execution_period = $3, percentage = $4 WHERE address = $5`,
+		policy.Threshold, policy.VotingPeriod, policy.MinExecutionPeriod, percentage, policyAddress,
+	)
+	if err != nil {
+		return err
+	}
+
+	_, err = dbTx.Exec(
+		`UPDATE group_with_policy SET threshold = $1, voting_period = $2, min_execution_period = $3, percentage = $4 WHERE address = $5`,
+		policy.Threshold, policy.VotingPeriod, policy.MinExecutionPeriod, percentage, policyAddress,
+	)
+	return err
+}
+
+// RemoveMember sets the weight of the given member to zero, keeping its votes referenceable
+func (dbTx *DbTx) RemoveMember(groupID uint64, address string) error {
+	_, err := dbTx.Exec(
+		`UPDATE group_member SET weight = 0 WHERE group_id = $1 AND address = $2`,
+		groupID, address,
 	)
 	return err
 }
 
 func (dbTx *DbTx) GetGroupIDByGroupAddress(groupAddress string) (uint64, error) {
 	var groupID uint64
-	err := dbTx.QueryRow(`SELECT id FROM group_with_policy WHERE address = $1`, groupAddress).Scan(&groupID)
+	err := dbTx.QueryRow(`SELECT group_id FROM group_policy WHERE address = $1`, groupAddress).Scan(&groupID)
 	return groupID, err
 }
 
@@ -120,36 +198,46 @@ func (dbTx *DbTx) GetProposal(proposalID uint64) (*dbtypes.GroupProposalRow, err
 	err := dbTx.QueryRow(`SELECT * FROM group_proposal WHERE id = $1`, proposalID).Scan(
 		&p.ID, &p.GroupID, &p.ProposalMetadata, &p.Proposer, &p.Status, &p.ExecutorResult, &p.Executor,
 		&p.ExecutionTime, &p.ExecutionLog, &p.Messages, &p.BlockHeight, &p.SubmitTime, &p.TxHash, &p.MemberCount,
+		&p.PolicyAddress,
 	)
 
 	return &p, err
 }
 
-func (dbTx *DbTx) GetGroupThreshold(groupID uint64) (int, error) {
-	var threshold int
-	err := dbTx.QueryRow(`SELECT threshold FROM group_with_policy WHERE id = $1`, groupID).Scan(&threshold)
-	return threshold, err
-}
-
-func (dbTx *DbTx) GetProposalVotes(proposalID uint64) ([]string, error) {
-	rows, err := dbTx.Query(`SELECT vote_option FROM group_proposal_vote WHERE proposal_id = $1`, proposalID)
-	if err != nil {
-		return nil, err
-	}
-
-	defer rows.Close()
-
-	var votes []string
-	for rows.Next() {
-		var vote string
-		if err := rows.Scan(&vote); err != nil {
-			return nil, err
-		}
+// GetProposalDecisionPolicy returns the decision policy the given proposal has been submitted to, falling back
+// to the first policy of its group for proposals stored without a policy
+func (dbTx *DbTx) GetProposalDecisionPolicy(proposalID uint64) (types.GroupDecisionPolicy, error) {
+	var policy types.GroupDecisionPolicy
+	var percentage sql.NullString
+	err := dbTx.QueryRow(
+		`SELECT 
+			CASE WHEN gp.address IS NULL THEN g.threshold ELSE gp.threshold END,
+			CASE WHEN gp.address IS NULL THEN g.percentage ELSE gp.percentage END,
+			CASE WHEN gp.address IS NULL THEN g.voting_period ELSE gp.voting_period END,
+			CASE WHEN gp.address IS NULL THEN g.min_execution_period ELSE gp.min_execution_period END
+		FROM group_proposal p
+		JOIN group_with_policy g ON g.id = p.group_id
+		LEFT JOIN group_policy gp ON gp.address = p.policy_address
+		WHERE p.id = $1`,
+		proposalID,
+	).Scan(&policy.Threshold, &percentage, &policy.VotingPeriod, &policy.MinExecutionPeriod)
 
-		votes = append(votes, vote)
-	}
+	policy.Percentage = percentage.String
+	return policy, err
+}
 
-	return votes, rows.Err()
+// GetProposalTally returns the weight of the YES votes and the total weight of the votes cast on the given proposal
+func (dbTx *DbTx) GetProposalTally(proposalID uint64) (yesWeight int, votedWeight int, err error) {
+	err = dbTx.QueryRow(
+		`SELECT 
+			COALESCE(SUM(m.weight) FILTER (WHERE v.vote_option = 'VOTE_OPTION_YES'), 0),
+			COALESCE(SUM(m.weight), 0)
+		FROM group_proposal_vote v
+		JOIN group_member m ON m.group_id = v.group_id AND m.address = v.voter
+		WHERE v.proposal_id = $1`,
+		proposalID,
+	).Scan(&yesWeight, &votedWeight)
+	return yesWeight, votedWeight, err
 }
 
 func (dbTx *DbTx) GetGroupTotalVotingPower(groupID uint64) (int, error) {
@@ -168,9 +256,10 @@ func (dbTx *DbTx) GetGroupMemberCount(groupID uint64) (int, error) {
 
 func (dbTx *DbTx) GetAllActiveProposals() ([]*types.ProposalDecisionPolicy, error) {
 	rows, err := dbTx.Query(
-		`SELECT p.id, g.voting_period, p.submit_time
+		`SELECT p.id, COALESCE(gp.voting_period, g.voting_period), p.submit_time
 		FROM group_proposal p
 		JOIN group_with_policy g ON g.id = p.group_id
+		LEFT JOIN group_policy gp ON gp.address = p.policy_address
 		WHERE p.status = 'PROPOSAL_STATUS_SUBMITTED'`,
 	)
 	if err != nil {
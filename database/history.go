@@ -1,8 +1,13 @@
 package database
 
 import (
+	"database/sql"
 	"fmt"
+	"time"
 
+	"github.com/lib/pq"
+
+	dbtypes "github.com/forbole/bdjuno/v2/database/types"
 	"github.com/forbole/bdjuno/v2/types"
 )
 
@@ -34,3 +39,128 @@ ON CONFLICT ON CONSTRAINT unique_price_for_timestamp DO UPDATE
 
 	return nil
 }
+
+// GetTokenPriceHistoryAt returns the price of the token having the given unit name that was the most recent
+// one at the given time, provided it is not older than maxAge. If no such price is stored, found is false
+func (db *Db) GetTokenPriceHistoryAt(unitName string, timestamp time.Time, maxAge time.Duration) (price float64, found bool, err error) {
+	err = db.Sql.QueryRow(`SELECT price FROM token_price_history 
+WHERE unit_name = $1 AND timestamp <= $2 AND timestamp >= $3 
+ORDER BY timestamp DESC LIMIT 1`, unitName, timestamp, timestamp.Add(-maxAge)).Scan(&price)
+	if err == sql.ErrNoRows {
+		return 0, false, nil
+	}
+	if err != nil {
+		return 0, false, fmt.Errorf("error while getting %s price history: %s", unitName, err)
+	}
+
+	return price, true, nil
+}
+
+// QueueAccountsBalanceHistory queues the given addresses so that their balance history is stored at the given height
+func (db *Db) QueueAccountsBalanceHistory(height int64, addresses []string) error {
+	if len(addresses) == 0 {
+		return nil
+	}
+
+	_, err := db.Sql.Exec(`INSERT INTO account_balance_history_queue (address, height) 
+SELECT UNNEST($1::TEXT[]), $2 ON CONFLICT DO NOTHING`, pq.Array(addresses), height)
+	if err != nil {
+		return fmt.Errorf("error while queueing accounts balance history: %s", err)
+	}
+
+	return nil
+}
+
+// GetQueuedAccountsBalanceHistory returns at most limit addresses queued at the lowest queued height,
+// along with such height. If the queue is empty, no addresses are returned
+func (db *Db) GetQueuedAccountsBalanceHistory(limit int) (int64, []string, error) {
+	var rows []struct {
+		Address string `db:"address"`
+		Height  int64  `db:"height"`
+	}
+	err := db.Sqlx.Select(&rows, `SELECT address, height FROM account_balance_history_queue 
+WHERE height = (SELECT MIN(height) FROM account_balance_history_queue) 
+ORDER BY address LIMIT $1`, limit)
+	if err != nil {
+		return 0, nil, fmt.Errorf("error while getting queued accounts balance history: %s", err)
+	}
+
+	if len(rows) == 0 {
+		return 0, nil, nil
+	}
+
+	addresses := make([]string, len(rows))
+	for i, row := range rows {
+		addresses[i] = row.Address
+	}
+
+	return rows[0].Height, addresses, nil
+}
+
+// DeleteQueuedAccountsBalanceHistory removes the given addresses queued at the given height
+func (db *Db) DeleteQueuedAccountsBalanceHistory(height int64, addresses []string) error {
+	_, err := db.Sql.Exec(`DELETE FROM account_balance_history_queue WHERE height = $1 AND address = ANY($2)`,
+		height, pq.Array(addresses))
+	if err != nil {
+		return fmt.Errorf("error while deleting queued accounts balance history: %s", err)
+	}
+
+	return nil
+}
+
+// GetAccountsWithBalanceHistoryAt returns the addresses among the given ones whose balance history
+// has already been stored at the given timestamp
+func (db *Db) GetAccountsWithBalanceHistoryAt(addresses []string, timestamp time.Time) ([]string, error) {
+	var stored []string
+	err := db.Sqlx.Select(&stored, `SELECT address FROM account_balance_history WHERE address = ANY($1) AND timestamp = $2`,
+		pq.Array(addresses), timestamp)
+	if err != nil {
+		return nil, fmt.Errorf("error while getting stored account balance history: %s", err)
+	}
+
+	return stored, nil
+}
+
+// SaveAccountBalanceHistory stores the given balances as historic ones, replacing the ones
+// already stored for the same address and timestamp
+func (db *Db) SaveAccountBalanceHistory(entries []types.AccountBalanceHistory) error {
+	if len(entries) == 0 {
+		return nil
+	}
+
+	query := `INSERT INTO account_balance_history 
+    (address, balance, delegated, unbonding, redelegating, commission, reward, timestamp) VALUES`
+	var param []interface{}
+
+	for i, entry := range entries {
+		vi := i * 8
+		query += fmt.Sprintf("($%d,$%d,$%d,$%d,$%d,$%d,$%d,$%d),", vi+1, vi+2, vi+3, vi+4, vi+5, vi+6, vi+7, vi+8)
+		param = append(param,
+			entry.Address,
+			pq.Array(dbtypes.NewDbCoins(entry.Balance)),
+			pq.Array(dbtypes.NewDbCoins(entry.Delegated)),
+			pq.Array(dbtypes.NewDbCoins(entry.Unbonding)),
+			pq.Array(dbtypes.NewDbCoins(entry.Redelegating)),
+			pq.Array(dbtypes.NewDbDecCoins(entry.Commission)),
+			pq.Array(dbtypes.NewDbDecCoins(entry.Reward)),
+			entry.Timestamp,
+		)
+	}
+
+	query = query[:len(query)-1] // Remove trailing ","
+	query += `
+ON CONFLICT ON CONSTRAINT unique_balance_for_height DO UPDATE 
+	SET balance = excluded.balance,
+	    delegated = excluded.delegated,
+	    unbonding = excluded.unbonding,
+	    redelegating = excluded.redelegating,
+	    commission = excluded.commission,
+	    reward = excluded.reward`
+
+	_, err := db.Sql.Exec(query, param...)
+	if err != nil {
+		return fmt.Errorf("error while storing account balance history: %s", err)
+	}
+
+	return nil
+}
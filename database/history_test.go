@@ -0,0 +1,116 @@
+package database_test
+
+import (
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	dbtypes "github.com/forbole/bdjuno/v2/database/types"
+	"github.com/forbole/bdjuno/v2/types"
+)
+
+func (suite *DbTestSuite) TestBigDipperDb_SaveAccountBalanceHistory() {
+	account := suite.getAccount("cosmos140xsjjg6pwkjp0xjz8zru7ytha60l5aee9nlf7")
+	timestamp := time.Date(2020, 10, 10, 15, 00, 00, 000, time.UTC)
+
+	err := suite.database.SaveAccountBalanceHistory([]types.AccountBalanceHistory{
+		types.NewAccountBalanceHistory(
+			account.String(),
+			sdk.NewCoins(sdk.NewInt64Coin("udaric", 100)),
+			sdk.NewCoins(sdk.NewInt64Coin("udaric", 50)),
+			nil,
+			nil,
+			nil,
+			sdk.NewDecCoins(sdk.NewInt64DecCoin("udaric", 1)),
+			timestamp,
+		),
+	})
+	suite.Require().NoError(err)
+
+	// Saving again at the same time replaces the stored balance
+	err = suite.database.SaveAccountBalanceHistory([]types.AccountBalanceHistory{
+		types.NewAccountBalanceHistory(
+			account.String(),
+			sdk.NewCoins(sdk.NewInt64Coin("udaric", 80)),
+			sdk.NewCoins(sdk.NewInt64Coin("udaric", 50)),
+			sdk.NewCoins(sdk.NewInt64Coin("udaric", 20)),
+			nil,
+			nil,
+			sdk.NewDecCoins(sdk.NewInt64DecCoin("udaric", 2)),
+			timestamp,
+		),
+	})
+	suite.Require().NoError(err)
+
+	var rows []dbtypes.AccountBalanceHistoryRow
+	err = suite.database.Sqlx.Select(&rows, `SELECT * FROM account_balance_history`)
+	suite.Require().NoError(err)
+	suite.Require().Len(rows, 1)
+
+	row := rows[0]
+	suite.Require().Equal(account.String(), row.Address)
+	suite.Require().Equal(sdk.NewCoins(sdk.NewInt64Coin("udaric", 80)), row.Balance.ToCoins())
+	suite.Require().Equal(sdk.NewCoins(sdk.NewInt64Coin("udaric", 50)), row.Delegated.ToCoins())
+	suite.Require().Equal(sdk.NewCoins(sdk.NewInt64Coin("udaric", 20)), row.Unbonding.ToCoins())
+	suite.Require().Empty(row.Redelegating)
+	suite.Require().Empty(row.Commission)
+	suite.Require().True(sdk.NewDecCoins(sdk.NewInt64DecCoin("udaric", 2)).IsEqual(row.Reward.ToDecCoins()))
+	suite.Require().True(timestamp.Equal(row.Timestamp))
+}
+
+func (suite *DbTestSuite) TestBigDipperDb_GetAccountsWithBalanceHistoryAt() {
+	account := suite.getAccount("cosmos140xsjjg6pwkjp0xjz8zru7ytha60l5aee9nlf7")
+	timestamp := time.Date(2020, 10, 10, 15, 00, 00, 000, time.UTC)
+
+	err := suite.database.SaveAccountBalanceHistory([]types.AccountBalanceHistory{
+		types.NewAccountBalanceHistory(account.String(), sdk.NewCoins(sdk.NewInt64Coin("udaric", 100)), nil, nil, nil, nil, nil, timestamp),
+	})
+	suite.Require().NoError(err)
+
+	addresses := []string{account.String(), "cosmos1other"}
+
+	stored, err := suite.database.GetAccountsWithBalanceHistoryAt(addresses, timestamp)
+	suite.Require().NoError(err)
+	suite.Require().Equal([]string{account.String()}, stored)
+
+	stored, err = suite.database.GetAccountsWithBalanceHistoryAt(addresses, timestamp.Add(time.Second))
+	suite.Require().NoError(err)
+	suite.Require().Empty(stored)
+}
+
+func (suite *DbTestSuite) TestBigDipperDb_QueueAccountsBalanceHistory() {
+	err := suite.database.QueueAccountsBalanceHistory(20, []string{"cosmos1c", "cosmos1a"})
+	suite.Require().NoError(err)
+	err = suite.database.QueueAccountsBalanceHistory(10, []string{"cosmos1b", "cosmos1a", "cosmos1c"})
+	suite.Require().NoError(err)
+	err = suite.database.QueueAccountsBalanceHistory(10, []string{"cosmos1a"})
+	suite.Require().NoError(err)
+
+	height, addresses, err := suite.database.GetQueuedAccountsBalanceHistory(2)
+	suite.Require().NoError(err)
+	suite.Require().Equal(int64(10), height)
+	suite.Require().Equal([]string{"cosmos1a", "cosmos1b"}, addresses)
+
+	err = suite.database.DeleteQueuedAccountsBalanceHistory(height, addresses)
+	suite.Require().NoError(err)
+
+	height, addresses, err = suite.database.GetQueuedAccountsBalanceHistory(2)
+	suite.Require().NoError(err)
+	suite.Require().Equal(int64(10), height)
+	suite.Require().Equal([]string{"cosmos1c"}, addresses)
+
+	err = suite.database.DeleteQueuedAccountsBalanceHistory(height, addresses)
+	suite.Require().NoError(err)
+
+	height, addresses, err = suite.database.GetQueuedAccountsBalanceHistory(2)
+	suite.Require().NoError(err)
+	suite.Require().Equal(int64(20), height)
+	suite.Require().Equal([]string{"cosmos1a", "cosmos1c"}, addresses)
+
+	err = suite.database.DeleteQueuedAccountsBalanceHistory(height, addresses)
+	suite.Require().NoError(err)
+
+	_, addresses, err = suite.database.GetQueuedAccountsBalanceHistory(2)
+	suite.Require().NoError(err)
+	suite.Require().Empty(addresses)
+}
@@ -3,7 +3,9 @@ package database
 import (
 	"fmt"
 
+	dbtypes "github.com/forbole/bdjuno/v2/database/types"
 	"github.com/forbole/bdjuno/v2/database/utils"
+	"github.com/forbole/bdjuno/v2/types"
 )
 
 func (db *Db) CheckIfNftExists(tokenId uint64, denomId string) error {
@@ -41,16 +43,22 @@ func (tx *DbTx) SaveMarketplaceNft(txHash string, tokenId uint64, denomID, uid,
 	return err
 }
 
-func (tx *DbTx) SaveMarketplaceNftBuy(txHash string, id uint64, buyer string, timestamp uint64, usdPrice, btcPrice string) error {
-	var tokenID uint64
-	var denomID, price, seller string
-
+func (tx *DbTx) GetListedMarketplaceNft(id uint64) (tokenID uint64, denomID, price, seller string, err error) {
 	if err := tx.QueryRow(`SELECT token_id, denom_id, price, creator FROM marketplace_nft WHERE id = $1`, id).Scan(&tokenID, &denomID, &price, &seller); err != nil {
-		return err
+		return 0, "", "", "", err
 	}
 
 	if seller == "" {
-		return fmt.Errorf("nft (%d) not found for sale", id)
+		return 0, "", "", "", fmt.Errorf("nft (%d) not found for sale", id)
+	}
+
+	return tokenID, denomID, price, seller, nil
+}
+
+func (tx *DbTx) SaveMarketplaceNftBuy(txHash string, id uint64, buyer string, timestamp uint64, usdPrice, btcPrice string) error {
+	tokenID, denomID, price, seller, err := tx.GetListedMarketplaceNft(id)
+	if err != nil {
+		return err
 	}
 
 	return tx.saveMarketplaceNftBuy(txHash, buyer, timestamp, tokenID, denomID, price, seller, usdPrice, btcPrice)
@@ -58,12 +66,62 @@ func (tx *DbTx) SaveMarketplaceNftBuy(txHash string, id uint64, buyer string, ti
 
 func (tx *DbTx) saveMarketplaceNftBuy(txHash string, buyer string, timestamp, tokenID uint64, denomID, price, seller, usdPrice, btcPrice string) error {
 	_, err := tx.Exec(`INSERT INTO marketplace_nft_buy_history (transaction_hash, token_id, denom_id, price, seller, buyer, usd_price, btc_price, timestamp, uniq_id) 
-	VALUES($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)`, txHash, tokenID, denomID, price, seller, buyer, usdPrice, btcPrice, timestamp, utils.FormatUniqID(tokenID, denomID))
+	VALUES($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)`, txHash, tokenID, denomID, price, seller, buyer, dbtypes.ToNullString(usdPrice), dbtypes.ToNullString(btcPrice), timestamp, utils.FormatUniqID(tokenID, denomID))
 	return err
 }
 
 func (tx *DbTx) SaveMarketplaceNftMint(txHash string, tokenID uint64, buyer, denomID, price string, timestamp uint64, usdPrice, btcPrice string) error {
-	return tx.saveMarketplaceNftBuy(txHash, buyer, timestamp, tokenID, denomID, price, "0x0", usdPrice, btcPrice)
+	return tx.saveMarketplaceNftBuy(txHash, buyer, timestamp, tokenID, denomID, price, mintSeller, usdPrice, btcPrice)
+}
+
+func (db *Db) SaveMarketplaceCollectionRoyalties(collectionID uint64, mintRoyalties, resaleRoyalties []types.Royalty) error {
+	return db.ExecuteTx(func(dbTx *DbTx) error {
+		if _, err := dbTx.Exec(`DELETE FROM marketplace_collection_royalty WHERE collection_id = $1`, collectionID); err != nil {
+			return err
+		}
+
+		for royaltyType, royalties := range map[string][]types.Royalty{types.RoyaltyTypeMint: mintRoyalties, types.RoyaltyTypeResale: resaleRoyalties} {
+			for i, r := range royalties {
+				if _, err := dbTx.Exec(`INSERT INTO marketplace_collection_royalty (collection_id, type, position, address, percent)
+					VALUES ($1, $2, $3, $4, $5)`, collectionID, royaltyType, i, r.Address, r.Percent); err != nil {
+					return err
+				}
+			}
+		}
+
+		return nil
+	})
+}
+
+func (tx *DbTx) GetMarketplaceRoyalties(denomID, royaltyType string) ([]types.Royalty, error) {
+	rows, err := tx.Query(`SELECT r.address, r.percent FROM marketplace_collection_royalty r
+		JOIN marketplace_collection c ON c.id = r.collection_id WHERE c.denom_id = $1 AND r.type = $2 ORDER BY r.position`, denomID, royaltyType)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	royalties := []types.Royalty{}
+	for rows.Next() {
+		var r types.Royalty
+		if err := rows.Scan(&r.Address, &r.Percent); err != nil {
+			return nil, err
+		}
+		royalties = append(royalties, r)
+	}
+
+	return royalties, rows.Err()
+}
+
+func (tx *DbTx) SaveMarketplaceNftPayouts(txHash string, tokenID uint64, denomID string, timestamp uint64, payouts []types.MarketplacePayout) error {
+	for _, p := range payouts {
+		if _, err := tx.Exec(`INSERT INTO marketplace_nft_sale_payout (transaction_hash, token_id, denom_id, address, amount, type, timestamp)
+			VALUES ($1, $2, $3, $4, $5, $6, $7) ON CONFLICT DO NOTHING`, txHash, tokenID, denomID, p.Address, p.Amount, p.Type, timestamp); err != nil {
+			return err
+		}
+	}
+
+	return nil
 }
 
 func (tx *DbTx) SetMarketplaceNFTPrice(id uint64, price string) error {
@@ -81,6 +139,16 @@ func (db *Db) UnlistNft(id uint64) error {
 	return err
 }
 
+// SaveMarketplaceNftListingEvent stores the given listing lifecycle event of the nft currently listed with the given id,
+// using the price it is listed at. It must be called after the listing is created or updated and before it is removed
+func (tx *DbTx) SaveMarketplaceNftListingEvent(txHash string, msgIndex int, height int64, id uint64, eventType, account string, timestamp uint64) error {
+	_, err := tx.Exec(`INSERT INTO marketplace_nft_listing_history (marketplace_nft_id, token_id, denom_id, type, price, account, height, transaction_hash, msg_index, timestamp, uniq_id) 
+	SELECT id, token_id, denom_id, $2, price, $3, $4, $5, $6, $7, concat(token_id, '@', denom_id) FROM marketplace_nft WHERE id = $1
+	ON CONFLICT (transaction_hash, msg_index) DO NOTHING`,
+		id, eventType, account, height, txHash, msgIndex, timestamp)
+	return err
+}
+
 func (db *Db) SetMarketplaceCollectionVerificationStatus(id uint64, verified bool) error {
 	_, err := db.Sql.Exec(`UPDATE marketplace_collection SET verified = $1 WHERE id = $2`, verified, id)
 	return err
@@ -95,3 +163,42 @@ func (db *Db) SetMarketplaceCollectionRoyalties(id uint64, mintRoyalties, resale
 	_, err := db.Sql.Exec(`UPDATE marketplace_collection SET mint_royalties = $1, resale_royalties = $2 WHERE id = $3`, mintRoyalties, resaleRoyalties, id)
 	return err
 }
+
+// SaveMarketplaceCollectionsStatistics recomputes the sales statistics of every published collection.
+// Volumes are computed from the sales that happened in the given windows before timestamp, while
+// USD volumes convert each sale price from acudos using the CUDOS price stored along with the sale.
+// Mints are not sales, so all the sales statistics only take the resales into account
+func (db *Db) SaveMarketplaceCollectionsStatistics(timestamp int64) error {
+	_, err := db.Sql.Exec(`INSERT INTO marketplace_collection_statistics (denom_id, floor_price, volume_24h, volume_7d, volume_30d,
+		usd_volume_24h, usd_volume_7d, usd_volume_30d, sales_count, unique_buyers, unique_sellers, owners_count, timestamp)
+	SELECT c.denom_id,
+		(SELECT MIN(n.price) FROM marketplace_nft n WHERE n.denom_id = c.denom_id AND n.id IS NOT NULL AND n.price > 0),
+		COALESCE(SUM(h.price) FILTER (WHERE h.timestamp > $1 - 86400), 0),
+		COALESCE(SUM(h.price) FILTER (WHERE h.timestamp > $1 - 604800), 0),
+		COALESCE(SUM(h.price) FILTER (WHERE h.timestamp > $1 - 2592000), 0),
+		COALESCE(SUM(h.price * h.usd_price) FILTER (WHERE h.timestamp > $1 - 86400), 0) / $2,
+		COALESCE(SUM(h.price * h.usd_price) FILTER (WHERE h.timestamp > $1 - 604800), 0) / $2,
+		COALESCE(SUM(h.price * h.usd_price) FILTER (WHERE h.timestamp > $1 - 2592000), 0) / $2,
+		COUNT(h.transaction_hash),
+		COUNT(DISTINCT h.buyer),
+		COUNT(DISTINCT h.seller),
+		(SELECT COUNT(DISTINCT o.owner) FROM nft_nft o WHERE o.denom_id = c.denom_id AND o.burned IS NOT TRUE),
+		$1
+	FROM (SELECT DISTINCT denom_id FROM marketplace_collection) c
+	LEFT JOIN marketplace_nft_buy_history h ON h.denom_id = c.denom_id AND h.seller <> $3
+	GROUP BY c.denom_id
+	ON CONFLICT (denom_id) DO UPDATE SET
+		floor_price = excluded.floor_price, volume_24h = excluded.volume_24h, volume_7d = excluded.volume_7d,
+		volume_30d = excluded.volume_30d, usd_volume_24h = excluded.usd_volume_24h, usd_volume_7d = excluded.usd_volume_7d,
+		usd_volume_30d = excluded.usd_volume_30d, sales_count = excluded.sales_count, unique_buyers = excluded.unique_buyers,
+		unique_sellers = excluded.unique_sellers, owners_count = excluded.owners_count, timestamp = excluded.timestamp`,
+		timestamp, acudosPerCudos, mintSeller)
+	return err
+}
+
+const (
+	acudosPerCudos = "1000000000000000000"
+
+	// mintSeller is the seller stored for the mints inside the buy history
+	mintSeller = "0x0"
+)
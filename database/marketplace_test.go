@@ -0,0 +1,184 @@
+package database_test
+
+import (
+	"github.com/forbole/bdjuno/v2/database"
+	"github.com/forbole/bdjuno/v2/types"
+)
+
+func (suite *DbTestSuite) TestMarketplace_SaveMarketplaceCollectionsStatistics() {
+	txHash := "txhash"
+	denomID := "denom1"
+	now := int64(10000000)
+
+	insertDummyTransaction(suite, 1, txHash)
+
+	err := suite.database.SaveDenom(txHash, denomID, "name", "schema", "symbol", "owner", "", "", "", "", "", "{}")
+	suite.Require().NoError(err)
+
+	err = suite.database.SaveMarketplaceCollection(txHash, 1, denomID, "[]", "[]", "creator", false)
+	suite.Require().NoError(err)
+
+	err = suite.database.ExecuteTx(func(dbTx *database.DbTx) error {
+		for tokenID, owner := range []string{"owner1", "owner2", "owner2"} {
+			if err := dbTx.SaveNFT(txHash, uint64(tokenID), denomID, "name", "uri", "{}", "", owner, "creator", ""); err != nil {
+				return err
+			}
+
+			if err := dbTx.SaveMarketplaceNft(txHash, uint64(tokenID), denomID, "", "0", "creator"); err != nil {
+				return err
+			}
+		}
+
+		if err := dbTx.ListNft(txHash, 1, 1, denomID, "5000000000000000000"); err != nil {
+			return err
+		}
+
+		if err := dbTx.ListNft(txHash, 2, 2, denomID, "3000000000000000000"); err != nil {
+			return err
+		}
+
+		// Mint one hour ago, resale two days ago, resale two months ago
+		if err := dbTx.SaveMarketplaceNftMint(txHash, 0, "owner1", denomID, "1000000000000000000", uint64(now-3600), "2", "0"); err != nil {
+			return err
+		}
+
+		if err := dbTx.SaveMarketplaceNftBuy(txHash, 1, "owner2", uint64(now-2*86400), "1", "0"); err != nil {
+			return err
+		}
+
+		return dbTx.SaveMarketplaceNftBuy(txHash, 2, "owner2", uint64(now-60*86400), "1", "0")
+	})
+	suite.Require().NoError(err)
+
+	err = suite.database.SaveMarketplaceCollectionsStatistics(now)
+	suite.Require().NoError(err)
+
+	var rows []struct {
+		FloorPrice    string  `db:"floor_price"`
+		Volume24h     string  `db:"volume_24h"`
+		Volume7d      string  `db:"volume_7d"`
+		Volume30d     string  `db:"volume_30d"`
+		USDVolume24h  float64 `db:"usd_volume_24h"`
+		USDVolume7d   float64 `db:"usd_volume_7d"`
+		SalesCount    int64   `db:"sales_count"`
+		UniqueBuyers  int64   `db:"unique_buyers"`
+		UniqueSellers int64   `db:"unique_sellers"`
+		OwnersCount   int64   `db:"owners_count"`
+	}
+	err = suite.database.Sqlx.Select(&rows, `SELECT floor_price, volume_24h, volume_7d, volume_30d, usd_volume_24h, usd_volume_7d,
+		sales_count, unique_buyers, unique_sellers, owners_count FROM marketplace_collection_statistics WHERE denom_id = $1`, denomID)
+	suite.Require().NoError(err)
+	suite.Require().Len(rows, 1)
+
+	suite.Require().Equal("3000000000000000000", rows[0].FloorPrice)
+	// The mint is not counted as a sale
+	suite.Require().Equal("0", rows[0].Volume24h)
+	suite.Require().Equal("5000000000000000000", rows[0].Volume7d)
+	suite.Require().Equal("5000000000000000000", rows[0].Volume30d)
+	suite.Require().Equal(float64(0), rows[0].USDVolume24h)
+	suite.Require().Equal(float64(5), rows[0].USDVolume7d)
+	suite.Require().Equal(int64(2), rows[0].SalesCount)
+	suite.Require().Equal(int64(1), rows[0].UniqueBuyers)
+	suite.Require().Equal(int64(1), rows[0].UniqueSellers)
+	suite.Require().Equal(int64(2), rows[0].OwnersCount)
+}
+
+func (suite *DbTestSuite) TestMarketplace_SaveMarketplaceNftPayouts() {
+	txHash := "txhash"
+	denomID := "denom1"
+
+	insertDummyTransaction(suite, 1, txHash)
+
+	err := suite.database.SaveDenom(txHash, denomID, "name", "schema", "symbol", "owner", "", "", "", "", "", "{}")
+	suite.Require().NoError(err)
+
+	payouts := []types.MarketplacePayout{
+		{Address: "creator", Amount: "100", Type: types.PayoutTypeResaleRoyalty},
+		{Address: "seller", Amount: "900", Type: types.PayoutTypeSeller},
+	}
+
+	// Storing the payouts again when re-parsing the same height must not duplicate them
+	for i := 0; i < 2; i++ {
+		err = suite.database.ExecuteTx(func(dbTx *database.DbTx) error {
+			if err := dbTx.SaveNFT(txHash, 1, denomID, "name", "uri", "{}", "", "owner", "creator", ""); err != nil {
+				return err
+			}
+
+			return dbTx.SaveMarketplaceNftPayouts(txHash, 1, denomID, 10, payouts)
+		})
+		suite.Require().NoError(err)
+	}
+
+	var count int
+	err = suite.database.Sqlx.Get(&count, `SELECT COUNT(*) FROM marketplace_nft_sale_payout WHERE transaction_hash = $1`, txHash)
+	suite.Require().NoError(err)
+	suite.Require().Equal(2, count)
+}
+
+func (suite *DbTestSuite) TestMarketplace_SaveMarketplaceNftListingEvent() {
+	txHash := "txhash"
+	denomID := "denom1"
+
+	insertDummyTransaction(suite, 1, txHash)
+
+	err := suite.database.SaveDenom(txHash, denomID, "name", "schema", "symbol", "owner", "", "", "", "", "", "{}")
+	suite.Require().NoError(err)
+
+	err = suite.database.ExecuteTx(func(dbTx *database.DbTx) error {
+		if err := dbTx.SaveNFT(txHash, 1, denomID, "name", "uri", "{}", "", "owner", "creator", ""); err != nil {
+			return err
+		}
+
+		if err := dbTx.SaveMarketplaceNft(txHash, 1, denomID, "", "0", "creator"); err != nil {
+			return err
+		}
+
+		if err := dbTx.ListNft(txHash, 1, 1, denomID, "100"); err != nil {
+			return err
+		}
+
+		if err := dbTx.SaveMarketplaceNftListingEvent(txHash, 0, 1, 1, types.ListingEventListed, "owner", 10); err != nil {
+			return err
+		}
+
+		if err := dbTx.SetMarketplaceNFTPrice(1, "200"); err != nil {
+			return err
+		}
+
+		if err := dbTx.SaveMarketplaceNftListingEvent(txHash, 1, 1, 1, types.ListingEventPriceUpdated, "owner", 10); err != nil {
+			return err
+		}
+
+		// Saving the same message twice must not duplicate the event
+		if err := dbTx.SaveMarketplaceNftListingEvent(txHash, 1, 1, 1, types.ListingEventPriceUpdated, "owner", 10); err != nil {
+			return err
+		}
+
+		if err := dbTx.SaveMarketplaceNftListingEvent(txHash, 2, 1, 1, types.ListingEventDelisted, "owner", 10); err != nil {
+			return err
+		}
+
+		if err := dbTx.UnlistNft(1); err != nil {
+			return err
+		}
+
+		// Events of listings that do not exist anymore are ignored
+		return dbTx.SaveMarketplaceNftListingEvent(txHash, 3, 1, 1, types.ListingEventDelisted, "owner", 10)
+	})
+	suite.Require().NoError(err)
+
+	var rows []struct {
+		Type  string `db:"type"`
+		Price string `db:"price"`
+	}
+	err = suite.database.Sqlx.Select(&rows, `SELECT type, price FROM marketplace_nft_listing_history WHERE token_id = 1 AND denom_id = $1 ORDER BY msg_index`, denomID)
+	suite.Require().NoError(err)
+	suite.Require().Len(rows, 3)
+
+	suite.Require().Equal(types.ListingEventListed, rows[0].Type)
+	suite.Require().Equal("100", rows[0].Price)
+	suite.Require().Equal(types.ListingEventPriceUpdated, rows[1].Type)
+	suite.Require().Equal("200", rows[1].Price)
+	suite.Require().Equal(types.ListingEventDelisted, rows[2].Type)
+	suite.Require().Equal("200", rows[2].Price)
+}
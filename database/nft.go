@@ -1,6 +1,12 @@
 package database
 
-import "github.com/forbole/bdjuno/v2/database/utils"
+import (
+	"database/sql"
+
+	dbtypes "github.com/forbole/bdjuno/v2/database/types"
+	"github.com/forbole/bdjuno/v2/database/utils"
+	"github.com/forbole/bdjuno/v2/types"
+)
 
 func (db *Db) SaveDenom(txHash, denomID, name, schema, symbol, owner, contractAddressSigner, traits, minter, description, dataText, dataJSON string) error {
 	_, err := db.Sql.Exec(`INSERT INTO nft_denom (transaction_hash, id, name, schema, symbol, owner, contract_address_signer, 
@@ -9,11 +15,149 @@ func (db *Db) SaveDenom(txHash, denomID, name, schema, symbol, owner, contractAd
 	return err
 }
 
-func (db *Db) UpdateDenom(denomID, owner string) error {
-	_, err := db.Sql.Exec(`UPDATE nft_denom SET owner = $1 WHERE id = $2`, owner, denomID)
+// UpdateDenom sets the new owner of the given denom, storing the ownership change inside the denom transfer history
+func (tx *DbTx) UpdateDenom(txHash string, msgIndex int, height int64, denomID, owner string, timestamp uint64) error {
+	_, err := tx.Exec(`INSERT INTO nft_denom_transfer_history (denom_id, old_owner, new_owner, height, transaction_hash, msg_index, timestamp) 
+		SELECT id, owner, $2, $3, $4, $5, $6 FROM nft_denom WHERE id = $1 ON CONFLICT (transaction_hash, msg_index) DO NOTHING`,
+		denomID, owner, height, txHash, msgIndex, timestamp)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`UPDATE nft_denom SET owner = $1 WHERE id = $2`, owner, denomID)
 	return err
 }
 
+// UpdateDenomStatisticsOnMint updates the counters of the given denom after a new nft owned by owner has been stored
+func (tx *DbTx) UpdateDenomStatisticsOnMint(denomID, owner string, height int64) error {
+	owned, err := tx.countOwnedNFTs(denomID, owner)
+	if err != nil {
+		return err
+	}
+
+	var holders int64
+	if owned == 1 {
+		holders = 1
+	}
+
+	return tx.updateDenomStatistics(denomID, height, 1, 0, 1, holders, 0)
+}
+
+// UpdateDenomStatisticsOnBurn updates the counters of the given denom after an nft owned by owner has been burned
+func (tx *DbTx) UpdateDenomStatisticsOnBurn(denomID, owner string, height int64) error {
+	owned, err := tx.countOwnedNFTs(denomID, owner)
+	if err != nil {
+		return err
+	}
+
+	var holders int64
+	if owned == 0 {
+		holders = -1
+	}
+
+	return tx.updateDenomStatistics(denomID, height, 0, 1, -1, holders, 0)
+}
+
+// UpdateDenomStatisticsOnTransfer updates the counters of the given denom after an nft has been transferred
+// from the given owner to a different one
+func (tx *DbTx) UpdateDenomStatisticsOnTransfer(denomID, from, to string, height int64) error {
+	fromOwned, err := tx.countOwnedNFTs(denomID, from)
+	if err != nil {
+		return err
+	}
+
+	toOwned, err := tx.countOwnedNFTs(denomID, to)
+	if err != nil {
+		return err
+	}
+
+	var holders int64
+	if fromOwned == 0 {
+		holders--
+	}
+	if toOwned == 1 {
+		holders++
+	}
+
+	return tx.updateDenomStatistics(denomID, height, 0, 0, 0, holders, 1)
+}
+
+// countOwnedNFTs returns the number of nfts of the given denom that are owned by owner and have not been burned
+func (tx *DbTx) countOwnedNFTs(denomID, owner string) (int64, error) {
+	var count int64
+	err := tx.QueryRow(`SELECT COUNT(*) FROM nft_nft WHERE denom_id = $1 AND owner = $2 AND burned IS NOT TRUE`,
+		denomID, owner).Scan(&count)
+	return count, err
+}
+
+// updateDenomStatistics adds the given amounts to the counters of the given denom
+func (tx *DbTx) updateDenomStatistics(denomID string, height, minted, burned, supply, holders, transfers int64) error {
+	_, err := tx.Exec(`INSERT INTO nft_denom_statistics (denom_id, minted, burned, supply, holders, transfers, height)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		ON CONFLICT (denom_id) DO UPDATE 
+			SET minted = nft_denom_statistics.minted + EXCLUDED.minted,
+				burned = nft_denom_statistics.burned + EXCLUDED.burned,
+				supply = nft_denom_statistics.supply + EXCLUDED.supply,
+				holders = nft_denom_statistics.holders + EXCLUDED.holders,
+				transfers = nft_denom_statistics.transfers + EXCLUDED.transfers,
+				height = GREATEST(nft_denom_statistics.height, EXCLUDED.height)`,
+		denomID, minted, burned, supply, holders, transfers, height)
+	return err
+}
+
+// UpdateDenomsStatistics recomputes the counters of every denom from the stored nfts and transfers,
+// marking them as computed at the given height. It reconciles the counters updated by the handlers
+func (db *Db) UpdateDenomsStatistics(height int64) error {
+	_, err := db.Sql.Exec(`INSERT INTO nft_denom_statistics (denom_id, minted, burned, supply, holders, transfers, height)
+		SELECT d.id,
+			COALESCE(n.minted, 0),
+			COALESCE(n.burned, 0),
+			COALESCE(n.supply, 0),
+			COALESCE(n.holders, 0),
+			COALESCE(t.transfers, 0),
+			$1
+		FROM nft_denom d
+		LEFT JOIN (
+			SELECT denom_id,
+				COUNT(*) AS minted,
+				COUNT(*) FILTER (WHERE burned IS TRUE) AS burned,
+				COUNT(*) FILTER (WHERE burned IS NOT TRUE) AS supply,
+				COUNT(DISTINCT owner) FILTER (WHERE burned IS NOT TRUE) AS holders
+			FROM nft_nft GROUP BY denom_id
+		) n ON n.denom_id = d.id
+		LEFT JOIN (
+			SELECT denom_id, COUNT(*) AS transfers
+			FROM nft_transfer_history WHERE old_owner <> '0x0' AND new_owner <> '0x0'
+			GROUP BY denom_id
+		) t ON t.denom_id = d.id
+		ON CONFLICT (denom_id) DO UPDATE 
+			SET minted = EXCLUDED.minted,
+				burned = EXCLUDED.burned,
+				supply = EXCLUDED.supply,
+				holders = EXCLUDED.holders,
+				transfers = EXCLUDED.transfers,
+				height = GREATEST(nft_denom_statistics.height, EXCLUDED.height)`, height)
+	return err
+}
+
+// GetNFTOwner returns the owner of the given nft and whether it has been burned, returning an empty owner
+// if the nft is not stored
+func (tx *DbTx) GetNFTOwner(id, denomID string) (string, bool, error) {
+	var owner string
+	var burned sql.NullBool
+	err := tx.QueryRow(`SELECT owner, burned FROM nft_nft WHERE id = $1 AND denom_id = $2`, id, denomID).Scan(&owner, &burned)
+	if err == sql.ErrNoRows {
+		return "", false, nil
+	}
+	return owner, burned.Bool, err
+}
+
+func (tx *DbTx) GetDenomOwner(denomID string) (string, error) {
+	var owner string
+	err := tx.QueryRow(`SELECT owner FROM nft_denom WHERE id = $1`, denomID).Scan(&owner)
+	return owner, err
+}
+
 func (tx *DbTx) SaveNFT(txHash string, tokenID uint64, denomID, name, uri, dataJSON, dataText, owner, sender, contractAddressSigner string) error {
 	_, err := tx.Exec(`INSERT INTO nft_nft (transaction_hash, id, denom_id, name, uri, owner, data_json, data_text, sender, contract_address_signer, uniq_id) 
 	VALUES($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11) ON CONFLICT (id, denom_id) DO UPDATE SET uniq_id = EXCLUDED.uniq_id`, txHash, tokenID, denomID, name, uri, owner,
@@ -21,8 +165,26 @@ func (tx *DbTx) SaveNFT(txHash string, tokenID uint64, denomID, name, uri, dataJ
 	return err
 }
 
-func (db *Db) UpdateNFT(id, denomID, name, uri, dataJSON, dataText string) error {
-	_, err := db.Sql.Exec(`UPDATE nft_nft SET name = $1, uri = $2, data_json = $3, data_text = $4 WHERE id = $5 AND denom_id = $6`, name, uri, dataJSON, dataText, id, denomID)
+func (tx *DbTx) UpdateNFT(id, denomID, name, uri, dataJSON, dataText string) error {
+	_, err := tx.Exec(`UPDATE nft_nft SET name = $1, uri = $2, data_json = $3, data_text = $4 WHERE id = $5 AND denom_id = $6`, name, uri, dataJSON, dataText, id, denomID)
+	return err
+}
+
+func (tx *DbTx) GetNFTMetadata(id, denomID string) (types.NftMetadata, error) {
+	var metadata types.NftMetadata
+	err := tx.QueryRow(`SELECT name, uri, data_json, data_text FROM nft_nft WHERE id = $1 AND denom_id = $2`, id, denomID).
+		Scan(&metadata.Name, &metadata.URI, &metadata.DataJSON, &metadata.DataText)
+	return metadata, err
+}
+
+// SaveNFTRevision stores the given edit of the nft metadata, saving the same message twice has no effect
+func (tx *DbTx) SaveNFTRevision(revision types.NftRevision) error {
+	_, err := tx.Exec(`INSERT INTO nft_nft_revision (token_id, denom_id, previous_name, previous_uri, previous_data_json, previous_data_text, 
+		new_name, new_uri, new_data_json, new_data_text, editor, height, transaction_hash, msg_index, uniq_id) 
+		VALUES($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15) ON CONFLICT (transaction_hash, msg_index) DO NOTHING`,
+		revision.TokenID, revision.DenomID, revision.Previous.Name, revision.Previous.URI, revision.Previous.DataJSON, revision.Previous.DataText,
+		revision.New.Name, revision.New.URI, revision.New.DataJSON, revision.New.DataText, revision.Editor, revision.Height, revision.TxHash, revision.MsgIndex,
+		utils.FormatUniqID(revision.TokenID, revision.DenomID))
 	return err
 }
 
@@ -42,3 +204,138 @@ func (tx *DbTx) UpdateNFTHistory(txHash string, tokenID uint64, denomID, from, t
 		txHash, tokenID, denomID, from, to, timestamp, utils.FormatUniqID(tokenID, denomID))
 	return err
 }
+
+func (db *Db) SaveNFTApproval(txHash string, height int64, id, denomID, address string) error {
+	_, err := db.Sql.Exec(`INSERT INTO nft_approval (token_id, denom_id, address, height, transaction_hash) 
+		VALUES($1, $2, $3, $4, $5) ON CONFLICT (token_id, denom_id, address) DO UPDATE 
+		SET height = EXCLUDED.height, transaction_hash = EXCLUDED.transaction_hash
+		WHERE nft_approval.height <= EXCLUDED.height`,
+		id, denomID, address, height, txHash)
+	return err
+}
+
+func (db *Db) DeleteNFTApproval(id, denomID, address string) error {
+	_, err := db.Sql.Exec(`DELETE FROM nft_approval WHERE token_id = $1 AND denom_id = $2 AND address = $3`, id, denomID, address)
+	return err
+}
+
+// DeleteNFTApprovals removes all the approvals of the given nft, as the chain does when it is transferred or burned
+func (tx *DbTx) DeleteNFTApprovals(id, denomID string) error {
+	_, err := tx.Exec(`DELETE FROM nft_approval WHERE token_id = $1 AND denom_id = $2`, id, denomID)
+	return err
+}
+
+// SaveNFTOperator stores the given operator of all the owner nfts, or removes it if it is not approved anymore
+func (db *Db) SaveNFTOperator(txHash string, height int64, owner, operator string, approved bool) error {
+	if !approved {
+		_, err := db.Sql.Exec(`DELETE FROM nft_operator WHERE owner = $1 AND operator = $2`, owner, operator)
+		return err
+	}
+
+	_, err := db.Sql.Exec(`INSERT INTO nft_operator (owner, operator, height, transaction_hash) 
+		VALUES($1, $2, $3, $4) ON CONFLICT (owner, operator) DO UPDATE 
+		SET height = EXCLUDED.height, transaction_hash = EXCLUDED.transaction_hash
+		WHERE nft_operator.height <= EXCLUDED.height`,
+		owner, operator, height, txHash)
+	return err
+}
+
+// GetNftsToResolveMetadata returns the nfts whose off-chain metadata has never been resolved, has been reset
+// or failed to be fetched less than maxRetries times and is due to be retried at the given timestamp, along
+// with the number of failed attempts
+func (db *Db) GetNftsToResolveMetadata(maxRetries, limit int, timestamp int64) ([]dbtypes.NftURIRow, error) {
+	var rows []dbtypes.NftURIRow
+	err := db.Sqlx.Select(&rows, `SELECT n.id, n.denom_id, n.uri, COALESCE(m.retries, 0) AS retries 
+		FROM nft_nft n LEFT JOIN nft_nft_metadata m ON m.token_id = n.id AND m.denom_id = n.denom_id
+		WHERE n.burned IS NOT TRUE AND n.uri <> '' AND (
+			m.token_id IS NULL OR 
+			m.uri <> n.uri OR 
+			m.status = $1 OR 
+			(m.status = $2 AND m.retries < $3 AND m.next_retry <= $5))
+		ORDER BY m.retries NULLS FIRST, n.denom_id, n.id 
+		LIMIT $4`,
+		types.NftMetadataStatusPending, types.NftMetadataStatusFailed, maxRetries, limit, timestamp)
+	return rows, err
+}
+
+// SaveNftMetadata stores the result of resolving the off-chain metadata of an nft. When the document could
+// not be fetched, the last fetched metadata and attributes are kept
+func (db *Db) SaveNftMetadata(metadata types.NftOffChainMetadata) error {
+	if metadata.Metadata == "" {
+		metadata.Metadata = "{}"
+	}
+
+	if metadata.Attributes == "" {
+		metadata.Attributes = "[]"
+	}
+
+	_, err := db.Sql.Exec(`INSERT INTO nft_nft_metadata (token_id, denom_id, uri, metadata, attributes, status, retries, error, timestamp, next_retry) 
+		VALUES($1, $2, $3, $4, $5, $6, $7, $8, $9, $10) ON CONFLICT (token_id, denom_id) DO UPDATE 
+		SET uri = EXCLUDED.uri,
+			metadata = CASE WHEN EXCLUDED.status = $11 THEN EXCLUDED.metadata ELSE nft_nft_metadata.metadata END,
+			attributes = CASE WHEN EXCLUDED.status = $11 THEN EXCLUDED.attributes ELSE nft_nft_metadata.attributes END,
+			status = EXCLUDED.status,
+			retries = EXCLUDED.retries,
+			error = EXCLUDED.error,
+			timestamp = EXCLUDED.timestamp,
+			next_retry = EXCLUDED.next_retry`,
+		metadata.TokenID, metadata.DenomID, metadata.URI, metadata.Metadata, metadata.Attributes, metadata.Status,
+		metadata.Retries, metadata.Error, metadata.Timestamp, metadata.NextRetry, types.NftMetadataStatusFetched)
+	return err
+}
+
+// ResetNftMetadata marks the off-chain metadata of the given nft to be fetched again
+func (tx *DbTx) ResetNftMetadata(id, denomID string) error {
+	_, err := tx.Exec(`UPDATE nft_nft_metadata SET status = $1, retries = 0, next_retry = 0 WHERE token_id = $2 AND denom_id = $3`,
+		types.NftMetadataStatusPending, id, denomID)
+	return err
+}
+
+func (db *Db) GetDenomIDs() ([]string, error) {
+	var ids []string
+	err := db.Sqlx.Select(&ids, `SELECT id FROM nft_denom`)
+	return ids, err
+}
+
+// GetDenomNftsAttributes returns the attributes of all the live nfts of the given denom, preferring the ones of the
+// resolved off-chain metadata over the ones stored on chain inside the nft data
+func (db *Db) GetDenomNftsAttributes(denomID string) ([]dbtypes.NftAttributesRow, error) {
+	var rows []dbtypes.NftAttributesRow
+	err := db.Sqlx.Select(&rows, `SELECT n.id, COALESCE(
+			CASE WHEN m.status = $2 AND jsonb_array_length(m.attributes) > 0 THEN m.attributes END,
+			CASE WHEN jsonb_typeof(n.data_json -> 'attributes') = 'array' THEN n.data_json -> 'attributes' END,
+			'[]'::JSONB) AS attributes
+		FROM nft_nft n LEFT JOIN nft_nft_metadata m ON m.token_id = n.id AND m.denom_id = n.denom_id
+		WHERE n.denom_id = $1 AND n.burned IS NOT TRUE`,
+		denomID, types.NftMetadataStatusFetched)
+	return rows, err
+}
+
+// SaveDenomRarity replaces the trait frequencies and the nfts rarity of the given denom
+func (db *Db) SaveDenomRarity(denomID string, traits []types.NftTraitFrequency, rarities []types.NftRarity, timestamp int64) error {
+	return db.ExecuteTx(func(dbTx *DbTx) error {
+		if _, err := dbTx.Exec(`DELETE FROM nft_denom_trait WHERE denom_id = $1`, denomID); err != nil {
+			return err
+		}
+
+		if _, err := dbTx.Exec(`DELETE FROM nft_nft_rarity WHERE denom_id = $1`, denomID); err != nil {
+			return err
+		}
+
+		for _, t := range traits {
+			if _, err := dbTx.Exec(`INSERT INTO nft_denom_trait (denom_id, trait_type, value, count, frequency, timestamp) 
+				VALUES ($1, $2, $3, $4, $5, $6)`, denomID, t.TraitType, t.Value, t.Count, t.Frequency, timestamp); err != nil {
+				return err
+			}
+		}
+
+		for _, r := range rarities {
+			if _, err := dbTx.Exec(`INSERT INTO nft_nft_rarity (token_id, denom_id, score, rank, timestamp) 
+				VALUES ($1, $2, $3, $4, $5)`, r.TokenID, denomID, r.Score, r.Rank, timestamp); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
@@ -0,0 +1,363 @@
+package database_test
+
+import (
+	"github.com/forbole/bdjuno/v2/database"
+	"github.com/forbole/bdjuno/v2/types"
+)
+
+func (suite *DbTestSuite) TestNft_Approvals() {
+	txHash := "txhash"
+	denomID := "denom1"
+
+	insertDummyTransaction(suite, 1, txHash)
+
+	err := suite.database.SaveDenom(txHash, denomID, "name", "schema", "symbol", "owner", "", "", "", "", "", "{}")
+	suite.Require().NoError(err)
+
+	err = suite.database.ExecuteTx(func(dbTx *database.DbTx) error {
+		return dbTx.SaveNFT(txHash, 1, denomID, "name", "uri", "{}", "", "owner", "owner", "")
+	})
+	suite.Require().NoError(err)
+
+	suite.Require().NoError(suite.database.SaveNFTApproval(txHash, 1, "1", denomID, "approved1"))
+	suite.Require().NoError(suite.database.SaveNFTApproval(txHash, 1, "1", denomID, "approved2"))
+	suite.Require().NoError(suite.database.SaveNFTApproval(txHash, 1, "1", denomID, "approved2"))
+	suite.Require().Equal(2, suite.countRows(`SELECT COUNT(*) FROM nft_approval`))
+
+	suite.Require().NoError(suite.database.DeleteNFTApproval("1", denomID, "approved1"))
+	suite.Require().Equal(1, suite.countRows(`SELECT COUNT(*) FROM nft_approval`))
+
+	err = suite.database.ExecuteTx(func(dbTx *database.DbTx) error {
+		if err := dbTx.DeleteNFTApprovals("1", denomID); err != nil {
+			return err
+		}
+
+		return dbTx.UpdateNFTOwner("1", denomID, "new_owner")
+	})
+	suite.Require().NoError(err)
+	suite.Require().Equal(0, suite.countRows(`SELECT COUNT(*) FROM nft_approval`))
+
+	suite.Require().NoError(suite.database.SaveNFTOperator(txHash, 1, "owner", "operator", true))
+	suite.Require().Equal(1, suite.countRows(`SELECT COUNT(*) FROM nft_operator WHERE owner = 'owner'`))
+
+	suite.Require().NoError(suite.database.SaveNFTOperator(txHash, 1, "owner", "operator", false))
+	suite.Require().Equal(0, suite.countRows(`SELECT COUNT(*) FROM nft_operator WHERE owner = 'owner'`))
+}
+
+func (suite *DbTestSuite) countRows(query string) int {
+	var count int
+	err := suite.database.Sql.QueryRow(query).Scan(&count)
+	suite.Require().NoError(err)
+	return count
+}
+
+func (suite *DbTestSuite) TestNft_SaveNFTRevision() {
+	txHash := "txhash"
+	denomID := "denom1"
+
+	insertDummyTransaction(suite, 1, txHash)
+
+	err := suite.database.SaveDenom(txHash, denomID, "name", "schema", "symbol", "owner", "", "", "", "", "", "{}")
+	suite.Require().NoError(err)
+
+	err = suite.database.ExecuteTx(func(dbTx *database.DbTx) error {
+		if err := dbTx.SaveNFT(txHash, 1, denomID, "name", "uri", `{"a":1}`, "", "owner", "owner", ""); err != nil {
+			return err
+		}
+
+		previous, err := dbTx.GetNFTMetadata("1", denomID)
+		if err != nil {
+			return err
+		}
+		suite.Require().Equal("name", previous.Name)
+
+		revision := types.NftRevision{
+			TokenID:  1,
+			DenomID:  denomID,
+			Previous: previous,
+			New:      types.NftMetadata{Name: "new_name", URI: "uri", DataJSON: "{}", DataText: "text"},
+			Editor:   "owner",
+			Height:   1,
+			TxHash:   txHash,
+		}
+
+		// Saving the same message twice must not duplicate the revision
+		for i := 0; i < 2; i++ {
+			if err := dbTx.SaveNFTRevision(revision); err != nil {
+				return err
+			}
+		}
+
+		return dbTx.UpdateNFT("1", denomID, revision.New.Name, revision.New.URI, revision.New.DataJSON, revision.New.DataText)
+	})
+	suite.Require().NoError(err)
+
+	var rows []struct {
+		PreviousName string `db:"previous_name"`
+		NewName      string `db:"new_name"`
+		NewDataText  string `db:"new_data_text"`
+	}
+	err = suite.database.Sqlx.Select(&rows, `SELECT previous_name, new_name, new_data_text FROM nft_nft_revision WHERE token_id = 1 AND denom_id = $1`, denomID)
+	suite.Require().NoError(err)
+	suite.Require().Len(rows, 1)
+	suite.Require().Equal("name", rows[0].PreviousName)
+	suite.Require().Equal("new_name", rows[0].NewName)
+	suite.Require().Equal("text", rows[0].NewDataText)
+}
+
+func (suite *DbTestSuite) TestNft_UpdateDenom() {
+	txHash := "txhash"
+	denomID := "denom1"
+
+	insertDummyTransaction(suite, 1, txHash)
+
+	err := suite.database.SaveDenom(txHash, denomID, "name", "schema", "symbol", "owner", "", "", "", "", "", "{}")
+	suite.Require().NoError(err)
+
+	err = suite.database.ExecuteTx(func(dbTx *database.DbTx) error {
+		if err := dbTx.UpdateDenom(txHash, 0, 1, denomID, "new_owner", 10); err != nil {
+			return err
+		}
+
+		return dbTx.UpdateDenom(txHash, 1, 1, denomID, "newest_owner", 10)
+	})
+	suite.Require().NoError(err)
+
+	var owner string
+	err = suite.database.Sql.QueryRow(`SELECT owner FROM nft_denom WHERE id = $1`, denomID).Scan(&owner)
+	suite.Require().NoError(err)
+	suite.Require().Equal("newest_owner", owner)
+
+	var rows []struct {
+		OldOwner string `db:"old_owner"`
+		NewOwner string `db:"new_owner"`
+	}
+	err = suite.database.Sqlx.Select(&rows, `SELECT old_owner, new_owner FROM nft_denom_transfer_history WHERE denom_id = $1 ORDER BY msg_index`, denomID)
+	suite.Require().NoError(err)
+	suite.Require().Len(rows, 2)
+	suite.Require().Equal("owner", rows[0].OldOwner)
+	suite.Require().Equal("new_owner", rows[0].NewOwner)
+	suite.Require().Equal("new_owner", rows[1].OldOwner)
+	suite.Require().Equal("newest_owner", rows[1].NewOwner)
+}
+
+func (suite *DbTestSuite) TestNft_UpdateDenomsStatistics() {
+	txHash := "txhash"
+	denomID := "denom1"
+
+	insertDummyTransaction(suite, 1, txHash)
+
+	err := suite.database.SaveDenom(txHash, denomID, "name", "schema", "symbol", "owner", "", "", "", "", "", "{}")
+	suite.Require().NoError(err)
+
+	err = suite.database.ExecuteTx(func(dbTx *database.DbTx) error {
+		for tokenID, owner := range []string{"owner1", "owner2", "owner2"} {
+			if err := dbTx.SaveNFT(txHash, uint64(tokenID), denomID, "name", "uri", "{}", "", owner, "owner", ""); err != nil {
+				return err
+			}
+
+			if err := dbTx.UpdateNFTHistory(txHash, uint64(tokenID), denomID, "0x0", owner, 10); err != nil {
+				return err
+			}
+		}
+
+		if err := dbTx.UpdateNFTHistory(txHash, 1, denomID, "owner2", "owner3", 10); err != nil {
+			return err
+		}
+
+		if err := dbTx.UpdateNFTOwner("1", denomID, "owner3"); err != nil {
+			return err
+		}
+
+		return dbTx.BurnNFT("0", denomID)
+	})
+	suite.Require().NoError(err)
+
+	err = suite.database.UpdateDenomsStatistics(1)
+	suite.Require().NoError(err)
+
+	var stats struct {
+		Minted    int64 `db:"minted"`
+		Burned    int64 `db:"burned"`
+		Supply    int64 `db:"supply"`
+		Holders   int64 `db:"holders"`
+		Transfers int64 `db:"transfers"`
+	}
+	err = suite.database.Sqlx.Get(&stats, `SELECT minted, burned, supply, holders, transfers FROM nft_denom_statistics WHERE denom_id = $1`, denomID)
+	suite.Require().NoError(err)
+	suite.Require().Equal(int64(3), stats.Minted)
+	suite.Require().Equal(int64(1), stats.Burned)
+	suite.Require().Equal(int64(2), stats.Supply)
+	suite.Require().Equal(int64(2), stats.Holders)
+	suite.Require().Equal(int64(1), stats.Transfers)
+}
+
+func (suite *DbTestSuite) TestNft_UpdateDenomStatisticsIncrementally() {
+	txHash := "txhash"
+	denomID := "denom1"
+
+	insertDummyTransaction(suite, 1, txHash)
+
+	err := suite.database.SaveDenom(txHash, denomID, "name", "schema", "symbol", "owner", "", "", "", "", "", "{}")
+	suite.Require().NoError(err)
+
+	err = suite.database.ExecuteTx(func(dbTx *database.DbTx) error {
+		for tokenID, owner := range []string{"owner1", "owner2", "owner2"} {
+			if err := dbTx.SaveNFT(txHash, uint64(tokenID), denomID, "name", "uri", "{}", "", owner, "owner", ""); err != nil {
+				return err
+			}
+
+			if err := dbTx.UpdateDenomStatisticsOnMint(denomID, owner, 1); err != nil {
+				return err
+			}
+		}
+
+		owner, burned, err := dbTx.GetNFTOwner("1", denomID)
+		if err != nil {
+			return err
+		}
+		suite.Require().Equal("owner2", owner)
+		suite.Require().False(burned)
+
+		if err := dbTx.UpdateNFTOwner("1", denomID, "owner3"); err != nil {
+			return err
+		}
+
+		if err := dbTx.UpdateDenomStatisticsOnTransfer(denomID, "owner2", "owner3", 2); err != nil {
+			return err
+		}
+
+		if err := dbTx.BurnNFT("0", denomID); err != nil {
+			return err
+		}
+
+		return dbTx.UpdateDenomStatisticsOnBurn(denomID, "owner1", 3)
+	})
+	suite.Require().NoError(err)
+
+	var stats struct {
+		Minted    int64 `db:"minted"`
+		Burned    int64 `db:"burned"`
+		Supply    int64 `db:"supply"`
+		Holders   int64 `db:"holders"`
+		Transfers int64 `db:"transfers"`
+		Height    int64 `db:"height"`
+	}
+	err = suite.database.Sqlx.Get(&stats, `SELECT minted, burned, supply, holders, transfers, height FROM nft_denom_statistics WHERE denom_id = $1`, denomID)
+	suite.Require().NoError(err)
+	suite.Require().Equal(int64(3), stats.Minted)
+	suite.Require().Equal(int64(1), stats.Burned)
+	suite.Require().Equal(int64(2), stats.Supply)
+	suite.Require().Equal(int64(2), stats.Holders)
+	suite.Require().Equal(int64(1), stats.Transfers)
+	suite.Require().Equal(int64(3), stats.Height)
+}
+
+func (suite *DbTestSuite) TestNft_NftMetadata() {
+	txHash := "txhash"
+	denomID := "denom1"
+
+	insertDummyTransaction(suite, 1, txHash)
+
+	err := suite.database.SaveDenom(txHash, denomID, "name", "schema", "symbol", "owner", "", "", "", "", "", "{}")
+	suite.Require().NoError(err)
+
+	err = suite.database.ExecuteTx(func(dbTx *database.DbTx) error {
+		for tokenID, uri := range []string{"ipfs://hash1", "ipfs://hash2", ""} {
+			if err := dbTx.SaveNFT(txHash, uint64(tokenID), denomID, "name", uri, "{}", "", "owner", "owner", ""); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	suite.Require().NoError(err)
+
+	nfts, err := suite.database.GetNftsToResolveMetadata(2, 10, 1)
+	suite.Require().NoError(err)
+	suite.Require().Len(nfts, 2)
+
+	err = suite.database.SaveNftMetadata(types.NftOffChainMetadata{TokenID: 0, DenomID: denomID, URI: "ipfs://hash1",
+		Metadata: `{"name":"nft"}`, Status: types.NftMetadataStatusFetched, Timestamp: 1})
+	suite.Require().NoError(err)
+
+	err = suite.database.SaveNftMetadata(types.NftOffChainMetadata{TokenID: 1, DenomID: denomID, URI: "ipfs://hash2",
+		Status: types.NftMetadataStatusFailed, Retries: 2, Error: "unavailable", Timestamp: 1})
+	suite.Require().NoError(err)
+
+	// Failing again must not overwrite the last fetched document
+	err = suite.database.SaveNftMetadata(types.NftOffChainMetadata{TokenID: 0, DenomID: denomID, URI: "ipfs://hash1",
+		Status: types.NftMetadataStatusFailed, Retries: 1, Error: "unavailable", Timestamp: 2, NextRetry: 10})
+	suite.Require().NoError(err)
+
+	var metadata string
+	err = suite.database.Sqlx.Get(&metadata, `SELECT metadata FROM nft_nft_metadata WHERE token_id = 0 AND denom_id = $1`, denomID)
+	suite.Require().NoError(err)
+	suite.Require().JSONEq(`{"name":"nft"}`, metadata)
+
+	// Nfts that failed too many times or that are not due to be retried yet are not returned
+	nfts, err = suite.database.GetNftsToResolveMetadata(2, 10, 1)
+	suite.Require().NoError(err)
+	suite.Require().Len(nfts, 0)
+
+	nfts, err = suite.database.GetNftsToResolveMetadata(2, 10, 10)
+	suite.Require().NoError(err)
+	suite.Require().Len(nfts, 1)
+	suite.Require().Equal(uint64(0), nfts[0].TokenID)
+
+	err = suite.database.ExecuteTx(func(dbTx *database.DbTx) error {
+		return dbTx.ResetNftMetadata("1", denomID)
+	})
+	suite.Require().NoError(err)
+
+	nfts, err = suite.database.GetNftsToResolveMetadata(2, 10, 1)
+	suite.Require().NoError(err)
+	suite.Require().Len(nfts, 1)
+	suite.Require().Equal(uint64(1), nfts[0].TokenID)
+	suite.Require().Equal(0, nfts[0].Retries)
+}
+
+func (suite *DbTestSuite) TestNft_SaveDenomRarity() {
+	txHash := "txhash"
+	denomID := "denom1"
+
+	insertDummyTransaction(suite, 1, txHash)
+
+	err := suite.database.SaveDenom(txHash, denomID, "name", "schema", "symbol", "owner", "", "", "", "", "", "{}")
+	suite.Require().NoError(err)
+
+	err = suite.database.ExecuteTx(func(dbTx *database.DbTx) error {
+		if err := dbTx.SaveNFT(txHash, 1, denomID, "name", "uri", `{"attributes":[{"trait_type":"color","value":"red"}]}`, "", "owner", "owner", ""); err != nil {
+			return err
+		}
+		return dbTx.SaveNFT(txHash, 2, denomID, "name", "uri", `{}`, "", "owner", "owner", "")
+	})
+	suite.Require().NoError(err)
+
+	err = suite.database.SaveNftMetadata(types.NftOffChainMetadata{TokenID: 2, DenomID: denomID, URI: "uri",
+		Attributes: `[{"trait_type":"color","value":"blue"}]`, Status: types.NftMetadataStatusFetched, Timestamp: 1})
+	suite.Require().NoError(err)
+
+	rows, err := suite.database.GetDenomNftsAttributes(denomID)
+	suite.Require().NoError(err)
+	suite.Require().Len(rows, 2)
+	for _, row := range rows {
+		if row.TokenID == 1 {
+			suite.Require().JSONEq(`[{"trait_type":"color","value":"red"}]`, row.Attributes)
+		} else {
+			suite.Require().JSONEq(`[{"trait_type":"color","value":"blue"}]`, row.Attributes)
+		}
+	}
+
+	red := types.NftTrait{TraitType: "color", Value: "red"}
+	for i := 0; i < 2; i++ {
+		err = suite.database.SaveDenomRarity(denomID,
+			[]types.NftTraitFrequency{{NftTrait: red, Count: 1, Frequency: 0.5}},
+			[]types.NftRarity{{TokenID: 1, Score: 2, Rank: 1}, {TokenID: 2, Score: 2, Rank: 1}},
+			1,
+		)
+		suite.Require().NoError(err)
+	}
+
+	suite.Require().Equal(1, suite.countRows(`SELECT COUNT(*) FROM nft_denom_trait`))
+	suite.Require().Equal(2, suite.countRows(`SELECT COUNT(*) FROM nft_nft_rarity`))
+}
@@ -123,3 +123,31 @@ func (suite *DbTestSuite) TestBigDipperDb_SaveTokenPrice() {
 		suite.Require().True(expected[i].Equals(row))
 	}
 }
+
+func (suite *DbTestSuite) TestBigDipperDb_GetTokenPriceHistoryAt() {
+	suite.insertToken("desmos")
+
+	err := suite.database.SaveTokenPricesHistory([]types.TokenPrice{
+		types.NewTokenPrice("desmos", 100.01, 10, time.Date(2020, 10, 10, 15, 00, 00, 000, time.UTC)),
+		types.NewTokenPrice("desmos", 200.01, 20, time.Date(2020, 10, 10, 16, 00, 00, 000, time.UTC)),
+	})
+	suite.Require().NoError(err)
+
+	price, found, err := suite.database.GetTokenPriceHistoryAt("desmos", time.Date(2020, 10, 10, 15, 30, 00, 000, time.UTC), time.Hour)
+	suite.Require().NoError(err)
+	suite.Require().True(found)
+	suite.Require().Equal(100.01, price)
+
+	price, found, err = suite.database.GetTokenPriceHistoryAt("desmos", time.Date(2020, 10, 10, 16, 00, 00, 000, time.UTC), time.Hour)
+	suite.Require().NoError(err)
+	suite.Require().True(found)
+	suite.Require().Equal(200.01, price)
+
+	_, found, err = suite.database.GetTokenPriceHistoryAt("desmos", time.Date(2020, 10, 10, 18, 00, 00, 000, time.UTC), time.Hour)
+	suite.Require().NoError(err)
+	suite.Require().False(found)
+
+	_, found, err = suite.database.GetTokenPriceHistoryAt("desmos", time.Date(2020, 10, 10, 14, 00, 00, 000, time.UTC), time.Hour)
+	suite.Require().NoError(err)
+	suite.Require().False(found)
+}
@@ -0,0 +1,120 @@
+package database
+
+import (
+	"fmt"
+
+	dbtypes "github.com/forbole/bdjuno/v2/database/types"
+	"github.com/forbole/bdjuno/v2/types"
+)
+
+// GetValidatorsRatingData returns the data used to compute the rating of all the stored validators.
+// Validators without signing info have not signed any block yet, so their uptime is 0
+func (db *Db) GetValidatorsRatingData() ([]types.ValidatorRatingData, error) {
+	stmt := `
+WITH delegation_total AS (
+    SELECT validator_address, SUM((amount).amount::NUMERIC) AS amount, COUNT(*) AS delegators_count
+    FROM delegation
+    GROUP BY validator_address
+),
+     signed_blocks_window AS (
+         SELECT COALESCE((SELECT (params ->> 'signed_blocks_window')::NUMERIC FROM slashing_params), 0) AS value
+     ),
+     voting_proposals AS (
+         SELECT id FROM proposal WHERE voting_start_time IS NOT NULL
+     )
+SELECT vi.consensus_address AS validator_address,
+       COALESCE((self_delegation.amount).amount::NUMERIC / NULLIF(delegation_total.amount, 0), 0)::FLOAT AS self_delegation_ratio,
+       CASE
+           WHEN vsi.validator_address IS NULL THEN 0
+           WHEN sbw.value = 0 THEN 1
+           ELSE GREATEST(1 - vsi.missed_blocks_counter / sbw.value, 0)
+           END::FLOAT AS uptime,
+       COALESCE(vsi.tombstoned, FALSE)
+           OR COALESCE(vsi.jailed_until > 'epoch', FALSE)
+           OR EXISTS(
+               SELECT 1
+               FROM double_sign_evidence evidence
+                        JOIN double_sign_vote vote ON vote.id = evidence.vote_a_id
+               WHERE vote.validator_address = vi.consensus_address
+           ) AS slashed,
+       COALESCE((
+                    SELECT COUNT(DISTINCT vote.proposal_id)::NUMERIC
+                    FROM proposal_vote vote
+                    WHERE vote.voter_address = vi.self_delegate_address
+                      AND vote.proposal_id IN (SELECT id FROM voting_proposals)
+                ) / NULLIF((SELECT COUNT(*) FROM voting_proposals), 0), 0)::FLOAT AS gov_participation,
+       COALESCE(delegation_total.delegators_count, 0) AS delegators_count,
+       COALESCE(vvp.voting_power, 0) AS voting_power
+FROM validator_info vi
+         CROSS JOIN signed_blocks_window sbw
+         LEFT JOIN delegation_total ON delegation_total.validator_address = vi.operator_address
+         LEFT JOIN delegation self_delegation ON self_delegation.validator_address = vi.operator_address
+    AND self_delegation.delegator_address = vi.self_delegate_address
+         LEFT JOIN validator_signing_info vsi ON vsi.validator_address = vi.consensus_address
+         LEFT JOIN validator_voting_power vvp ON vvp.validator_address = vi.consensus_address
+ORDER BY vi.consensus_address`
+
+	var rows []dbtypes.ValidatorRatingDataRow
+	err := db.Sqlx.Select(&rows, stmt)
+	if err != nil {
+		return nil, err
+	}
+
+	data := make([]types.ValidatorRatingData, len(rows))
+	for i, row := range rows {
+		data[i] = types.NewValidatorRatingData(
+			row.ValidatorAddress,
+			row.SelfDelegationRatio,
+			row.Uptime,
+			row.Slashed,
+			row.GovParticipation,
+			row.DelegatorsCount,
+			row.VotingPower,
+		)
+	}
+
+	return data, nil
+}
+
+// SaveValidatorsRatings stores the given validators ratings, replacing the ones computed at a lower height
+func (db *Db) SaveValidatorsRatings(ratings []types.ValidatorRating) error {
+	if len(ratings) == 0 {
+		return nil
+	}
+
+	stmt := `
+INSERT INTO validator_rating 
+    (validator_address, self_delegation_ratio, uptime, slashed, gov_participation, delegators_count, voting_power, score, height) 
+VALUES `
+	var params []interface{}
+
+	for i, rating := range ratings {
+		vi := i * 9
+		stmt += fmt.Sprintf("($%d, $%d, $%d, $%d, $%d, $%d, $%d, $%d, $%d),",
+			vi+1, vi+2, vi+3, vi+4, vi+5, vi+6, vi+7, vi+8, vi+9)
+		params = append(params,
+			rating.ValidatorAddress, rating.SelfDelegationRatio, rating.Uptime, rating.Slashed, rating.GovParticipation,
+			rating.DelegatorsCount, rating.VotingPower, rating.Score, rating.Height,
+		)
+	}
+
+	stmt = stmt[:len(stmt)-1] // Remove trailing ","
+	stmt += `
+ON CONFLICT (validator_address) DO UPDATE 
+	SET self_delegation_ratio = excluded.self_delegation_ratio,
+	    uptime = excluded.uptime,
+	    slashed = excluded.slashed,
+	    gov_participation = excluded.gov_participation,
+	    delegators_count = excluded.delegators_count,
+	    voting_power = excluded.voting_power,
+	    score = excluded.score,
+	    height = excluded.height
+WHERE validator_rating.height <= excluded.height`
+
+	_, err := db.Sql.Exec(stmt, params...)
+	if err != nil {
+		return fmt.Errorf("error while storing validators ratings: %s", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,73 @@
+package database_test
+
+import (
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	dbtypes "github.com/forbole/bdjuno/v2/database/types"
+	"github.com/forbole/bdjuno/v2/types"
+)
+
+func (suite *DbTestSuite) TestGetValidatorsRatingData() {
+	validator := "cudos1a326k254fukx9jlp0h3fwcr2ymjgludzum67d1"
+	delegator := "cudos1a326k254fukx9jlp0h3fwcr2ymjgludzum2ba1"
+	suite.saveDelegationsValidators([]string{delegator}, []string{validator})
+
+	// The validator self delegates a quarter of its delegations
+	suite.Require().NoError(suite.database.ReplaceValidatorDelegations(validator, []types.Delegation{
+		types.NewDelegation(validator, validator, sdk.NewCoin("acudos", sdk.NewInt(100)), 10),
+		types.NewDelegation(delegator, validator, sdk.NewCoin("acudos", sdk.NewInt(300)), 10),
+	}, 10))
+
+	data, err := suite.database.GetValidatorsRatingData()
+	suite.Require().NoError(err)
+	suite.Require().Equal([]types.ValidatorRatingData{
+		types.NewValidatorRatingData(validator, 0.25, 0, false, 0, 2, 0),
+	}, data)
+
+	// The uptime is computed from the signing info once it is stored
+	suite.Require().NoError(suite.database.SaveValidatorsSigningInfos([]types.ValidatorSigningInfo{
+		types.NewValidatorSigningInfo(validator, 0, 0, time.Unix(0, 0).UTC(), false, 0, 10),
+	}))
+
+	data, err = suite.database.GetValidatorsRatingData()
+	suite.Require().NoError(err)
+	suite.Require().Equal([]types.ValidatorRatingData{
+		types.NewValidatorRatingData(validator, 0.25, 1, false, 0, 2, 0),
+	}, data)
+}
+
+func (suite *DbTestSuite) TestSaveValidatorsRatings() {
+	validator := suite.getValidator(
+		"cosmosvalcons1qqqqrezrl53hujmpdch6d805ac75n220ku09rl",
+		"cosmosvaloper1rcp29q3hpd246n6qak7jluqep4v006cdsc2kkl",
+		"cosmosvalconspub1zcjduepq7mft6gfls57a0a42d7uhx656cckhfvtrlmw744jv4q0mvlv0dypskehfk8",
+	)
+
+	data := types.NewValidatorRatingData(validator.GetConsAddr(), 0.5, 1, false, 1, 10, 25)
+	suite.Require().NoError(suite.database.SaveValidatorsRatings([]types.ValidatorRating{
+		types.NewValidatorRating(data, 90, 10),
+	}))
+
+	// Older ratings should not override newer ones
+	suite.Require().NoError(suite.database.SaveValidatorsRatings([]types.ValidatorRating{
+		types.NewValidatorRating(data, 50, 9),
+	}))
+
+	var rows []dbtypes.ValidatorRatingRow
+	suite.Require().NoError(suite.database.Sqlx.Select(&rows, `SELECT * FROM validator_rating`))
+	suite.Require().Equal([]dbtypes.ValidatorRatingRow{
+		{
+			ValidatorAddress:    validator.GetConsAddr(),
+			SelfDelegationRatio: 0.5,
+			Uptime:              1,
+			Slashed:             false,
+			GovParticipation:    1,
+			DelegatorsCount:     10,
+			VotingPower:         25,
+			Score:               90,
+			Height:              10,
+		},
+	}, rows)
+}
@@ -0,0 +1,73 @@
+CREATE TABLE cw721token_code_id
+(
+    id INT NOT NULL PRIMARY KEY
+);
+
+CREATE TABLE cw721token_collection
+(
+    address    TEXT   NOT NULL PRIMARY KEY,
+    code_id    INT    NOT NULL REFERENCES cw721token_code_id(id),
+    name       TEXT   NOT NULL,
+    symbol     TEXT   NOT NULL,
+    minter     TEXT   NULL,
+    num_tokens BIGINT NOT NULL DEFAULT 0,
+    creator    TEXT   NOT NULL
+);
+
+CREATE INDEX cw721token_collection_code_id_index ON cw721token_collection (code_id);
+CREATE INDEX cw721token_collection_creator_index ON cw721token_collection (creator);
+
+CREATE TABLE cw721token_nft
+(
+    collection TEXT    NOT NULL REFERENCES cw721token_collection(address) ON DELETE CASCADE,
+    token_id   TEXT    NOT NULL,
+    owner      TEXT    NOT NULL,
+    token_uri  TEXT    NULL,
+    extension  JSONB   NOT NULL DEFAULT '{}'::JSONB,
+    burned     BOOLEAN NOT NULL DEFAULT FALSE,
+    PRIMARY KEY (collection, token_id)
+);
+
+CREATE INDEX cw721token_nft_collection_index ON cw721token_nft (collection);
+CREATE INDEX cw721token_nft_owner_index ON cw721token_nft (owner);
+
+CREATE TABLE cw721token_approval
+(
+    collection TEXT  NOT NULL,
+    token_id   TEXT  NOT NULL,
+    spender    TEXT  NOT NULL,
+    expires    JSONB NOT NULL DEFAULT '{}'::JSONB,
+    PRIMARY KEY (collection, token_id, spender),
+    FOREIGN KEY (collection, token_id) REFERENCES cw721token_nft(collection, token_id) ON DELETE CASCADE
+);
+
+CREATE INDEX cw721token_approval_spender_index ON cw721token_approval (spender);
+
+CREATE TABLE cw721token_operator
+(
+    collection TEXT  NOT NULL REFERENCES cw721token_collection(address) ON DELETE CASCADE,
+    owner      TEXT  NOT NULL,
+    operator   TEXT  NOT NULL,
+    expires    JSONB NOT NULL DEFAULT '{}'::JSONB,
+    PRIMARY KEY (collection, owner, operator)
+);
+
+CREATE INDEX cw721token_operator_operator_index ON cw721token_operator (operator);
+
+CREATE TABLE cw721token_history
+(
+    collection       TEXT   NOT NULL REFERENCES cw721token_collection(address) ON DELETE CASCADE,
+    token_id         TEXT   NOT NULL,
+    type             TEXT   NOT NULL,
+    sender           TEXT   NOT NULL,
+    from_address     TEXT   NOT NULL,
+    to_address       TEXT   NOT NULL,
+    height           BIGINT NOT NULL,
+    transaction_hash TEXT   NOT NULL,
+    msg_index        INT    NOT NULL,
+    PRIMARY KEY (transaction_hash, msg_index)
+);
+
+CREATE INDEX cw721token_history_collection_token_id_index ON cw721token_history (collection, token_id);
+CREATE INDEX cw721token_history_from_address_index ON cw721token_history (from_address);
+CREATE INDEX cw721token_history_to_address_index ON cw721token_history (to_address);
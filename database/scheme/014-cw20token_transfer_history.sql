@@ -0,0 +1,17 @@
+CREATE TABLE cw20token_transfer_history
+(
+    token            TEXT   NOT NULL REFERENCES cw20token_info(address) ON DELETE CASCADE,
+    type             TEXT   NOT NULL,
+    from_address     TEXT   NOT NULL,
+    to_address       TEXT   NOT NULL,
+    amount           TEXT   NOT NULL,
+    height           BIGINT NOT NULL,
+    transaction_hash TEXT   NOT NULL,
+    msg_index        INT    NOT NULL,
+    PRIMARY KEY (transaction_hash, msg_index)
+);
+
+CREATE INDEX cw20token_transfer_history_token_index ON cw20token_transfer_history (token);
+CREATE INDEX cw20token_transfer_history_from_address_index ON cw20token_transfer_history (from_address);
+CREATE INDEX cw20token_transfer_history_to_address_index ON cw20token_transfer_history (to_address);
+CREATE INDEX cw20token_transfer_history_height_index ON cw20token_transfer_history (height);
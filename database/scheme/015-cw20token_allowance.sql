@@ -0,0 +1,12 @@
+CREATE TABLE cw20token_allowance
+(
+    token   TEXT  NOT NULL REFERENCES cw20token_info(address) ON DELETE CASCADE,
+    owner   TEXT  NOT NULL,
+    spender TEXT  NOT NULL,
+    amount  TEXT  NOT NULL,
+    expires JSONB NOT NULL DEFAULT '{}'::JSONB,
+    PRIMARY KEY (token, owner, spender)
+);
+
+CREATE INDEX cw20token_allowance_owner_index ON cw20token_allowance (owner);
+CREATE INDEX cw20token_allowance_spender_index ON cw20token_allowance (spender);
@@ -0,0 +1,4 @@
+ALTER TABLE cw20token_transfer_history ADD COLUMN IF NOT EXISTS event_index INT NOT NULL DEFAULT 0;
+
+ALTER TABLE cw20token_transfer_history DROP CONSTRAINT IF EXISTS cw20token_transfer_history_pkey;
+ALTER TABLE cw20token_transfer_history ADD PRIMARY KEY (transaction_hash, msg_index, event_index);
@@ -0,0 +1,19 @@
+/* Volumes, sales count, unique buyers and unique sellers only take the resales into account, excluding the mints */
+CREATE TABLE marketplace_collection_statistics
+(
+    denom_id       TEXT    NOT NULL REFERENCES nft_denom (id) PRIMARY KEY,
+    floor_price    DECIMAL NULL,
+    volume_24h     DECIMAL NOT NULL DEFAULT 0,
+    volume_7d      DECIMAL NOT NULL DEFAULT 0,
+    volume_30d     DECIMAL NOT NULL DEFAULT 0,
+    usd_volume_24h DECIMAL NOT NULL DEFAULT 0,
+    usd_volume_7d  DECIMAL NOT NULL DEFAULT 0,
+    usd_volume_30d DECIMAL NOT NULL DEFAULT 0,
+    sales_count    BIGINT  NOT NULL DEFAULT 0,
+    unique_buyers  BIGINT  NOT NULL DEFAULT 0,
+    unique_sellers BIGINT  NOT NULL DEFAULT 0,
+    owners_count   BIGINT  NOT NULL DEFAULT 0,
+    timestamp      BIGINT  NOT NULL
+);
+
+CREATE INDEX marketplace_nft_buy_history_denom_id_timestamp_index ON marketplace_nft_buy_history (denom_id, timestamp);
@@ -0,0 +1,37 @@
+CREATE TABLE marketplace_collection_royalty
+(
+    collection_id BIGINT  NOT NULL REFERENCES marketplace_collection (id) ON DELETE CASCADE,
+    type          TEXT    NOT NULL,
+    position      INT     NOT NULL,
+    address       TEXT    NOT NULL,
+    percent       DECIMAL NOT NULL,
+    PRIMARY KEY (collection_id, type, position)
+);
+
+CREATE INDEX marketplace_collection_royalty_address_index ON marketplace_collection_royalty (address);
+
+INSERT INTO marketplace_collection_royalty (collection_id, type, position, address, percent)
+SELECT c.id, 'mint', r.position - 1, r.royalty ->> 'address', (r.royalty ->> 'percent')::DECIMAL
+FROM marketplace_collection c, jsonb_array_elements(c.mint_royalties::JSONB) WITH ORDINALITY AS r(royalty, position)
+WHERE jsonb_typeof(c.mint_royalties::JSONB) = 'array';
+
+INSERT INTO marketplace_collection_royalty (collection_id, type, position, address, percent)
+SELECT c.id, 'resale', r.position - 1, r.royalty ->> 'address', (r.royalty ->> 'percent')::DECIMAL
+FROM marketplace_collection c, jsonb_array_elements(c.resale_royalties::JSONB) WITH ORDINALITY AS r(royalty, position)
+WHERE jsonb_typeof(c.resale_royalties::JSONB) = 'array';
+
+CREATE TABLE marketplace_nft_sale_payout
+(
+    transaction_hash TEXT    NOT NULL REFERENCES transaction (hash),
+    token_id         BIGINT  NOT NULL,
+    denom_id         TEXT    NOT NULL REFERENCES nft_denom (id),
+    address          TEXT    NOT NULL,
+    amount           DECIMAL NOT NULL,
+    type             TEXT    NOT NULL,
+    timestamp        BIGINT  NOT NULL,
+    FOREIGN KEY (token_id, denom_id) REFERENCES nft_nft(id, denom_id)
+);
+
+CREATE INDEX marketplace_nft_sale_payout_transaction_hash_index ON marketplace_nft_sale_payout (transaction_hash);
+CREATE INDEX marketplace_nft_sale_payout_token_id_denom_id_index ON marketplace_nft_sale_payout (token_id, denom_id);
+CREATE INDEX marketplace_nft_sale_payout_address_index ON marketplace_nft_sale_payout (address);
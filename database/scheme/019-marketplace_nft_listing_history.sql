@@ -0,0 +1,21 @@
+CREATE TABLE marketplace_nft_listing_history
+(
+    marketplace_nft_id BIGINT  NOT NULL,
+    token_id           BIGINT  NOT NULL,
+    denom_id           TEXT    NOT NULL REFERENCES nft_denom (id),
+    type               TEXT    NOT NULL,
+    price              DECIMAL NOT NULL,
+    account            TEXT    NOT NULL,
+    height             BIGINT  NOT NULL,
+    transaction_hash   TEXT    NOT NULL REFERENCES transaction (hash),
+    msg_index          BIGINT  NOT NULL,
+    timestamp          BIGINT  NOT NULL,
+    uniq_id            TEXT    NOT NULL,
+    PRIMARY KEY (transaction_hash, msg_index),
+    FOREIGN KEY (token_id, denom_id) REFERENCES nft_nft(id, denom_id)
+);
+
+CREATE INDEX marketplace_nft_listing_history_token_id_denom_id_index ON marketplace_nft_listing_history (token_id, denom_id);
+CREATE INDEX marketplace_nft_listing_history_uniq_id_index ON marketplace_nft_listing_history (uniq_id);
+CREATE INDEX marketplace_nft_listing_history_marketplace_nft_id_index ON marketplace_nft_listing_history (marketplace_nft_id);
+CREATE INDEX marketplace_nft_listing_history_timestamp_index ON marketplace_nft_listing_history (timestamp);
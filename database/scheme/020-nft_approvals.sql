@@ -0,0 +1,24 @@
+CREATE TABLE nft_approval
+(
+    token_id         BIGINT NOT NULL,
+    denom_id         TEXT   NOT NULL REFERENCES nft_denom (id),
+    address          TEXT   NOT NULL,
+    height           BIGINT NOT NULL,
+    transaction_hash TEXT   NOT NULL REFERENCES transaction (hash),
+    PRIMARY KEY (token_id, denom_id, address),
+    FOREIGN KEY (token_id, denom_id) REFERENCES nft_nft(id, denom_id)
+);
+
+CREATE INDEX nft_approval_address_index ON nft_approval (address);
+
+/* Operators are approved by the owner for all of its nfts, regardless of the denom */
+CREATE TABLE nft_operator
+(
+    owner            TEXT   NOT NULL,
+    operator         TEXT   NOT NULL,
+    height           BIGINT NOT NULL,
+    transaction_hash TEXT   NOT NULL REFERENCES transaction (hash),
+    PRIMARY KEY (owner, operator)
+);
+
+CREATE INDEX nft_operator_operator_index ON nft_operator (operator);
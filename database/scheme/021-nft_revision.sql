@@ -0,0 +1,24 @@
+CREATE TABLE nft_nft_revision
+(
+    token_id           BIGINT NOT NULL,
+    denom_id           TEXT   NOT NULL REFERENCES nft_denom (id),
+    previous_name      TEXT   NOT NULL,
+    previous_uri       TEXT   NOT NULL,
+    previous_data_json JSONB  NOT NULL DEFAULT '{}'::JSONB,
+    previous_data_text TEXT   NOT NULL DEFAULT '',
+    new_name           TEXT   NOT NULL,
+    new_uri            TEXT   NOT NULL,
+    new_data_json      JSONB  NOT NULL DEFAULT '{}'::JSONB,
+    new_data_text      TEXT   NOT NULL DEFAULT '',
+    editor             TEXT   NOT NULL,
+    height             BIGINT NOT NULL,
+    transaction_hash   TEXT   NOT NULL REFERENCES transaction (hash),
+    msg_index          BIGINT NOT NULL,
+    uniq_id            TEXT   NOT NULL,
+    PRIMARY KEY (transaction_hash, msg_index),
+    FOREIGN KEY (token_id, denom_id) REFERENCES nft_nft(id, denom_id)
+);
+
+CREATE INDEX nft_nft_revision_token_id_denom_id_index ON nft_nft_revision (token_id, denom_id);
+CREATE INDEX nft_nft_revision_uniq_id_index ON nft_nft_revision (uniq_id);
+CREATE INDEX nft_nft_revision_height_index ON nft_nft_revision (height);
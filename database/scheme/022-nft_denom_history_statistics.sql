@@ -0,0 +1,39 @@
+CREATE TABLE nft_denom_transfer_history
+(
+    denom_id         TEXT   NOT NULL REFERENCES nft_denom (id),
+    old_owner        TEXT   NOT NULL,
+    new_owner        TEXT   NOT NULL,
+    height           BIGINT NOT NULL,
+    transaction_hash TEXT   NOT NULL REFERENCES transaction (hash),
+    msg_index        BIGINT NOT NULL,
+    timestamp        BIGINT NOT NULL,
+    PRIMARY KEY (transaction_hash, msg_index)
+);
+
+CREATE INDEX nft_denom_transfer_history_denom_id_index ON nft_denom_transfer_history (denom_id);
+CREATE INDEX nft_denom_transfer_history_old_owner_index ON nft_denom_transfer_history (old_owner);
+CREATE INDEX nft_denom_transfer_history_new_owner_index ON nft_denom_transfer_history (new_owner);
+
+CREATE INDEX nft_nft_denom_id_owner_index ON nft_nft (denom_id, owner);
+CREATE INDEX nft_transfer_history_denom_id_index ON nft_transfer_history (denom_id);
+
+CREATE TABLE nft_denom_statistics
+(
+    denom_id  TEXT   NOT NULL REFERENCES nft_denom (id) PRIMARY KEY,
+    minted    BIGINT NOT NULL DEFAULT 0,
+    burned    BIGINT NOT NULL DEFAULT 0,
+    supply    BIGINT NOT NULL DEFAULT 0,
+    holders   BIGINT NOT NULL DEFAULT 0,
+    transfers BIGINT NOT NULL DEFAULT 0,
+    height    BIGINT NOT NULL
+);
+
+INSERT INTO nft_denom_statistics (denom_id, minted, burned, supply, holders, transfers, height)
+SELECT d.id,
+       (SELECT COUNT(*) FROM nft_nft n WHERE n.denom_id = d.id),
+       (SELECT COUNT(*) FROM nft_nft n WHERE n.denom_id = d.id AND n.burned IS TRUE),
+       (SELECT COUNT(*) FROM nft_nft n WHERE n.denom_id = d.id AND n.burned IS NOT TRUE),
+       (SELECT COUNT(DISTINCT n.owner) FROM nft_nft n WHERE n.denom_id = d.id AND n.burned IS NOT TRUE),
+       (SELECT COUNT(*) FROM nft_transfer_history h WHERE h.denom_id = d.id AND h.old_owner <> '0x0' AND h.new_owner <> '0x0'),
+       COALESCE((SELECT MAX(height) FROM block), 0)
+FROM nft_denom d;
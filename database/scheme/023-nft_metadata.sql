@@ -0,0 +1,16 @@
+CREATE TABLE nft_nft_metadata
+(
+    token_id   BIGINT NOT NULL,
+    denom_id   TEXT   NOT NULL REFERENCES nft_denom (id),
+    uri        TEXT   NOT NULL,
+    metadata   JSONB  NOT NULL DEFAULT '{}'::JSONB,
+    attributes JSONB  NOT NULL DEFAULT '[]'::JSONB,
+    status     TEXT   NOT NULL,
+    retries    INT    NOT NULL DEFAULT 0,
+    error      TEXT   NOT NULL DEFAULT '',
+    timestamp  BIGINT NOT NULL,
+    PRIMARY KEY (token_id, denom_id),
+    FOREIGN KEY (token_id, denom_id) REFERENCES nft_nft(id, denom_id)
+);
+
+CREATE INDEX nft_nft_metadata_status_index ON nft_nft_metadata (status);
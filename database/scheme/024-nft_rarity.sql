@@ -0,0 +1,23 @@
+CREATE TABLE nft_denom_trait
+(
+    denom_id   TEXT             NOT NULL REFERENCES nft_denom (id),
+    trait_type TEXT             NOT NULL,
+    value      TEXT             NOT NULL,
+    count      BIGINT           NOT NULL,
+    frequency  DOUBLE PRECISION NOT NULL,
+    timestamp  BIGINT           NOT NULL,
+    PRIMARY KEY (denom_id, trait_type, value)
+);
+
+CREATE TABLE nft_nft_rarity
+(
+    token_id  BIGINT           NOT NULL,
+    denom_id  TEXT             NOT NULL REFERENCES nft_denom (id),
+    score     DOUBLE PRECISION NOT NULL,
+    rank      BIGINT           NOT NULL,
+    timestamp BIGINT           NOT NULL,
+    PRIMARY KEY (token_id, denom_id),
+    FOREIGN KEY (token_id, denom_id) REFERENCES nft_nft(id, denom_id)
+);
+
+CREATE INDEX nft_nft_rarity_denom_id_rank_index ON nft_nft_rarity (denom_id, rank);
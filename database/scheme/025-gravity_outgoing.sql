@@ -0,0 +1,46 @@
+CREATE TABLE gravity_batch
+(
+    nonce            BIGINT NOT NULL PRIMARY KEY,
+    denom            TEXT   NOT NULL,
+    token_contract   TEXT   NOT NULL DEFAULT '',
+    requester        TEXT   NOT NULL,
+    status           TEXT   NOT NULL,
+    height           BIGINT NOT NULL,
+    transaction_hash TEXT   NOT NULL REFERENCES transaction (hash),
+    executed_height  BIGINT
+);
+
+CREATE INDEX gravity_batch_denom_index ON gravity_batch (denom);
+CREATE INDEX gravity_batch_status_index ON gravity_batch (status);
+
+CREATE TABLE gravity_outgoing_transfer
+(
+    id                      BIGINT  NOT NULL PRIMARY KEY,
+    sender                  TEXT    NOT NULL,
+    eth_dest                TEXT    NOT NULL,
+    denom                   TEXT    NOT NULL,
+    amount                  DECIMAL NOT NULL,
+    bridge_fee              DECIMAL NOT NULL,
+    status                  TEXT    NOT NULL,
+    batch_nonce             BIGINT REFERENCES gravity_batch (nonce),
+    height                  BIGINT  NOT NULL,
+    transaction_hash        TEXT    NOT NULL REFERENCES transaction (hash),
+    cancel_transaction_hash TEXT REFERENCES transaction (hash)
+);
+
+CREATE INDEX gravity_outgoing_transfer_sender_index ON gravity_outgoing_transfer (sender);
+CREATE INDEX gravity_outgoing_transfer_eth_dest_index ON gravity_outgoing_transfer (eth_dest);
+CREATE INDEX gravity_outgoing_transfer_batch_nonce_index ON gravity_outgoing_transfer (batch_nonce);
+CREATE INDEX gravity_outgoing_transfer_denom_status_index ON gravity_outgoing_transfer (denom, status);
+
+/* Batch confirmations are not bound to gravity_batch as they can refer to batches requested before the indexing started */
+CREATE TABLE gravity_batch_confirm
+(
+    batch_nonce      BIGINT NOT NULL,
+    token_contract   TEXT   NOT NULL,
+    orchestrator     TEXT   NOT NULL,
+    eth_signer       TEXT   NOT NULL,
+    height           BIGINT NOT NULL,
+    transaction_hash TEXT   NOT NULL REFERENCES transaction (hash),
+    PRIMARY KEY (batch_nonce, orchestrator)
+);
@@ -0,0 +1,17 @@
+ALTER TABLE gravity_orchestrator
+    ADD COLUMN validator_address TEXT   NOT NULL DEFAULT '',
+    ADD COLUMN eth_address       TEXT   NOT NULL DEFAULT '',
+    ADD COLUMN height            BIGINT NOT NULL DEFAULT 0,
+    ADD COLUMN transaction_hash  TEXT REFERENCES transaction (hash);
+
+CREATE INDEX gravity_orchestrator_validator_address_index ON gravity_orchestrator (validator_address);
+
+/* Validators allowed to register an orchestrator, whose power is the one attestations are weighted against */
+CREATE TABLE gravity_static_validator
+(
+    operator_address TEXT NOT NULL PRIMARY KEY
+);
+
+/* Sum of the power the validators voting for the attestation had at the time of the vote */
+ALTER TABLE gravity_transaction
+    ADD COLUMN power BIGINT NOT NULL DEFAULT 0;
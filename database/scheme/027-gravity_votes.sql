@@ -0,0 +1,49 @@
+/* One row for each orchestrator voting for an attestation, gravity_transaction keeping the aggregated result */
+CREATE TABLE gravity_attestation_vote
+(
+    attestation_id    TEXT   NOT NULL REFERENCES gravity_transaction (attestation_id),
+    orchestrator      TEXT   NOT NULL REFERENCES gravity_orchestrator (address),
+    validator_address TEXT   NOT NULL,
+    power             BIGINT NOT NULL,
+    height            BIGINT NOT NULL,
+    transaction_hash  TEXT   NOT NULL REFERENCES transaction (hash),
+    PRIMARY KEY (attestation_id, orchestrator)
+);
+
+CREATE INDEX gravity_attestation_vote_orchestrator_height_index ON gravity_attestation_vote (orchestrator, height);
+CREATE INDEX gravity_attestation_vote_transaction_hash_index ON gravity_attestation_vote (transaction_hash);
+
+/* The latest voter is the only one known for the attestations stored before votes were tracked */
+INSERT INTO gravity_attestation_vote (attestation_id, orchestrator, validator_address, power, height, transaction_hash)
+SELECT gt.attestation_id, gt.orchestrator, o.validator_address, 0, gt.height, gt.transaction_hash
+FROM gravity_transaction gt
+         JOIN gravity_orchestrator o ON o.address = gt.orchestrator
+ON CONFLICT DO NOTHING;
+
+CREATE TABLE gravity_valset_confirm
+(
+    nonce            BIGINT NOT NULL,
+    orchestrator     TEXT   NOT NULL,
+    eth_address      TEXT   NOT NULL,
+    height           BIGINT NOT NULL,
+    transaction_hash TEXT   NOT NULL REFERENCES transaction (hash),
+    PRIMARY KEY (nonce, orchestrator)
+);
+
+CREATE INDEX gravity_valset_confirm_orchestrator_height_index ON gravity_valset_confirm (orchestrator, height);
+
+/* Validator sets the orchestrators claim to have been updated on Ethereum */
+CREATE TABLE gravity_valset_update
+(
+    valset_nonce     BIGINT  NOT NULL,
+    attestation_id   TEXT    NOT NULL REFERENCES gravity_transaction (attestation_id),
+    members          JSONB   NOT NULL DEFAULT '[]'::JSONB,
+    reward_amount    DECIMAL NOT NULL,
+    reward_token     TEXT    NOT NULL,
+    eth_block_height BIGINT  NOT NULL,
+    height           BIGINT  NOT NULL,
+    transaction_hash TEXT    NOT NULL REFERENCES transaction (hash),
+    PRIMARY KEY (valset_nonce, attestation_id)
+);
+
+CREATE INDEX gravity_valset_update_attestation_id_index ON gravity_valset_update (attestation_id);
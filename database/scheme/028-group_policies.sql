@@ -0,0 +1,34 @@
+/* Groups created without a policy have an empty address until their first policy is created */
+ALTER TABLE group_with_policy
+    ADD COLUMN admin TEXT NOT NULL DEFAULT '';
+
+/* A group can have several policies, the one mirrored inside group_with_policy being the first one created */
+CREATE TABLE group_policy
+(
+    address              TEXT   NOT NULL PRIMARY KEY,
+    group_id             INT    NOT NULL REFERENCES group_with_policy (id),
+    admin                TEXT   NOT NULL,
+    metadata             TEXT   NULL,
+    threshold            INT    NOT NULL,
+    voting_period        BIGINT NOT NULL,
+    min_execution_period BIGINT NOT NULL
+);
+
+CREATE INDEX group_policy_group_id_index ON group_policy (group_id);
+
+INSERT INTO group_policy (address, group_id, admin, metadata, threshold, voting_period, min_execution_period)
+SELECT address, id, '', policy_metadata, threshold, voting_period, min_execution_period
+FROM group_with_policy
+WHERE address != ''
+ON CONFLICT DO NOTHING;
+
+ALTER TABLE group_proposal
+    ADD COLUMN policy_address TEXT NULL REFERENCES group_policy (address);
+
+UPDATE group_proposal p
+SET policy_address = g.address
+FROM group_with_policy g
+WHERE g.id = p.group_id
+  AND g.address != '';
+
+CREATE INDEX group_proposal_policy_address_index ON group_proposal (policy_address);
@@ -0,0 +1,6 @@
+/* Percentage decision policies have no threshold, their minimum share of YES votes is stored instead */
+ALTER TABLE group_with_policy
+    ADD COLUMN percentage NUMERIC NULL;
+
+ALTER TABLE group_policy
+    ADD COLUMN percentage NUMERIC NULL;
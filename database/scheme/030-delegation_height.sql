@@ -0,0 +1,14 @@
+/* Delegations are now updated one by one, so each pair can only be stored once */
+DELETE FROM delegation a
+    USING delegation b
+WHERE a.ctid < b.ctid
+  AND a.validator_address = b.validator_address
+  AND a.delegator_address = b.delegator_address;
+
+ALTER TABLE delegation
+    ADD COLUMN height BIGINT NOT NULL DEFAULT 0;
+
+ALTER TABLE delegation
+    ADD CONSTRAINT delegation_pkey PRIMARY KEY (validator_address, delegator_address);
+
+CREATE INDEX delegation_height_index ON delegation (height);
@@ -0,0 +1,29 @@
+CREATE TABLE unbonding_delegation
+(
+    validator_address    TEXT                        NOT NULL REFERENCES validator_info (operator_address),
+    delegator_address    TEXT                        NOT NULL REFERENCES account (address),
+    amount               COIN                        NOT NULL,
+    completion_timestamp TIMESTAMP WITHOUT TIME ZONE NOT NULL,
+    height               BIGINT                      NOT NULL,
+    CONSTRAINT unbonding_delegation_validator_delegator_unique
+        UNIQUE (delegator_address, validator_address, amount, completion_timestamp)
+);
+CREATE INDEX unbonding_delegation_validator_address_index ON unbonding_delegation (validator_address);
+CREATE INDEX unbonding_delegation_delegator_address_index ON unbonding_delegation (delegator_address);
+CREATE INDEX unbonding_delegation_completion_timestamp_index ON unbonding_delegation (completion_timestamp);
+
+CREATE TABLE redelegation
+(
+    delegator_address     TEXT                        NOT NULL REFERENCES account (address),
+    src_validator_address TEXT                        NOT NULL REFERENCES validator_info (operator_address),
+    dst_validator_address TEXT                        NOT NULL REFERENCES validator_info (operator_address),
+    amount                COIN                        NOT NULL,
+    completion_time       TIMESTAMP WITHOUT TIME ZONE NOT NULL,
+    height                BIGINT                      NOT NULL,
+    CONSTRAINT redelegation_validator_delegator_unique
+        UNIQUE (delegator_address, src_validator_address, dst_validator_address, amount, completion_time)
+);
+CREATE INDEX redelegation_delegator_address_index ON redelegation (delegator_address);
+CREATE INDEX redelegation_src_validator_address_index ON redelegation (src_validator_address);
+CREATE INDEX redelegation_dst_validator_address_index ON redelegation (dst_validator_address);
+CREATE INDEX redelegation_completion_time_index ON redelegation (completion_time);
@@ -0,0 +1,14 @@
+CREATE TABLE validator_rating
+(
+    validator_address     TEXT             NOT NULL REFERENCES validator (consensus_address) PRIMARY KEY,
+    self_delegation_ratio DOUBLE PRECISION NOT NULL,
+    uptime                DOUBLE PRECISION NOT NULL,
+    slashed               BOOLEAN          NOT NULL,
+    gov_participation     DOUBLE PRECISION NOT NULL,
+    delegators_count      BIGINT           NOT NULL,
+    voting_power          BIGINT           NOT NULL,
+    score                 DOUBLE PRECISION NOT NULL,
+    height                BIGINT           NOT NULL
+);
+CREATE INDEX validator_rating_score_index ON validator_rating (score);
+CREATE INDEX validator_rating_height_index ON validator_rating (height);
@@ -0,0 +1,12 @@
+CREATE TABLE alert
+(
+    id        SERIAL    NOT NULL PRIMARY KEY,
+    type      TEXT      NOT NULL,
+    message   TEXT      NOT NULL,
+    data      JSONB     NOT NULL DEFAULT '{}'::JSONB,
+    height    BIGINT    NOT NULL,
+    timestamp TIMESTAMP NOT NULL
+);
+CREATE INDEX alert_type_index ON alert (type);
+CREATE INDEX alert_height_index ON alert (height);
+CREATE INDEX alert_timestamp_index ON alert (timestamp);
@@ -0,0 +1,3 @@
+/* Balances are written both by the parser and by the reconciliation worker, so keep the height they refer to */
+ALTER TABLE cw20token_balance
+    ADD COLUMN height BIGINT NOT NULL DEFAULT 0;
@@ -0,0 +1,4 @@
+/* Sale prices that cannot be read from the price history nor fetched from CryptoCompare are stored as NULL */
+ALTER TABLE marketplace_nft_buy_history
+    ALTER COLUMN usd_price DROP NOT NULL,
+    ALTER COLUMN btc_price DROP NOT NULL;
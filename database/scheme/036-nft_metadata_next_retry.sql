@@ -0,0 +1,3 @@
+/* Failed metadata fetches are retried with an increasing delay, keep when the next attempt is due */
+ALTER TABLE nft_nft_metadata
+    ADD COLUMN next_retry BIGINT NOT NULL DEFAULT 0;
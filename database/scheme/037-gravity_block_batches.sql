@@ -0,0 +1,3 @@
+/* Batches are also created and cancelled by the chain itself at the beginning and at the end of blocks */
+ALTER TABLE gravity_batch
+    ALTER COLUMN transaction_hash DROP NOT NULL;
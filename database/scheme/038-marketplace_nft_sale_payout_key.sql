@@ -0,0 +1,12 @@
+/* Payouts are stored again when a height is re-parsed, keep a single row for each of them */
+DELETE FROM marketplace_nft_sale_payout p
+    USING marketplace_nft_sale_payout d
+WHERE p.ctid > d.ctid
+  AND p.transaction_hash = d.transaction_hash
+  AND p.token_id = d.token_id
+  AND p.denom_id = d.denom_id
+  AND p.address = d.address
+  AND p.type = d.type;
+
+ALTER TABLE marketplace_nft_sale_payout
+    ADD PRIMARY KEY (transaction_hash, token_id, denom_id, address, type);
@@ -0,0 +1,19 @@
+/* One row for each registration of an orchestrator, gravity_orchestrator keeping the latest one */
+CREATE TABLE gravity_orchestrator_registration
+(
+    address           TEXT   NOT NULL REFERENCES gravity_orchestrator (address),
+    validator_address TEXT   NOT NULL,
+    eth_address       TEXT   NOT NULL,
+    height            BIGINT NOT NULL,
+    transaction_hash  TEXT REFERENCES transaction (hash),
+    PRIMARY KEY (address, height)
+);
+
+CREATE INDEX gravity_orchestrator_registration_validator_address_index ON gravity_orchestrator_registration (validator_address);
+
+/* The latest registration is the only one known for the orchestrators stored before registrations were tracked */
+INSERT INTO gravity_orchestrator_registration (address, validator_address, eth_address, height, transaction_hash)
+SELECT address, validator_address, eth_address, height, transaction_hash
+FROM gravity_orchestrator
+WHERE validator_address != ''
+ON CONFLICT DO NOTHING;
@@ -0,0 +1,7 @@
+/* Accounts whose balance history has to be stored at the given height, filled while handling the messages */
+CREATE TABLE account_balance_history_queue
+(
+    address TEXT   NOT NULL,
+    height  BIGINT NOT NULL,
+    PRIMARY KEY (height, address)
+);
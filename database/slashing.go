@@ -4,6 +4,9 @@ import (
 	"encoding/json"
 	"fmt"
 
+	slashingtypes "github.com/cosmos/cosmos-sdk/x/slashing/types"
+
+	dbtypes "github.com/forbole/bdjuno/v2/database/types"
 	"github.com/forbole/bdjuno/v2/types"
 )
 
@@ -71,3 +74,48 @@ WHERE slashing_params.height <= excluded.height`
 
 	return nil
 }
+
+// GetValidatorsSigningInfos returns the signing infos of all the validators stored inside the database
+func (db *Db) GetValidatorsSigningInfos() ([]types.ValidatorSigningInfo, error) {
+	var rows []dbtypes.ValidatorSigningInfoRow
+	err := db.Sqlx.Select(&rows, `SELECT * FROM validator_signing_info`)
+	if err != nil {
+		return nil, err
+	}
+
+	infos := make([]types.ValidatorSigningInfo, len(rows))
+	for i, row := range rows {
+		infos[i] = types.NewValidatorSigningInfo(
+			row.ValidatorAddress,
+			row.StartHeight,
+			row.IndexOffset,
+			row.JailedUntil,
+			row.Tombstoned,
+			row.MissedBlocksCounter,
+			row.Height,
+		)
+	}
+
+	return infos, nil
+}
+
+// GetSlashingParams returns the slashing params stored inside the database, or nil if they are not found
+func (db *Db) GetSlashingParams() (*types.SlashingParams, error) {
+	var rows []dbtypes.SlashingParamsRow
+	err := db.Sqlx.Select(&rows, `SELECT * FROM slashing_params`)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(rows) == 0 {
+		return nil, nil
+	}
+
+	var params slashingtypes.Params
+	err = json.Unmarshal([]byte(rows[0].Params), &params)
+	if err != nil {
+		return nil, fmt.Errorf("error while unmarshaling slashing params: %s", err)
+	}
+
+	return types.NewSlashingParams(params, rows[0].Height), nil
+}
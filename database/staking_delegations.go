@@ -0,0 +1,229 @@
+package database
+
+import (
+	"fmt"
+	"time"
+
+	dbtypes "github.com/forbole/bdjuno/v2/database/types"
+	"github.com/forbole/bdjuno/v2/types"
+)
+
+// ReplaceDelegatorDelegations stores the given delegations as the only ones of the given delegator at the given height,
+// removing the ones that have been fully undelegated or redelegated since
+func (db *Db) ReplaceDelegatorDelegations(delegator string, delegations []types.Delegation, height int64) error {
+	return db.ExecuteTx(func(dbTx *DbTx) error {
+		_, err := dbTx.Exec(`DELETE FROM delegation WHERE delegator_address = $1 AND height <= $2`, delegator, height)
+		if err != nil {
+			return fmt.Errorf("error while deleting delegator delegations: %s", err)
+		}
+
+		return dbTx.saveDelegations(delegations)
+	})
+}
+
+// ReplaceValidatorDelegations stores the given delegations as the only ones of the given validator at the given height,
+// removing the ones that have been fully undelegated or redelegated since
+func (db *Db) ReplaceValidatorDelegations(validator string, delegations []types.Delegation, height int64) error {
+	return db.ExecuteTx(func(dbTx *DbTx) error {
+		_, err := dbTx.Exec(`DELETE FROM delegation WHERE validator_address = $1 AND height <= $2`, validator, height)
+		if err != nil {
+			return fmt.Errorf("error while deleting validator delegations: %s", err)
+		}
+
+		return dbTx.saveDelegations(delegations)
+	})
+}
+
+// saveDelegations stores the given delegations, without overriding the ones stored at a greater height
+func (dbTx *DbTx) saveDelegations(delegations []types.Delegation) error {
+	if len(delegations) == 0 {
+		return nil
+	}
+
+	stmt := `INSERT INTO delegation (validator_address, delegator_address, amount, height) VALUES `
+	var params []interface{}
+
+	for i, delegation := range delegations {
+		vi := i * 4
+		stmt += fmt.Sprintf("($%d, $%d, $%d, $%d),", vi+1, vi+2, vi+3, vi+4)
+
+		coin := dbtypes.NewDbCoin(delegation.Amount)
+		amount, err := coin.Value()
+		if err != nil {
+			return fmt.Errorf("error while converting delegation amount: %s", err)
+		}
+
+		params = append(params, delegation.ValidatorAddress, delegation.DelegatorAddress, amount, delegation.Height)
+	}
+
+	stmt = stmt[:len(stmt)-1] // Remove trailing ","
+	stmt += `
+ON CONFLICT (validator_address, delegator_address) DO UPDATE 
+	SET amount = excluded.amount,
+	    height = excluded.height
+WHERE delegation.height <= excluded.height`
+
+	_, err := dbTx.Exec(stmt, params...)
+	if err != nil {
+		return fmt.Errorf("error while storing delegations: %s", err)
+	}
+
+	return nil
+}
+
+// -------------------------------------------------------------------------------------------------------------------
+
+// SaveUnbondingDelegation stores the given unbonding delegation entry
+func (db *Db) SaveUnbondingDelegation(delegation types.UnbondingDelegation) error {
+	stmt := `
+INSERT INTO unbonding_delegation (validator_address, delegator_address, amount, completion_timestamp, height) 
+VALUES ($1, $2, $3, $4, $5)
+ON CONFLICT ON CONSTRAINT unbonding_delegation_validator_delegator_unique DO UPDATE 
+	SET height = excluded.height
+WHERE unbonding_delegation.height <= excluded.height`
+
+	coin := dbtypes.NewDbCoin(delegation.Amount)
+	amount, err := coin.Value()
+	if err != nil {
+		return fmt.Errorf("error while converting unbonding delegation amount: %s", err)
+	}
+
+	_, err = db.Sql.Exec(stmt,
+		delegation.ValidatorAddress, delegation.DelegatorAddress, amount, delegation.CompletionTimestamp, delegation.Height)
+	if err != nil {
+		return fmt.Errorf("error while storing unbonding delegation: %s", err)
+	}
+
+	return nil
+}
+
+// DeleteCompletedUnbondingDelegations removes all the unbonding delegations of the given delegator from the given
+// validator that have been completed before or at the given timestamp
+func (db *Db) DeleteCompletedUnbondingDelegations(delegator string, validator string, timestamp time.Time) error {
+	stmt := `
+DELETE FROM unbonding_delegation 
+WHERE delegator_address = $1 AND validator_address = $2 AND completion_timestamp <= $3`
+	_, err := db.Sql.Exec(stmt, delegator, validator, timestamp)
+	if err != nil {
+		return fmt.Errorf("error while deleting completed unbonding delegations: %s", err)
+	}
+
+	return nil
+}
+
+// GetDelegatorUnbondingDelegations returns all the unbonding delegations of the given delegator
+// that are stored inside the database
+func (db *Db) GetDelegatorUnbondingDelegations(delegator string) ([]types.UnbondingDelegation, error) {
+	return db.getUnbondingDelegations(`
+SELECT * FROM unbonding_delegation WHERE delegator_address = $1 
+ORDER BY validator_address, completion_timestamp`, delegator)
+}
+
+// GetValidatorUnbondingDelegations returns all the unbonding delegations from the given validator
+// that are stored inside the database
+func (db *Db) GetValidatorUnbondingDelegations(validator string) ([]types.UnbondingDelegation, error) {
+	return db.getUnbondingDelegations(`
+SELECT * FROM unbonding_delegation WHERE validator_address = $1 
+ORDER BY delegator_address, completion_timestamp`, validator)
+}
+
+func (db *Db) getUnbondingDelegations(query string, args ...interface{}) ([]types.UnbondingDelegation, error) {
+	var rows []dbtypes.UnbondingDelegationRow
+	err := db.Sqlx.Select(&rows, query, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	delegations := make([]types.UnbondingDelegation, len(rows))
+	for i, row := range rows {
+		delegations[i] = types.NewUnbondingDelegation(
+			row.DelegatorAddress,
+			row.ValidatorAddress,
+			row.Amount.ToCoin(),
+			row.CompletionTimestamp.UTC(),
+			row.Height,
+		)
+	}
+
+	return delegations, nil
+}
+
+// -------------------------------------------------------------------------------------------------------------------
+
+// SaveRedelegation stores the given redelegation entry
+func (db *Db) SaveRedelegation(redelegation types.Redelegation) error {
+	stmt := `
+INSERT INTO redelegation (delegator_address, src_validator_address, dst_validator_address, amount, completion_time, height) 
+VALUES ($1, $2, $3, $4, $5, $6)
+ON CONFLICT ON CONSTRAINT redelegation_validator_delegator_unique DO UPDATE 
+	SET height = excluded.height
+WHERE redelegation.height <= excluded.height`
+
+	coin := dbtypes.NewDbCoin(redelegation.Amount)
+	amount, err := coin.Value()
+	if err != nil {
+		return fmt.Errorf("error while converting redelegation amount: %s", err)
+	}
+
+	_, err = db.Sql.Exec(stmt,
+		redelegation.DelegatorAddress, redelegation.SrcValidator, redelegation.DstValidator,
+		amount, redelegation.CompletionTime, redelegation.Height)
+	if err != nil {
+		return fmt.Errorf("error while storing redelegation: %s", err)
+	}
+
+	return nil
+}
+
+// DeleteCompletedRedelegations removes all the redelegations of the given delegator between the given validators
+// that have been completed before or at the given time
+func (db *Db) DeleteCompletedRedelegations(
+	delegator string, srcValidator string, dstValidator string, timestamp time.Time,
+) error {
+	stmt := `
+DELETE FROM redelegation 
+WHERE delegator_address = $1 AND src_validator_address = $2 AND dst_validator_address = $3 AND completion_time <= $4`
+	_, err := db.Sql.Exec(stmt, delegator, srcValidator, dstValidator, timestamp)
+	if err != nil {
+		return fmt.Errorf("error while deleting completed redelegations: %s", err)
+	}
+
+	return nil
+}
+
+// GetDelegatorRedelegations returns all the redelegations of the given delegator that are stored inside the database
+func (db *Db) GetDelegatorRedelegations(delegator string) ([]types.Redelegation, error) {
+	return db.getRedelegations(`
+SELECT * FROM redelegation WHERE delegator_address = $1 
+ORDER BY src_validator_address, dst_validator_address, completion_time`, delegator)
+}
+
+// GetValidatorRedelegationsFrom returns all the redelegations having the given validator as their source
+// that are stored inside the database
+func (db *Db) GetValidatorRedelegationsFrom(srcValidator string) ([]types.Redelegation, error) {
+	return db.getRedelegations(`
+SELECT * FROM redelegation WHERE src_validator_address = $1 
+ORDER BY delegator_address, dst_validator_address, completion_time`, srcValidator)
+}
+
+func (db *Db) getRedelegations(query string, args ...interface{}) ([]types.Redelegation, error) {
+	var rows []dbtypes.RedelegationRow
+	err := db.Sqlx.Select(&rows, query, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	redelegations := make([]types.Redelegation, len(rows))
+	for i, row := range rows {
+		redelegations[i] = types.NewRedelegation(
+			row.DelegatorAddress,
+			row.SrcValidatorAddress,
+			row.DstValidatorAddress,
+			row.Amount.ToCoin(),
+			row.CompletionTime.UTC(),
+			row.Height,
+		)
+	}
+
+	return redelegations, nil
+}
@@ -0,0 +1,167 @@
+package database_test
+
+import (
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	junotypes "github.com/forbole/juno/v2/types"
+
+	dbtypes "github.com/forbole/bdjuno/v2/database/types"
+	"github.com/forbole/bdjuno/v2/types"
+)
+
+type delegationRow struct {
+	ValidatorAddress string `db:"validator_address"`
+	DelegatorAddress string `db:"delegator_address"`
+	Amount           string `db:"amount"`
+	Height           int64  `db:"height"`
+}
+
+// saveDelegationsValidators stores the given validators along with the accounts of the given delegators
+func (suite *DbTestSuite) saveDelegationsValidators(delegators []string, validators []string) {
+	accounts := make([]types.Account, 0, len(delegators)+len(validators))
+	for _, address := range append(delegators, validators...) {
+		accounts = append(accounts, types.NewAccount(address))
+	}
+	suite.Require().NoError(suite.database.SaveAccounts(accounts))
+
+	vals := make([]*junotypes.Validator, len(validators))
+	for i := range validators {
+		vals[i] = junotypes.NewValidator(validators[i], validators[i])
+	}
+	suite.Require().NoError(suite.database.SaveValidators(vals))
+
+	for i := range validators {
+		_, err := suite.database.Sqlx.Exec(`INSERT INTO validator_info (consensus_address, operator_address, self_delegate_address, max_rate, max_change_rate, height) 
+			VALUES ($1, $2, $3, '1', '2', 1)`, validators[i], validators[i], validators[i])
+		suite.Require().NoError(err)
+	}
+}
+
+func (suite *DbTestSuite) newDelegationRow(delegator, validator string, amount int64, height int64) delegationRow {
+	dbcoin := dbtypes.NewDbCoin(sdk.NewCoin("acudos", sdk.NewInt(amount)))
+	value, err := dbcoin.Value()
+	suite.Require().NoError(err)
+
+	return delegationRow{
+		ValidatorAddress: validator,
+		DelegatorAddress: delegator,
+		Amount:           value.(string),
+		Height:           height,
+	}
+}
+
+func (suite *DbTestSuite) TestReplaceValidatorDelegations() {
+	delegators := []string{
+		"cudos1a326k254fukx9jlp0h3fwcr2ymjgludzum2ba1",
+		"cudos1a326k254fukx9jlp0h3fwcr2ymjgludzum2ba2",
+		"cudos1a326k254fukx9jlp0h3fwcr2ymjgludzum2ba3",
+	}
+	validator := "cudos1a326k254fukx9jlp0h3fwcr2ymjgludzum67d1"
+	suite.saveDelegationsValidators(delegators, []string{validator})
+
+	delegations := make([]types.Delegation, len(delegators))
+	for i := range delegators {
+		delegations[i] = types.NewDelegation(delegators[i], validator, sdk.NewCoin("acudos", sdk.NewInt(100+int64(i))), 10)
+	}
+	suite.Require().NoError(suite.database.ReplaceValidatorDelegations(validator, delegations, 10))
+
+	// The first delegator undelegates everything, while the second one is slashed
+	suite.Require().NoError(suite.database.ReplaceValidatorDelegations(validator, []types.Delegation{
+		types.NewDelegation(delegators[1], validator, sdk.NewCoin("acudos", sdk.NewInt(50)), 20),
+		types.NewDelegation(delegators[2], validator, sdk.NewCoin("acudos", sdk.NewInt(102)), 20),
+	}, 20))
+
+	var rows []delegationRow
+	suite.Require().NoError(suite.database.Sqlx.Select(&rows, `SELECT * FROM delegation ORDER BY delegator_address`))
+	suite.Require().Equal([]delegationRow{
+		suite.newDelegationRow(delegators[1], validator, 50, 20),
+		suite.newDelegationRow(delegators[2], validator, 102, 20),
+	}, rows)
+}
+
+func (suite *DbTestSuite) TestReplaceDelegatorDelegations() {
+	delegator := "cudos1a326k254fukx9jlp0h3fwcr2ymjgludzum2ba1"
+	validators := []string{
+		"cudos1a326k254fukx9jlp0h3fwcr2ymjgludzum67d1",
+		"cudos1a326k254fukx9jlp0h3fwcr2ymjgludzum67d2",
+	}
+	suite.saveDelegationsValidators([]string{delegator}, validators)
+
+	suite.Require().NoError(suite.database.ReplaceDelegatorDelegations(delegator, []types.Delegation{
+		types.NewDelegation(delegator, validators[0], sdk.NewCoin("acudos", sdk.NewInt(100)), 20),
+	}, 20))
+
+	// Delegations of an older height must not override newer data
+	suite.Require().NoError(suite.database.ReplaceDelegatorDelegations(delegator, []types.Delegation{
+		types.NewDelegation(delegator, validators[0], sdk.NewCoin("acudos", sdk.NewInt(40)), 10),
+	}, 10))
+
+	// Redelegating moves the delegation to the other validator
+	suite.Require().NoError(suite.database.ReplaceDelegatorDelegations(delegator, []types.Delegation{
+		types.NewDelegation(delegator, validators[1], sdk.NewCoin("acudos", sdk.NewInt(100)), 30),
+	}, 30))
+
+	var rows []delegationRow
+	suite.Require().NoError(suite.database.Sqlx.Select(&rows, `SELECT * FROM delegation ORDER BY validator_address`))
+	suite.Require().Equal([]delegationRow{
+		suite.newDelegationRow(delegator, validators[1], 100, 30),
+	}, rows)
+}
+
+func (suite *DbTestSuite) TestUnbondingDelegations() {
+	delegator := "cudos1a326k254fukx9jlp0h3fwcr2ymjgludzum2ba1"
+	validator := "cudos1a326k254fukx9jlp0h3fwcr2ymjgludzum67d1"
+	suite.saveDelegationsValidators([]string{delegator}, []string{validator})
+
+	first := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+	second := first.Add(time.Hour)
+	suite.Require().NoError(suite.database.SaveUnbondingDelegation(
+		types.NewUnbondingDelegation(delegator, validator, sdk.NewCoin("acudos", sdk.NewInt(100)), first, 10)))
+	suite.Require().NoError(suite.database.SaveUnbondingDelegation(
+		types.NewUnbondingDelegation(delegator, validator, sdk.NewCoin("acudos", sdk.NewInt(200)), second, 11)))
+
+	// Storing the same entry twice should not duplicate it
+	suite.Require().NoError(suite.database.SaveUnbondingDelegation(
+		types.NewUnbondingDelegation(delegator, validator, sdk.NewCoin("acudos", sdk.NewInt(200)), second, 11)))
+
+	delegations, err := suite.database.GetDelegatorUnbondingDelegations(delegator)
+	suite.Require().NoError(err)
+	suite.Require().Len(delegations, 2)
+
+	// Only the first entry is completed
+	suite.Require().NoError(suite.database.DeleteCompletedUnbondingDelegations(delegator, validator, first))
+
+	delegations, err = suite.database.GetValidatorUnbondingDelegations(validator)
+	suite.Require().NoError(err)
+	suite.Require().Equal([]types.UnbondingDelegation{
+		types.NewUnbondingDelegation(delegator, validator, sdk.NewCoin("acudos", sdk.NewInt(200)), second, 11),
+	}, delegations)
+}
+
+func (suite *DbTestSuite) TestRedelegations() {
+	delegator := "cudos1a326k254fukx9jlp0h3fwcr2ymjgludzum2ba1"
+	srcValidator := "cudos1a326k254fukx9jlp0h3fwcr2ymjgludzum67d1"
+	dstValidator := "cudos1a326k254fukx9jlp0h3fwcr2ymjgludzum67d2"
+	suite.saveDelegationsValidators([]string{delegator}, []string{srcValidator, dstValidator})
+
+	first := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+	second := first.Add(time.Hour)
+	suite.Require().NoError(suite.database.SaveRedelegation(
+		types.NewRedelegation(delegator, srcValidator, dstValidator, sdk.NewCoin("acudos", sdk.NewInt(100)), first, 10)))
+	suite.Require().NoError(suite.database.SaveRedelegation(
+		types.NewRedelegation(delegator, srcValidator, dstValidator, sdk.NewCoin("acudos", sdk.NewInt(200)), second, 11)))
+
+	redelegations, err := suite.database.GetDelegatorRedelegations(delegator)
+	suite.Require().NoError(err)
+	suite.Require().Len(redelegations, 2)
+
+	// Only the first entry is completed
+	suite.Require().NoError(suite.database.DeleteCompletedRedelegations(delegator, srcValidator, dstValidator, first))
+
+	redelegations, err = suite.database.GetValidatorRedelegationsFrom(srcValidator)
+	suite.Require().NoError(err)
+	suite.Require().Equal([]types.Redelegation{
+		types.NewRedelegation(delegator, srcValidator, dstValidator, sdk.NewCoin("acudos", sdk.NewInt(200)), second, 11),
+	}, redelegations)
+}
@@ -0,0 +1,13 @@
+package types
+
+import "time"
+
+// AlertRow represents a single row of the alert table
+type AlertRow struct {
+	ID        int64     `db:"id"`
+	Type      string    `db:"type"`
+	Message   string    `db:"message"`
+	Data      string    `db:"data"`
+	Height    int64     `db:"height"`
+	Timestamp time.Time `db:"timestamp"`
+}
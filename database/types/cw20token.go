@@ -17,3 +17,15 @@ type TokenInfoRow struct {
 	Type           string `db:"type"`
 	Creator        string `db:"creator"`
 }
+
+type TokenTransferRow struct {
+	Token      string `db:"token"`
+	Type       string `db:"type"`
+	From       string `db:"from_address"`
+	To         string `db:"to_address"`
+	Amount     string `db:"amount"`
+	Height     int64  `db:"height"`
+	TxHash     string `db:"transaction_hash"`
+	MsgIndex   int    `db:"msg_index"`
+	EventIndex int    `db:"event_index"`
+}
@@ -0,0 +1,46 @@
+package types
+
+type CW721CollectionRow struct {
+	Address   string `db:"address"`
+	CodeID    uint64 `db:"code_id"`
+	Name      string `db:"name"`
+	Symbol    string `db:"symbol"`
+	Minter    string `db:"minter"`
+	NumTokens uint64 `db:"num_tokens"`
+	Creator   string `db:"creator"`
+}
+
+type CW721NftRow struct {
+	Collection string `db:"collection"`
+	TokenID    string `db:"token_id"`
+	Owner      string `db:"owner"`
+	TokenURI   string `db:"token_uri"`
+	Extension  string `db:"extension"`
+	Burned     bool   `db:"burned"`
+}
+
+type CW721ApprovalRow struct {
+	Collection string `db:"collection"`
+	TokenID    string `db:"token_id"`
+	Spender    string `db:"spender"`
+	Expires    string `db:"expires"`
+}
+
+type CW721OperatorRow struct {
+	Collection string `db:"collection"`
+	Owner      string `db:"owner"`
+	Operator   string `db:"operator"`
+	Expires    string `db:"expires"`
+}
+
+type CW721HistoryRow struct {
+	Collection      string `db:"collection"`
+	TokenID         string `db:"token_id"`
+	Type            string `db:"type"`
+	Sender          string `db:"sender"`
+	FromAddress     string `db:"from_address"`
+	ToAddress       string `db:"to_address"`
+	Height          int64  `db:"height"`
+	TransactionHash string `db:"transaction_hash"`
+	MsgIndex        int    `db:"msg_index"`
+}
@@ -1,7 +1,13 @@
 package types
 
+import "database/sql"
+
 type GravityOrchestratorRow struct {
-	Address string `db:"address"`
+	Address          string         `db:"address"`
+	ValidatorAddress string         `db:"validator_address"`
+	EthAddress       string         `db:"eth_address"`
+	Height           int64          `db:"height"`
+	TransactionHash  sql.NullString `db:"transaction_hash"`
 }
 
 type GravityTransactionRow struct {
@@ -13,4 +19,30 @@ type GravityTransactionRow struct {
 	Consensus       bool   `db:"consensus"`
 	Height          int64  `db:"height"`
 	TransactionHash string `db:"transaction_hash"`
+	Power           int64  `db:"power"`
+}
+
+type GravityBatchRow struct {
+	Nonce           uint64         `db:"nonce"`
+	Denom           string         `db:"denom"`
+	TokenContract   string         `db:"token_contract"`
+	Requester       string         `db:"requester"`
+	Status          string         `db:"status"`
+	Height          int64          `db:"height"`
+	TransactionHash sql.NullString `db:"transaction_hash"`
+	ExecutedHeight  sql.NullInt64  `db:"executed_height"`
+}
+
+type GravityOutgoingTransferRow struct {
+	ID                    uint64         `db:"id"`
+	Sender                string         `db:"sender"`
+	EthDest               string         `db:"eth_dest"`
+	Denom                 string         `db:"denom"`
+	Amount                string         `db:"amount"`
+	BridgeFee             string         `db:"bridge_fee"`
+	Status                string         `db:"status"`
+	BatchNonce            sql.NullInt64  `db:"batch_nonce"`
+	Height                int64          `db:"height"`
+	TransactionHash       string         `db:"transaction_hash"`
+	CancelTransactionHash sql.NullString `db:"cancel_transaction_hash"`
 }
@@ -6,13 +6,26 @@ import (
 )
 
 type GroupRow struct {
-	ID                 uint64 `db:"id"`
-	Address            string `db:"address"`
-	GroupMetadata      string `db:"group_metadata"`
-	PolicyMetadata     string `db:"policy_metadata"`
-	Threshold          uint64 `db:"threshold"`
-	VotingPeriod       uint64 `db:"voting_period"`
-	MinExecutionPeriod uint64 `db:"min_execution_period"`
+	ID                 uint64         `db:"id"`
+	Address            string         `db:"address"`
+	GroupMetadata      string         `db:"group_metadata"`
+	PolicyMetadata     string         `db:"policy_metadata"`
+	Threshold          uint64         `db:"threshold"`
+	VotingPeriod       uint64         `db:"voting_period"`
+	MinExecutionPeriod uint64         `db:"min_execution_period"`
+	Admin              string         `db:"admin"`
+	Percentage         sql.NullString `db:"percentage"`
+}
+
+type GroupPolicyRow struct {
+	Address            string         `db:"address"`
+	GroupID            uint64         `db:"group_id"`
+	Admin              string         `db:"admin"`
+	Metadata           sql.NullString `db:"metadata"`
+	Threshold          uint64         `db:"threshold"`
+	VotingPeriod       uint64         `db:"voting_period"`
+	MinExecutionPeriod uint64         `db:"min_execution_period"`
+	Percentage         sql.NullString `db:"percentage"`
 }
 
 type GroupProposalRow struct {
@@ -30,6 +43,7 @@ type GroupProposalRow struct {
 	BlockHeight      int64          `db:"height"`
 	SubmitTime       time.Time      `db:"submit_time"`
 	MemberCount      int            `db:"member_count"`
+	PolicyAddress    sql.NullString `db:"policy_address"`
 }
 
 type GroupMemberRow struct {
@@ -0,0 +1,15 @@
+package types
+
+import "time"
+
+// AccountBalanceHistoryRow represents a single row of the account_balance_history table
+type AccountBalanceHistoryRow struct {
+	Address      string     `db:"address"`
+	Balance      DbCoins    `db:"balance"`
+	Delegated    DbCoins    `db:"delegated"`
+	Unbonding    DbCoins    `db:"unbonding"`
+	Redelegating DbCoins    `db:"redelegating"`
+	Commission   DbDecCoins `db:"commission"`
+	Reward       DbDecCoins `db:"reward"`
+	Timestamp    time.Time  `db:"timestamp"`
+}
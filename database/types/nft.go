@@ -0,0 +1,13 @@
+package types
+
+type NftURIRow struct {
+	TokenID uint64 `db:"id"`
+	DenomID string `db:"denom_id"`
+	URI     string `db:"uri"`
+	Retries int    `db:"retries"`
+}
+
+type NftAttributesRow struct {
+	TokenID    uint64 `db:"id"`
+	Attributes string `db:"attributes"`
+}
@@ -0,0 +1,25 @@
+package types
+
+// ValidatorRatingDataRow represents the data about a single validator that is used to compute its rating
+type ValidatorRatingDataRow struct {
+	ValidatorAddress    string  `db:"validator_address"`
+	SelfDelegationRatio float64 `db:"self_delegation_ratio"`
+	Uptime              float64 `db:"uptime"`
+	Slashed             bool    `db:"slashed"`
+	GovParticipation    float64 `db:"gov_participation"`
+	DelegatorsCount     int64   `db:"delegators_count"`
+	VotingPower         int64   `db:"voting_power"`
+}
+
+// ValidatorRatingRow represents a single row of the validator_rating table
+type ValidatorRatingRow struct {
+	ValidatorAddress    string  `db:"validator_address"`
+	SelfDelegationRatio float64 `db:"self_delegation_ratio"`
+	Uptime              float64 `db:"uptime"`
+	Slashed             bool    `db:"slashed"`
+	GovParticipation    float64 `db:"gov_participation"`
+	DelegatorsCount     int64   `db:"delegators_count"`
+	VotingPower         int64   `db:"voting_power"`
+	Score               float64 `db:"score"`
+	Height              int64   `db:"height"`
+}
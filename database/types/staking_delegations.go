@@ -0,0 +1,22 @@
+package types
+
+import "time"
+
+// UnbondingDelegationRow represents a single row of the unbonding_delegation table
+type UnbondingDelegationRow struct {
+	ValidatorAddress    string    `db:"validator_address"`
+	DelegatorAddress    string    `db:"delegator_address"`
+	Amount              DbCoin    `db:"amount"`
+	CompletionTimestamp time.Time `db:"completion_timestamp"`
+	Height              int64     `db:"height"`
+}
+
+// RedelegationRow represents a single row of the redelegation table
+type RedelegationRow struct {
+	DelegatorAddress    string    `db:"delegator_address"`
+	SrcValidatorAddress string    `db:"src_validator_address"`
+	DstValidatorAddress string    `db:"dst_validator_address"`
+	Amount              DbCoin    `db:"amount"`
+	CompletionTime      time.Time `db:"completion_time"`
+	Height              int64     `db:"height"`
+}
@@ -0,0 +1,57 @@
+package alert
+
+import (
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Config contains the configuration about the alert module.
+// Alerts raised for blocks older than MaxAge are not sent to the sinks, so that they are not
+// repeated while re-parsing old blocks. A zero MaxAge sends the alerts of all the blocks
+type Config struct {
+	Rules  []RuleConfig  `yaml:"rules"`
+	Sinks  SinksConfig   `yaml:"sinks"`
+	MaxAge time.Duration `yaml:"max_age,omitempty"`
+}
+
+// NewConfig returns a new Config instance
+func NewConfig(rules []RuleConfig, sinks SinksConfig, maxAge time.Duration) *Config {
+	return &Config{
+		Rules:  rules,
+		Sinks:  sinks,
+		MaxAge: maxAge,
+	}
+}
+
+// RuleConfig contains the configuration of a single alert rule.
+// The threshold represents the minimum amount for huge delegations and undelegations,
+// and the minimum uptime (from 0 to 1) for the validators low uptime
+type RuleConfig struct {
+	Type      string `yaml:"type"`
+	Threshold string `yaml:"threshold,omitempty"`
+	Denom     string `yaml:"denom,omitempty"`
+}
+
+// SinksConfig contains the configuration of the destinations of the alerts
+type SinksConfig struct {
+	Database bool            `yaml:"database"`
+	Webhooks []WebhookConfig `yaml:"webhooks"`
+}
+
+// WebhookConfig contains the configuration of a webhook to which the alerts are sent
+type WebhookConfig struct {
+	URL     string            `yaml:"url"`
+	Headers map[string]string `yaml:"headers,omitempty"`
+}
+
+// ParseConfig parses the alert module configuration from the given bytes,
+// returning nil if no configuration is present
+func ParseConfig(bz []byte) (*Config, error) {
+	type T struct {
+		Config *Config `yaml:"alert"`
+	}
+	var cfg T
+	err := yaml.Unmarshal(bz, &cfg)
+	return cfg.Config, err
+}
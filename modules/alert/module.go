@@ -0,0 +1,96 @@
+package alert
+
+import (
+	"fmt"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/forbole/juno/v2/modules"
+
+	"github.com/forbole/bdjuno/v2/database"
+	"github.com/forbole/bdjuno/v2/types"
+)
+
+const (
+	moduleName = "alert"
+
+	// alertsQueueSize represents the maximum number of alerts waiting to be sent to the sinks
+	alertsQueueSize = 100
+)
+
+var (
+	_ modules.Module = &Module{}
+)
+
+// rule represents a parsed alert rule
+type rule struct {
+	Type      string
+	Threshold sdk.Dec
+	Denom     string
+}
+
+// Module represents the module that raises alerts about the events handled by the other modules.
+// Alerts are queued and sent to the sinks by a separate worker, so that slow sinks do not block the parsing
+type Module struct {
+	rules  []rule
+	sinks  []Sink
+	maxAge time.Duration
+	queue  chan types.Alert
+}
+
+// NewModule returns a new Module instance. A nil configuration results in no alert being raised
+func NewModule(cfg *Config, db *database.Db) *Module {
+	module := &Module{}
+	if cfg == nil {
+		return module
+	}
+
+	module.maxAge = cfg.MaxAge
+
+	for _, ruleCfg := range cfg.Rules {
+		rule, err := parseRule(ruleCfg)
+		if err != nil {
+			panic(fmt.Errorf("invalid alert rule: %s", err))
+		}
+		module.rules = append(module.rules, rule)
+	}
+
+	if cfg.Sinks.Database {
+		module.sinks = append(module.sinks, NewDatabaseSink(db))
+	}
+
+	for _, webhook := range cfg.Sinks.Webhooks {
+		module.sinks = append(module.sinks, NewWebhookSink(webhook))
+	}
+
+	if len(module.rules) > 0 && len(module.sinks) > 0 {
+		module.queue = make(chan types.Alert, alertsQueueSize)
+		go module.sendAlerts()
+	}
+
+	return module
+}
+
+// parseRule parses the given configuration, making sure that it contains a valid threshold when required
+func parseRule(cfg RuleConfig) (rule, error) {
+	switch cfg.Type {
+	case types.AlertProposalCreated, types.AlertProposalVotingStarted, types.AlertProposalVotingEnded,
+		types.AlertValidatorSlashed:
+		return rule{Type: cfg.Type}, nil
+
+	case types.AlertValidatorLowUptime, types.AlertHugeDelegation, types.AlertHugeUndelegation:
+		threshold, err := sdk.NewDecFromStr(cfg.Threshold)
+		if err != nil {
+			return rule{}, fmt.Errorf("invalid threshold for %s: %s", cfg.Type, err)
+		}
+		return rule{Type: cfg.Type, Threshold: threshold, Denom: cfg.Denom}, nil
+
+	default:
+		return rule{}, fmt.Errorf("unknown alert type: %s", cfg.Type)
+	}
+}
+
+// Name implements modules.Module
+func (m *Module) Name() string {
+	return moduleName
+}
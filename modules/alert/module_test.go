@@ -0,0 +1,201 @@
+package alert
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	govtypes "github.com/cosmos/cosmos-sdk/x/gov/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/forbole/bdjuno/v2/types"
+)
+
+// receiver represents a local webhook that records all the alerts it receives
+type receiver struct {
+	server   *httptest.Server
+	mutex    sync.Mutex
+	payloads []WebhookPayload
+}
+
+func newReceiver(t *testing.T) *receiver {
+	r := &receiver{}
+	r.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		assert.Equal(t, "application/json", req.Header.Get("Content-Type"))
+		assert.Equal(t, "secret", req.Header.Get("X-Token"))
+
+		var payload WebhookPayload
+		assert.NoError(t, json.NewDecoder(req.Body).Decode(&payload))
+
+		r.mutex.Lock()
+		defer r.mutex.Unlock()
+		r.payloads = append(r.payloads, payload)
+	}))
+	t.Cleanup(r.server.Close)
+	return r
+}
+
+// waitTypes waits for the receiver to get the alerts having the given types, failing otherwise
+func (r *receiver) waitTypes(t *testing.T, alertTypes ...string) {
+	require.Eventually(t, func() bool {
+		return len(r.types()) >= len(alertTypes)
+	}, 5*time.Second, 10*time.Millisecond)
+	require.Equal(t, alertTypes, r.types())
+}
+
+func (r *receiver) types() []string {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	alertTypes := make([]string, len(r.payloads))
+	for i, payload := range r.payloads {
+		alertTypes[i] = payload.Type
+	}
+	return alertTypes
+}
+
+func newTestModule(r *receiver, maxAge time.Duration, rules ...RuleConfig) *Module {
+	return NewModule(NewConfig(rules, SinksConfig{
+		Webhooks: []WebhookConfig{{URL: r.server.URL, Headers: map[string]string{"X-Token": "secret"}}},
+	}, maxAge), nil)
+}
+
+func TestParseConfig(t *testing.T) {
+	bz := []byte(`
+alert:
+  rules:
+    - type: huge_delegation
+      threshold: "1000"
+      denom: acudos
+    - type: validator_slashed
+  sinks:
+    database: true
+    webhooks:
+      - url: http://127.0.0.1:8080/alerts
+        headers:
+          Authorization: Bearer token
+  max_age: 1h
+`)
+
+	cfg, err := ParseConfig(bz)
+	require.NoError(t, err)
+	require.Equal(t, NewConfig(
+		[]RuleConfig{
+			{Type: types.AlertHugeDelegation, Threshold: "1000", Denom: "acudos"},
+			{Type: types.AlertValidatorSlashed},
+		},
+		SinksConfig{
+			Database: true,
+			Webhooks: []WebhookConfig{
+				{URL: "http://127.0.0.1:8080/alerts", Headers: map[string]string{"Authorization": "Bearer token"}},
+			},
+		},
+		time.Hour,
+	), cfg)
+
+	cfg, err = ParseConfig([]byte(`pricefeed: {}`))
+	require.NoError(t, err)
+	require.Nil(t, cfg)
+}
+
+func TestNewModule_InvalidRules(t *testing.T) {
+	require.Panics(t, func() {
+		NewModule(NewConfig([]RuleConfig{{Type: "unknown"}}, SinksConfig{}, 0), nil)
+	})
+	require.Panics(t, func() {
+		NewModule(NewConfig([]RuleConfig{{Type: types.AlertHugeDelegation}}, SinksConfig{}, 0), nil)
+	})
+}
+
+func TestNotifyDelegation(t *testing.T) {
+	r := newReceiver(t)
+	module := newTestModule(r, 0,
+		RuleConfig{Type: types.AlertHugeDelegation, Threshold: "1000", Denom: "acudos"},
+		RuleConfig{Type: types.AlertHugeUndelegation, Threshold: "500"},
+	)
+
+	timestamp := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+	module.NotifyDelegation(10, timestamp, "delegator", "validator", sdk.NewCoin("acudos", sdk.NewInt(999)))
+	module.NotifyDelegation(10, timestamp, "delegator", "validator", sdk.NewCoin("stake", sdk.NewInt(2000)))
+	module.NotifyDelegation(11, timestamp, "delegator", "validator", sdk.NewCoin("acudos", sdk.NewInt(1000)))
+	module.NotifyUndelegation(12, timestamp, "delegator", "validator", sdk.NewCoin("stake", sdk.NewInt(500)))
+	r.waitTypes(t, types.AlertHugeDelegation, types.AlertHugeUndelegation)
+
+	payload := r.payloads[0]
+	require.Equal(t, int64(11), payload.Height)
+	require.True(t, timestamp.Equal(payload.Timestamp))
+	require.Equal(t, "delegator delegated 1000acudos to validator", payload.Message)
+	require.Equal(t, map[string]string{
+		"delegator_address": "delegator",
+		"validator_address": "validator",
+		"amount":            "1000",
+		"denom":             "acudos",
+	}, payload.Data)
+}
+
+func TestNotifyProposalStatusChanged(t *testing.T) {
+	r := newReceiver(t)
+	module := newTestModule(r, 0,
+		RuleConfig{Type: types.AlertProposalVotingStarted},
+		RuleConfig{Type: types.AlertProposalVotingEnded},
+	)
+
+	depositPeriod := govtypes.StatusDepositPeriod.String()
+	votingPeriod := govtypes.StatusVotingPeriod.String()
+	passed := govtypes.StatusPassed.String()
+
+	timestamp := time.Now()
+	module.NotifyProposalStatusChanged(10, timestamp, 1, depositPeriod, depositPeriod)
+	module.NotifyProposalStatusChanged(11, timestamp, 1, depositPeriod, votingPeriod)
+	module.NotifyProposalStatusChanged(12, timestamp, 1, votingPeriod, votingPeriod)
+	module.NotifyProposalStatusChanged(13, timestamp, 1, votingPeriod, passed)
+	r.waitTypes(t, types.AlertProposalVotingStarted, types.AlertProposalVotingEnded)
+	require.Equal(t, passed, r.payloads[1].Data["status"])
+}
+
+func TestNotifySigningInfoUpdated(t *testing.T) {
+	r := newReceiver(t)
+	module := newTestModule(r, 0,
+		RuleConfig{Type: types.AlertValidatorSlashed},
+		RuleConfig{Type: types.AlertValidatorLowUptime, Threshold: "0.9"},
+	)
+
+	notJailed := time.Unix(0, 0)
+	jailed := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+	info := func(jailedUntil time.Time, missed int64, height int64) types.ValidatorSigningInfo {
+		return types.NewValidatorSigningInfo("cudosvalcons1", 1, 0, jailedUntil, false, missed, height)
+	}
+
+	// The uptime drops below the threshold only once
+	timestamp := time.Now()
+	first := info(notJailed, 5, 10)
+	module.NotifySigningInfoUpdated(nil, first, 100, timestamp)
+	second := info(notJailed, 11, 11)
+	module.NotifySigningInfoUpdated(&first, second, 100, timestamp)
+	third := info(notJailed, 12, 12)
+	module.NotifySigningInfoUpdated(&second, third, 100, timestamp)
+	r.waitTypes(t, types.AlertValidatorLowUptime)
+
+	// The validator gets jailed, while older signing infos are ignored
+	fourth := info(jailed, 0, 13)
+	module.NotifySigningInfoUpdated(&fourth, third, 100, timestamp)
+	module.NotifySigningInfoUpdated(&third, fourth, 100, timestamp)
+	r.waitTypes(t, types.AlertValidatorLowUptime, types.AlertValidatorSlashed)
+}
+
+func TestRaise_MaxAge(t *testing.T) {
+	r := newReceiver(t)
+	module := newTestModule(r, time.Hour, RuleConfig{Type: types.AlertHugeDelegation, Threshold: "1000"})
+
+	// Alerts of old blocks are not sent to the sinks
+	amount := sdk.NewCoin("acudos", sdk.NewInt(1000))
+	module.NotifyDelegation(10, time.Now().Add(-2*time.Hour), "delegator", "validator", amount)
+	module.NotifyDelegation(11, time.Now(), "delegator", "validator", amount)
+	r.waitTypes(t, types.AlertHugeDelegation)
+	require.Equal(t, int64(11), r.payloads[0].Height)
+}
@@ -0,0 +1,211 @@
+package alert
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	govtypes "github.com/cosmos/cosmos-sdk/x/gov/types"
+	"github.com/rs/zerolog/log"
+
+	"github.com/forbole/bdjuno/v2/types"
+)
+
+// NotifyProposalSubmitted raises the alerts about the submission of the given proposal
+func (m *Module) NotifyProposalSubmitted(height int64, timestamp time.Time, proposal types.Proposal) {
+	data := map[string]string{
+		"proposal_id": strconv.FormatUint(proposal.ProposalID, 10),
+		"title":       proposal.Content.GetTitle(),
+		"proposer":    proposal.Proposer,
+	}
+
+	if m.hasRule(types.AlertProposalCreated) {
+		m.raise(types.AlertProposalCreated, height, timestamp, data,
+			"proposal #%d \"%s\" has been submitted by %s", proposal.ProposalID, proposal.Content.GetTitle(), proposal.Proposer)
+	}
+
+	// Proposals submitted with enough deposit enter the voting period right away
+	if proposal.Status == govtypes.StatusVotingPeriod.String() {
+		m.NotifyProposalStatusChanged(height, timestamp, proposal.ProposalID, "", proposal.Status)
+	}
+}
+
+// NotifyProposalStatusChanged raises the alerts about the status of the proposal
+// having the given id changing from oldStatus to newStatus
+func (m *Module) NotifyProposalStatusChanged(
+	height int64, timestamp time.Time, proposalID uint64, oldStatus string, newStatus string,
+) {
+	if oldStatus == newStatus {
+		return
+	}
+
+	data := map[string]string{
+		"proposal_id": strconv.FormatUint(proposalID, 10),
+		"status":      newStatus,
+	}
+
+	votingPeriod := govtypes.StatusVotingPeriod.String()
+	if newStatus == votingPeriod {
+		if m.hasRule(types.AlertProposalVotingStarted) {
+			m.raise(types.AlertProposalVotingStarted, height, timestamp, data,
+				"voting period of proposal #%d has started", proposalID)
+		}
+	}
+
+	if oldStatus == votingPeriod {
+		if m.hasRule(types.AlertProposalVotingEnded) {
+			m.raise(types.AlertProposalVotingEnded, height, timestamp, data,
+				"voting period of proposal #%d has ended with status %s", proposalID, newStatus)
+		}
+	}
+}
+
+// NotifySigningInfoUpdated raises the alerts about a validator signing info changing from the previous one,
+// which is nil if no signing info was known before. Alerts are raised only when a validator gets slashed
+// or its uptime over the signed blocks window drops below a rule threshold, so that they are not repeated
+func (m *Module) NotifySigningInfoUpdated(
+	previous *types.ValidatorSigningInfo, current types.ValidatorSigningInfo, signedBlocksWindow int64, timestamp time.Time,
+) {
+	if previous != nil && previous.Height >= current.Height {
+		return
+	}
+
+	data := map[string]string{
+		"validator_address":     current.ValidatorAddress,
+		"missed_blocks_counter": strconv.FormatInt(current.MissedBlocksCounter, 10),
+		"jailed_until":          current.JailedUntil.UTC().Format(time.RFC3339),
+		"tombstoned":            strconv.FormatBool(current.Tombstoned),
+	}
+
+	if previous != nil &&
+		((current.Tombstoned && !previous.Tombstoned) || current.JailedUntil.After(previous.JailedUntil)) {
+		if m.hasRule(types.AlertValidatorSlashed) {
+			m.raise(types.AlertValidatorSlashed, current.Height, timestamp, data,
+				"validator %s has been slashed and jailed until %s",
+				current.ValidatorAddress, current.JailedUntil.UTC().Format(time.RFC3339))
+		}
+	}
+
+	if signedBlocksWindow <= 0 {
+		return
+	}
+
+	uptime := getUptime(current.MissedBlocksCounter, signedBlocksWindow)
+	for _, rule := range m.getRules(types.AlertValidatorLowUptime) {
+		if uptime.GTE(rule.Threshold) {
+			continue
+		}
+
+		if previous != nil && getUptime(previous.MissedBlocksCounter, signedBlocksWindow).LT(rule.Threshold) {
+			// The alert has already been raised
+			continue
+		}
+
+		m.raise(types.AlertValidatorLowUptime, current.Height, timestamp, data,
+			"validator %s uptime is %s, below the threshold of %s",
+			current.ValidatorAddress, uptime.String(), rule.Threshold.String())
+	}
+}
+
+// getUptime returns the uptime of a validator that missed the given number of blocks over the given window
+func getUptime(missedBlocksCounter int64, signedBlocksWindow int64) sdk.Dec {
+	return sdk.NewDec(signedBlocksWindow - missedBlocksCounter).QuoInt64(signedBlocksWindow)
+}
+
+// NotifyDelegation raises the alerts about the delegation of the given amount
+func (m *Module) NotifyDelegation(
+	height int64, timestamp time.Time, delegator string, validator string, amount sdk.Coin,
+) {
+	m.notifyHugeAmount(types.AlertHugeDelegation, height, timestamp, delegator, validator, amount,
+		"%s delegated %s to %s")
+}
+
+// NotifyUndelegation raises the alerts about the undelegation of the given amount
+func (m *Module) NotifyUndelegation(
+	height int64, timestamp time.Time, delegator string, validator string, amount sdk.Coin,
+) {
+	m.notifyHugeAmount(types.AlertHugeUndelegation, height, timestamp, delegator, validator, amount,
+		"%s undelegated %s from %s")
+}
+
+// notifyHugeAmount raises the alerts of the given type for which the given amount exceeds the threshold
+func (m *Module) notifyHugeAmount(
+	alertType string, height int64, timestamp time.Time, delegator string, validator string, amount sdk.Coin,
+	format string,
+) {
+	data := map[string]string{
+		"delegator_address": delegator,
+		"validator_address": validator,
+		"amount":            amount.Amount.String(),
+		"denom":             amount.Denom,
+	}
+
+	for _, rule := range m.getRules(alertType) {
+		if rule.Denom != "" && rule.Denom != amount.Denom {
+			continue
+		}
+
+		if amount.Amount.ToDec().LT(rule.Threshold) {
+			continue
+		}
+
+		m.raise(alertType, height, timestamp, data, format, delegator, amount.String(), validator)
+	}
+}
+
+// --------------------------------------------------------------------------------------------------------------------
+
+// getRules returns all the rules having the given alert type
+func (m *Module) getRules(alertType string) []rule {
+	var rules []rule
+	for _, rule := range m.rules {
+		if rule.Type == alertType {
+			rules = append(rules, rule)
+		}
+	}
+	return rules
+}
+
+// hasRule tells whether there is at least a rule having the given alert type
+func (m *Module) hasRule(alertType string) bool {
+	return len(m.getRules(alertType)) > 0
+}
+
+// raise queues a new alert raised for the block having the given height and timestamp.
+// Alerts of blocks older than the configured max age are ignored, and so are the ones
+// that do not fit inside the queue, so that the parsing is never blocked by the sinks
+func (m *Module) raise(
+	alertType string, height int64, timestamp time.Time, data map[string]string, format string, args ...interface{},
+) {
+	if m.queue == nil {
+		return
+	}
+
+	if m.maxAge > 0 && time.Since(timestamp) > m.maxAge {
+		log.Debug().Str("module", moduleName).Int64("height", height).
+			Str("type", alertType).Msg("skipping alert of old block")
+		return
+	}
+
+	alert := types.NewAlert(alertType, fmt.Sprintf(format, args...), data, height, timestamp.UTC())
+	select {
+	case m.queue <- alert:
+	default:
+		log.Warn().Str("module", moduleName).Int64("height", height).
+			Str("type", alertType).Msg("alerts queue is full, dropping alert")
+	}
+}
+
+// sendAlerts sends the queued alerts to all the sinks, logging the ones that could not be reached
+func (m *Module) sendAlerts() {
+	for alert := range m.queue {
+		for _, sink := range m.sinks {
+			err := sink.Send(alert)
+			if err != nil {
+				log.Error().Str("module", moduleName).Err(err).Int64("height", alert.Height).
+					Str("type", alert.Type).Msg("error while sending alert")
+			}
+		}
+	}
+}
@@ -0,0 +1,103 @@
+package alert
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/forbole/bdjuno/v2/database"
+	"github.com/forbole/bdjuno/v2/types"
+)
+
+// Sink represents a destination to which the alerts are sent
+type Sink interface {
+	Send(alert types.Alert) error
+}
+
+// --------------------------------------------------------------------------------------------------------------------
+
+var _ Sink = &DatabaseSink{}
+
+// DatabaseSink stores the alerts inside the alert table
+type DatabaseSink struct {
+	db *database.Db
+}
+
+// NewDatabaseSink returns a new DatabaseSink instance
+func NewDatabaseSink(db *database.Db) *DatabaseSink {
+	return &DatabaseSink{
+		db: db,
+	}
+}
+
+// Send implements Sink
+func (s *DatabaseSink) Send(alert types.Alert) error {
+	return s.db.SaveAlert(alert)
+}
+
+// --------------------------------------------------------------------------------------------------------------------
+
+var _ Sink = &WebhookSink{}
+
+// WebhookSink posts the alerts as JSON to a generic webhook
+type WebhookSink struct {
+	url     string
+	headers map[string]string
+	client  *http.Client
+}
+
+// NewWebhookSink returns a new WebhookSink instance
+func NewWebhookSink(cfg WebhookConfig) *WebhookSink {
+	return &WebhookSink{
+		url:     cfg.URL,
+		headers: cfg.Headers,
+		client:  &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+// WebhookPayload represents the body sent to the webhooks for each alert
+type WebhookPayload struct {
+	Type      string            `json:"type"`
+	Message   string            `json:"message"`
+	Data      map[string]string `json:"data"`
+	Height    int64             `json:"height"`
+	Timestamp time.Time         `json:"timestamp"`
+}
+
+// Send implements Sink
+func (s *WebhookSink) Send(alert types.Alert) error {
+	bz, err := json.Marshal(WebhookPayload{
+		Type:      alert.Type,
+		Message:   alert.Message,
+		Data:      alert.Data,
+		Height:    alert.Height,
+		Timestamp: alert.Timestamp,
+	})
+	if err != nil {
+		return fmt.Errorf("error while marshaling alert: %s", err)
+	}
+
+	req, err := http.NewRequest(http.MethodPost, s.url, bytes.NewReader(bz))
+	if err != nil {
+		return fmt.Errorf("error while building webhook request: %s", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	for key, value := range s.headers {
+		req.Header.Set(key, value)
+	}
+
+	res, err := s.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("error while sending alert to webhook: %s", err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		return fmt.Errorf("webhook returned unexpected status code: %d", res.StatusCode)
+	}
+
+	return nil
+}
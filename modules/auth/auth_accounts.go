@@ -49,7 +49,20 @@ func GetAccounts(height int64, addresses []string) []types.Account {
 
 // RefreshAccounts takes the given addresses and for each one queries the chain
 // retrieving the account data and stores it inside the database.
+// It also queues the accounts so that their balance history at the given height is stored
+// later on, logging any error as the history is not required to handle the messages.
 func (m *Module) RefreshAccounts(height int64, addresses []string) error {
 	accounts := GetAccounts(height, addresses)
-	return m.db.SaveAccounts(accounts)
+	err := m.db.SaveAccounts(accounts)
+	if err != nil {
+		return err
+	}
+
+	err = m.historyModule.QueueAccountsBalanceHistory(height, addresses)
+	if err != nil {
+		log.Error().Str("module", "auth").Err(err).Int64("height", height).
+			Msg("error while queueing accounts balance history")
+	}
+
+	return nil
 }
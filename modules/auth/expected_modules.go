@@ -0,0 +1,5 @@
+package auth
+
+type HistoryModule interface {
+	QueueAccountsBalanceHistory(height int64, addresses []string) error
+}
@@ -20,12 +20,14 @@ type Module struct {
 	cdc            codec.Codec
 	db             *database.Db
 	messagesParser messages.MessageAddressesParser
+	historyModule  HistoryModule
 }
 
 // NewModule builds a new Module instance
-func NewModule(messagesParser messages.MessageAddressesParser, cdc codec.Codec, db *database.Db) *Module {
+func NewModule(messagesParser messages.MessageAddressesParser, historyModule HistoryModule, cdc codec.Codec, db *database.Db) *Module {
 	return &Module{
 		messagesParser: messagesParser,
+		historyModule:  historyModule,
 		cdc:            cdc,
 		db:             db,
 	}
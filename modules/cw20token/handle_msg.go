@@ -3,7 +3,6 @@ package cw20token
 import (
 	"encoding/json"
 	"strconv"
-	"strings"
 
 	wasm "github.com/CosmWasm/wasmd/x/wasm/types"
 	sdk "github.com/cosmos/cosmos-sdk/types"
@@ -20,18 +19,25 @@ func (m *Module) HandleMsg(index int, msg sdk.Msg, tx *juno.Tx) error {
 	}
 
 	return m.db.ExecuteTx(func(dbTx *database.DbTx) error {
+		var err error
 		switch cosmosMsg := msg.(type) {
 		case *wasm.MsgStoreCode:
-			return m.handleMsgStoreCode(dbTx, cosmosMsg, tx, index)
+			err = m.handleMsgStoreCode(dbTx, cosmosMsg, tx, index)
 		case *wasm.MsgInstantiateContract:
-			return m.handleMsgInstantiateContract(dbTx, cosmosMsg, tx, index)
+			err = m.handleMsgInstantiateContract(dbTx, cosmosMsg, tx, index)
 		case *wasm.MsgExecuteContract:
-			return m.handleMsgExecuteContract(dbTx, cosmosMsg, tx, index)
+			err = m.handleMsgExecuteContract(dbTx, cosmosMsg, tx, index)
 		case *wasm.MsgMigrateContract:
-			return m.handleMsgMigrateContract(dbTx, cosmosMsg)
-		default:
-			return nil
+			err = m.handleMsgMigrateContract(dbTx, cosmosMsg)
 		}
+
+		if err != nil {
+			return err
+		}
+
+		// Balance changes are taken from the wasm events so that the ones
+		// triggered by other contracts through sub-messages are indexed too
+		return m.handleWasmEvents(dbTx, tx, index)
 	})
 }
 
@@ -62,26 +68,12 @@ func (m *Module) handleMsgInstantiateContract(dbTx *database.DbTx, msg *wasm.Msg
 	tokenInfo.CodeID = msg.CodeID
 	tokenInfo.Creator = msg.Sender
 
-	switch {
-
-	case strings.Contains(string(msg.Msg), "standard"):
-		tokenInfo.Type = "standard"
-		tokenInfo.Mint.MaxSupply = tokenInfo.TotalSupply
-
-	case strings.Contains(string(msg.Msg), "mintable"):
-		tokenInfo.Type = "mintable"
-
-	case strings.Contains(string(msg.Msg), "burnable"):
-		tokenInfo.Type = "burnable"
-		tokenInfo.Mint.MaxSupply = tokenInfo.TotalSupply
-
-	case strings.Contains(string(msg.Msg), "unlimited"):
-		tokenInfo.Type = "unlimited"
-
-	default:
-		tokenInfo.Type = ""
+	msgInstantiate := types.MsgInstantiate{}
+	if err := json.Unmarshal(msg.Msg, &msgInstantiate); err != nil {
+		return err
 	}
 
+	tokenInfo.SetType(msgInstantiate)
 	if err := dbTx.SaveInfo(tokenInfo); err != nil {
 		return err
 	}
@@ -91,7 +83,7 @@ func (m *Module) handleMsgInstantiateContract(dbTx *database.DbTx, msg *wasm.Msg
 		return err
 	}
 
-	return dbTx.SaveBalances(contractAddr, balances)
+	return dbTx.SaveBalances(contractAddr, balances, tx.Height)
 }
 
 func (m *Module) handleMsgExecuteContract(dbTx *database.DbTx, msg *wasm.MsgExecuteContract, tx *juno.Tx, index int) error {
@@ -105,25 +97,8 @@ func (m *Module) handleMsgExecuteContract(dbTx *database.DbTx, msg *wasm.MsgExec
 	}
 
 	msgType := mutils.GetValueFromLogs(uint32(index), tx.Logs, wasm.WasmModuleEventType, sdk.AttributeKeyAction)
-	addresses := []string{}
 
 	switch types.TypeMsgExecute(msgType) {
-	case types.TypeTransfer:
-		addresses = append(addresses, msgExecute.Transfer.Recipient, msg.Sender)
-	case types.TypeTransferFrom:
-		mm := msgExecute.TransferFrom
-		addresses = append(addresses, mm.Owner, mm.Recipient)
-	case types.TypeSend:
-		addresses = append(addresses, msgExecute.Send.Contract, msg.Sender)
-	case types.TypeSendFrom:
-		mm := msgExecute.SendFrom
-		addresses = append(addresses, mm.Owner, mm.Contract)
-	case types.TypeBurn:
-		addresses = append(addresses, msg.Sender)
-	case types.TypeBurnFrom:
-		addresses = append(addresses, msgExecute.BurnFrom.Owner)
-	case types.TypeMint:
-		addresses = append(addresses, msgExecute.Mint.Recipient)
 	case types.TypeUpdateMinter:
 		return dbTx.UpdateMinter(msg.Contract, msgExecute.UpdateMinter.NewMinter)
 	case types.TypeUpdateMarketing:
@@ -131,28 +106,18 @@ func (m *Module) handleMsgExecuteContract(dbTx *database.DbTx, msg *wasm.MsgExec
 		return dbTx.UpdateMarketing(msg.Contract, types.Marketing{mm.Project, mm.Description, mm.Admin, nil})
 	case types.TypeUploadLogo:
 		return dbTx.UpdateLogo(msg.Contract, mutils.SanitizeUTF8(string(msgExecute.UploadLogo)))
+	default:
+		return nil
 	}
+}
 
-	supply, err := m.source.TotalSupply(msg.Contract, tx.Height)
+func (m *Module) refreshAllowance(dbTx *database.DbTx, token string, owner string, spender string, height int64) error {
+	allowance, err := m.source.Allowance(token, owner, spender, height)
 	if err != nil {
 		return err
 	}
 
-	if err := dbTx.UpdateSupply(msg.Contract, supply); err != nil {
-		return err
-	}
-
-	balances := make([]types.TokenBalance, len(addresses))
-	for i, a := range addresses {
-		b, err := m.source.Balance(msg.Contract, a, tx.Height)
-		if err != nil {
-			return err
-		}
-
-		balances[i] = types.TokenBalance{a, b}
-	}
-
-	return dbTx.SaveBalances(msg.Contract, balances)
+	return dbTx.SaveAllowance(token, allowance)
 }
 
 func (m *Module) handleMsgMigrateContract(dbTx *database.DbTx, msg *wasm.MsgMigrateContract) error {
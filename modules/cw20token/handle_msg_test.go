@@ -23,56 +23,61 @@ func TestCW20Token_HandleMsg(t *testing.T) {
 		"instantiate": {
 			arrange: func(s *source.MockSource, txb *utils.MockTxBuilder) sdk.Msg {
 				s.T.Address = tokenAddr2
+				s.T.Type = types.TokenTypeMintable
 				txb.WithEventInstantiateContract(tokenAddr2)
-				return &wasm.MsgInstantiateContract{CodeID: s.T.CodeID}
+
+				msgJSON, err := json.Marshal(types.MsgInstantiate{InitialBalances: s.T.Balances, Mint: &s.T.Mint})
+				require.NoError(t, err)
+
+				return &wasm.MsgInstantiateContract{CodeID: s.T.CodeID, Msg: msgJSON}
 			},
 		},
 		"execute transfer": {
 			arrange: func(s *source.MockSource, txb *utils.MockTxBuilder) sdk.Msg {
 				s.Transfer(addr1, addr2, num1)
-				txb.WithEventWasmAction(string(types.TypeTransfer))
+				txb.WithEventWasm(tokenAddr1, string(types.TypeTransfer), mockTransferAttrs(addr1, addr2, "")...)
 				return mockMsgExecute(t, types.MsgExecute{Transfer: types.MsgTransfer{addr2, str1}})
 			},
 		},
 		"execute transfer_from": {
 			arrange: func(s *source.MockSource, txb *utils.MockTxBuilder) sdk.Msg {
 				s.Transfer(addr2, addr1, num1)
-				txb.WithEventWasmAction(string(types.TypeTransferFrom))
+				txb.WithEventWasm(tokenAddr1, string(types.TypeTransferFrom), mockTransferAttrs(addr2, addr1, addr1)...)
 				return mockMsgExecute(t, types.MsgExecute{TransferFrom: types.MsgTransferFrom{addr2, addr1, str1}})
 			},
 		},
 		"execute send": {
 			arrange: func(s *source.MockSource, txb *utils.MockTxBuilder) sdk.Msg {
 				s.Transfer(addr1, addr2, num1)
-				txb.WithEventWasmAction(string(types.TypeSend))
+				txb.WithEventWasm(tokenAddr1, string(types.TypeSend), mockTransferAttrs(addr1, addr2, "")...)
 				return mockMsgExecute(t, types.MsgExecute{Send: types.MsgSend{addr2, str1, nil}})
 			},
 		},
 		"execute send_from": {
 			arrange: func(s *source.MockSource, txb *utils.MockTxBuilder) sdk.Msg {
 				s.Transfer(addr2, addr1, num1)
-				txb.WithEventWasmAction(string(types.TypeSendFrom))
+				txb.WithEventWasm(tokenAddr1, string(types.TypeSendFrom), mockTransferAttrs(addr2, addr1, addr1)...)
 				return mockMsgExecute(t, types.MsgExecute{SendFrom: types.MsgSendFrom{addr2, addr1, str1, nil}})
 			},
 		},
 		"execute burn": {
 			arrange: func(s *source.MockSource, txb *utils.MockTxBuilder) sdk.Msg {
 				s.Burn(addr1, num1)
-				txb.WithEventWasmAction(string(types.TypeBurn))
+				txb.WithEventWasm(tokenAddr1, string(types.TypeBurn), mockTransferAttrs(addr1, "", "")...)
 				return mockMsgExecute(t, types.MsgExecute{Burn: types.MsgBurn{str1}})
 			},
 		},
 		"execute burn_from": {
 			arrange: func(s *source.MockSource, txb *utils.MockTxBuilder) sdk.Msg {
 				s.Burn(addr2, num1)
-				txb.WithEventWasmAction(string(types.TypeBurnFrom))
+				txb.WithEventWasm(tokenAddr1, string(types.TypeBurnFrom), mockTransferAttrs(addr2, "", addr1)...)
 				return mockMsgExecute(t, types.MsgExecute{BurnFrom: types.MsgBurnFrom{addr2, str1}})
 			},
 		},
 		"execute mint": {
 			arrange: func(s *source.MockSource, txb *utils.MockTxBuilder) sdk.Msg {
 				s.Mint(addr2, num1)
-				txb.WithEventWasmAction(string(types.TypeMint))
+				txb.WithEventWasm(tokenAddr1, string(types.TypeMint), mockTransferAttrs("", addr2, "")...)
 				return mockMsgExecute(t, types.MsgExecute{Mint: types.MsgMint{addr2, str1}})
 			},
 		},
@@ -164,6 +169,161 @@ func TestCW20Token_HandleMsg(t *testing.T) {
 	}
 }
 
+func TestCW20Token_HandleMsg_TransferHistory(t *testing.T) {
+	for testName, tc := range map[string]struct {
+		arrange func(txb *utils.MockTxBuilder) sdk.Msg
+		want    []types.TokenTransfer
+	}{
+		"transfer": {
+			arrange: func(txb *utils.MockTxBuilder) sdk.Msg {
+				txb.WithEventWasm(tokenAddr1, string(types.TypeTransfer), mockTransferAttrs(addr1, addr2, "")...)
+				return mockMsgExecute(t, types.MsgExecute{Transfer: types.MsgTransfer{Recipient: addr2, Amount: str1}})
+			},
+			want: []types.TokenTransfer{{Type: string(types.TypeTransfer), From: addr1, To: addr2, Amount: str1}},
+		},
+		"burn": {
+			arrange: func(txb *utils.MockTxBuilder) sdk.Msg {
+				txb.WithEventWasm(tokenAddr1, string(types.TypeBurn), mockTransferAttrs(addr1, "", "")...)
+				return mockMsgExecute(t, types.MsgExecute{Burn: types.MsgBurn{Amount: str1}})
+			},
+			want: []types.TokenTransfer{{Type: string(types.TypeBurn), From: addr1, Amount: str1}},
+		},
+		"sub-messages": {
+			arrange: func(txb *utils.MockTxBuilder) sdk.Msg {
+				txb.WithEventWasm(tokenAddr2, "swap")
+				txb.WithEventWasm(tokenAddr1, string(types.TypeTransfer), mockTransferAttrs(tokenAddr2, addr2, "")...)
+				txb.WithEventWasm(tokenAddr1, string(types.TypeMint), mockTransferAttrs("", addr1, "")...)
+				return &wasm.MsgExecuteContract{Contract: tokenAddr2, Sender: addr1, Msg: []byte(`{"swap":{}}`)}
+			},
+			want: []types.TokenTransfer{
+				{Type: string(types.TypeTransfer), From: tokenAddr2, To: addr2, Amount: str1, EventIndex: 1},
+				{Type: string(types.TypeMint), To: addr1, Amount: str1, EventIndex: 2},
+			},
+		},
+	} {
+		t.Run(testName, func(t *testing.T) {
+			db, err := utils.NewTestDb("cw20TokenTest_transferHistory")
+			require.NoError(t, err)
+
+			s := source.NewMockSource(mockTokenInfo)
+
+			_, err = db.Sqlx.Exec(`INSERT INTO cw20token_code_id VALUES ($1)`, s.T.CodeID)
+			require.NoError(t, err)
+
+			_, err = db.Sqlx.Exec(
+				`INSERT INTO cw20token_info (address, code_id, name, symbol, decimals, initial_supply, circulating_supply, creator)
+				VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`,
+				s.T.Address, s.T.CodeID, s.T.Name, s.T.Symbol, s.T.Decimals, s.T.TotalSupply, s.T.TotalSupply, s.T.Creator,
+			)
+			require.NoError(t, err)
+
+			m := NewModule(simapp.MakeTestEncodingConfig().Marshaler, db, s)
+			txb := utils.NewMockTxBuilder(t, time.Time{}, str2, num1)
+			msg := tc.arrange(txb)
+
+			err = m.HandleMsg(0, msg, txb.Build())
+			require.NoError(t, err)
+
+			var have []dbtypes.TokenTransferRow
+			err = db.Sqlx.Select(&have, `SELECT * FROM cw20token_transfer_history WHERE token = $1 ORDER BY event_index`, s.T.Address)
+			require.NoError(t, err)
+
+			want := []dbtypes.TokenTransferRow{}
+			for _, w := range tc.want {
+				want = append(want, dbtypes.TokenTransferRow{
+					Token:      s.T.Address,
+					Type:       w.Type,
+					From:       w.From,
+					To:         w.To,
+					Amount:     w.Amount,
+					Height:     num1,
+					TxHash:     str2,
+					EventIndex: w.EventIndex,
+				})
+			}
+			require.Equal(t, want, have)
+		})
+	}
+}
+
+func TestCW20Token_HandleMsg_Allowance(t *testing.T) {
+	for testName, tc := range map[string]struct {
+		arrange func(s *source.MockSource, txb *utils.MockTxBuilder) sdk.Msg
+	}{
+		"execute increase_allowance": {
+			arrange: func(s *source.MockSource, txb *utils.MockTxBuilder) sdk.Msg {
+				s.UpdateAllowance(addr1, addr2, "15")
+				txb.WithEventWasm(tokenAddr1, string(types.TypeIncreaseAllowance), mockAllowanceAttrs(addr1, addr2)...)
+				return mockMsgExecute(t, types.MsgExecute{IncreaseAllowance: types.MsgIncreaseAllowance{Spender: addr2, Amount: "5"}})
+			},
+		},
+		"execute decrease_allowance": {
+			arrange: func(s *source.MockSource, txb *utils.MockTxBuilder) sdk.Msg {
+				s.UpdateAllowance(addr1, addr2, "0")
+				txb.WithEventWasm(tokenAddr1, string(types.TypeDecreaseAllowance), mockAllowanceAttrs(addr1, addr2)...)
+				return mockMsgExecute(t, types.MsgExecute{DecreaseAllowance: types.MsgDecreaseAllowance{Spender: addr2, Amount: "10"}})
+			},
+		},
+		"execute transfer_from": {
+			arrange: func(s *source.MockSource, txb *utils.MockTxBuilder) sdk.Msg {
+				s.UpdateAllowance(addr2, addr1, "9")
+				s.Transfer(addr2, addr1, num1)
+				txb.WithEventWasm(tokenAddr1, string(types.TypeTransferFrom), mockTransferAttrs(addr2, addr1, addr1)...)
+				return mockMsgExecute(t, types.MsgExecute{TransferFrom: types.MsgTransferFrom{Owner: addr2, Recipient: addr1, Amount: str1}})
+			},
+		},
+	} {
+		t.Run(testName, func(t *testing.T) {
+			db, err := utils.NewTestDb("cw20TokenTest_allowance")
+			require.NoError(t, err)
+
+			s := source.NewMockSource(mockTokenInfo)
+			s.UpdateAllowance(addr1, addr2, "10")
+			s.UpdateAllowance(addr2, addr1, "10")
+
+			_, err = db.Sqlx.Exec(`INSERT INTO cw20token_code_id VALUES ($1)`, s.T.CodeID)
+			require.NoError(t, err)
+
+			_, err = db.Sqlx.Exec(
+				`INSERT INTO cw20token_info (address, code_id, name, symbol, decimals, initial_supply, circulating_supply, creator)
+				VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`,
+				s.T.Address, s.T.CodeID, s.T.Name, s.T.Symbol, s.T.Decimals, s.T.TotalSupply, s.T.TotalSupply, s.T.Creator,
+			)
+			require.NoError(t, err)
+
+			for _, a := range s.Allowances {
+				_, err = db.Sqlx.Exec(
+					`INSERT INTO cw20token_allowance VALUES ($1, $2, $3, $4, $5)`,
+					s.T.Address, a.Owner, a.Spender, a.Amount, string(a.Expires),
+				)
+				require.NoError(t, err)
+			}
+
+			m := NewModule(simapp.MakeTestEncodingConfig().Marshaler, db, s)
+			txb := utils.NewMockTxBuilder(t, time.Time{}, "", num1)
+			msg := tc.arrange(s, txb)
+
+			err = m.HandleMsg(0, msg, txb.Build())
+			require.NoError(t, err)
+
+			for _, a := range s.Allowances {
+				var have []string
+				err = db.Sqlx.Select(
+					&have, `SELECT amount FROM cw20token_allowance WHERE token = $1 AND owner = $2 AND spender = $3`,
+					s.T.Address, a.Owner, a.Spender,
+				)
+				require.NoError(t, err)
+
+				if a.Amount == "0" {
+					require.Empty(t, have)
+				} else {
+					require.Equal(t, []string{a.Amount}, have)
+				}
+			}
+		})
+	}
+}
+
 const (
 	addr1      = "cudos1"
 	addr2      = "cudos2"
@@ -204,6 +364,25 @@ func mockMsgExecute(t *testing.T, msg types.MsgExecute) *wasm.MsgExecuteContract
 	}
 }
 
+func mockTransferAttrs(from string, to string, by string) []sdk.Attribute {
+	attrs := []sdk.Attribute{}
+	if from != "" {
+		attrs = append(attrs, sdk.NewAttribute("from", from))
+	}
+	if to != "" {
+		attrs = append(attrs, sdk.NewAttribute("to", to))
+	}
+	if by != "" {
+		attrs = append(attrs, sdk.NewAttribute("by", by))
+	}
+
+	return append(attrs, sdk.NewAttribute("amount", str1))
+}
+
+func mockAllowanceAttrs(owner string, spender string) []sdk.Attribute {
+	return []sdk.Attribute{sdk.NewAttribute("owner", owner), sdk.NewAttribute("spender", spender), sdk.NewAttribute("amount", str1)}
+}
+
 func parseTokenInfoFromDbRow(t dbtypes.TokenInfoRow) types.TokenInfo {
 	logo := json.RawMessage(t.Logo)
 	return types.TokenInfo{
@@ -216,5 +395,6 @@ func parseTokenInfoFromDbRow(t dbtypes.TokenInfoRow) types.TokenInfo {
 		Mint:          types.Mint{t.Minter, t.MaxSupply},
 		Marketing:     types.Marketing{t.ProjectURL, t.Description, t.MarketingAdmin, &logo},
 		CodeID:        t.CodeID,
+		Type:          t.Type,
 		Balances:      []types.TokenBalance{}}
 }
@@ -0,0 +1,105 @@
+package cw20token
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	juno "github.com/forbole/juno/v2/types"
+
+	"github.com/forbole/bdjuno/v2/database"
+	mutils "github.com/forbole/bdjuno/v2/modules/utils"
+	"github.com/forbole/bdjuno/v2/types"
+)
+
+const (
+	attributeKeyFrom    = "from"
+	attributeKeyTo      = "to"
+	attributeKeyBy      = "by"
+	attributeKeyAmount  = "amount"
+	attributeKeyOwner   = "owner"
+	attributeKeySpender = "spender"
+)
+
+// handleWasmEvents updates the balances, supply, allowances and transfer history
+// of every known token that has been executed by the message with the given index
+func (m *Module) handleWasmEvents(dbTx *database.DbTx, tx *juno.Tx, index int) error {
+	tokens := map[string]bool{}
+
+	for eventIndex, event := range mutils.GetWasmEventsFromLogs(uint32(index), tx.Logs) {
+		found, ok := tokens[event.Contract]
+		if !ok {
+			var err error
+			if found, err = dbTx.TokenExists(event.Contract); err != nil {
+				return err
+			}
+			tokens[event.Contract] = found
+		}
+
+		if !found {
+			continue
+		}
+
+		if err := m.handleWasmEvent(dbTx, event, tx, index, eventIndex); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (m *Module) handleWasmEvent(dbTx *database.DbTx, event mutils.WasmEvent, tx *juno.Tx, index int, eventIndex int) error {
+	msgType := event.Attributes[sdk.AttributeKeyAction]
+	attrs := event.Attributes
+
+	transfer := types.TokenTransfer{
+		Type:       msgType,
+		From:       attrs[attributeKeyFrom],
+		To:         attrs[attributeKeyTo],
+		Amount:     attrs[attributeKeyAmount],
+		Height:     tx.Height,
+		TxHash:     tx.TxHash,
+		MsgIndex:   index,
+		EventIndex: eventIndex,
+	}
+
+	switch types.TypeMsgExecute(msgType) {
+	case types.TypeTransfer, types.TypeSend, types.TypeBurn, types.TypeMint:
+	case types.TypeTransferFrom, types.TypeSendFrom, types.TypeBurnFrom:
+		if err := m.refreshAllowance(dbTx, event.Contract, transfer.From, attrs[attributeKeyBy], tx.Height); err != nil {
+			return err
+		}
+	case types.TypeIncreaseAllowance, types.TypeDecreaseAllowance:
+		return m.refreshAllowance(dbTx, event.Contract, attrs[attributeKeyOwner], attrs[attributeKeySpender], tx.Height)
+	default:
+		return nil
+	}
+
+	if err := dbTx.SaveTransfer(event.Contract, transfer); err != nil {
+		return err
+	}
+
+	if transfer.From == "" || transfer.To == "" {
+		supply, err := m.source.TotalSupply(event.Contract, tx.Height)
+		if err != nil {
+			return err
+		}
+
+		if err := dbTx.UpdateSupply(event.Contract, supply); err != nil {
+			return err
+		}
+	}
+
+	balances := []types.TokenBalance{}
+	for _, a := range []string{transfer.From, transfer.To} {
+		if a == "" {
+			continue
+		}
+
+		b, err := m.source.Balance(event.Contract, a, tx.Height)
+		if err != nil {
+			return err
+		}
+
+		balances = append(balances, types.TokenBalance{Address: a, Amount: b})
+	}
+
+	return dbTx.SaveBalances(event.Contract, balances, tx.Height)
+}
@@ -30,3 +30,8 @@ func NewModule(cdc codec.Codec, db *database.Db, source source.Source) *Module {
 func (m *Module) Name() string {
 	return "cw20token"
 }
+
+// Source returns the source used by the module to query the tokens from the chain
+func (m *Module) Source() source.Source {
+	return m.source
+}
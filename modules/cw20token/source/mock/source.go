@@ -13,7 +13,8 @@ var (
 )
 
 type MockSource struct {
-	T types.TokenInfo
+	T          types.TokenInfo
+	Allowances []types.Allowance
 }
 
 func NewMockSource(token types.TokenInfo) *MockSource {
@@ -21,7 +22,7 @@ func NewMockSource(token types.TokenInfo) *MockSource {
 	tokenCopy.Balances = []types.TokenBalance{}
 	tokenCopy.Balances = append(tokenCopy.Balances, token.Balances...)
 
-	return &MockSource{tokenCopy}
+	return &MockSource{T: tokenCopy, Allowances: []types.Allowance{}}
 }
 
 func (s *MockSource) TokenInfo(tokenAddr string, height int64) (types.TokenInfo, error) {
@@ -46,6 +47,16 @@ func (s *MockSource) TotalSupply(tokenAddr string, height int64) (string, error)
 	return s.T.TotalSupply, nil
 }
 
+func (s *MockSource) Allowance(tokenAddr string, owner string, spender string, height int64) (types.Allowance, error) {
+	for _, a := range s.Allowances {
+		if a.Owner == owner && a.Spender == spender {
+			return a, nil
+		}
+	}
+
+	return types.Allowance{Owner: owner, Spender: spender, Amount: "0", Expires: json.RawMessage(`{"never":{}}`)}, nil
+}
+
 func (s *MockSource) getBalanceIndex(addr string) int {
 	for i, b := range s.T.Balances {
 		if b.Address == addr {
@@ -115,3 +126,14 @@ func (s *MockSource) UpdateLogo(newLogo string) {
 func (s *MockSource) UpdateMarketing(marketing types.Marketing) {
 	s.T.Marketing = marketing
 }
+
+func (s *MockSource) UpdateAllowance(owner string, spender string, amount string) {
+	for i, a := range s.Allowances {
+		if a.Owner == owner && a.Spender == spender {
+			s.Allowances[i].Amount = amount
+			return
+		}
+	}
+
+	s.Allowances = append(s.Allowances, types.Allowance{Owner: owner, Spender: spender, Amount: amount, Expires: json.RawMessage(`{"never":{}}`)})
+}
@@ -97,6 +97,19 @@ func (q *QueryHandler) TotalSupply(ctx context.Context, tokenAddr string, height
 	return supply.TotalSupply, err
 }
 
+func (q *QueryHandler) Allowance(ctx context.Context, tokenAddr string, owner string, spender string, height int64) (types.Allowance, error) {
+	allowance := types.Allowance{}
+
+	query := fmt.Sprintf(`{"allowance":{"owner":"%s","spender":"%s"}}`, owner, spender)
+	if err := q.query(ctx, tokenAddr, query, &allowance); err != nil {
+		return types.Allowance{}, err
+	}
+
+	allowance.Owner = owner
+	allowance.Spender = spender
+	return allowance, nil
+}
+
 func (q *QueryHandler) query(ctx context.Context, tokenAddr string, query string, dest interface{}) error {
 	if dest == nil {
 		return nil
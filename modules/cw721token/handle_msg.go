@@ -0,0 +1,159 @@
+package cw721token
+
+import (
+	"encoding/json"
+	"strconv"
+
+	wasm "github.com/CosmWasm/wasmd/x/wasm/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/forbole/bdjuno/v2/database"
+	mutils "github.com/forbole/bdjuno/v2/modules/utils"
+	"github.com/forbole/bdjuno/v2/types"
+	"github.com/forbole/bdjuno/v2/utils"
+	juno "github.com/forbole/juno/v2/types"
+)
+
+func (m *Module) HandleMsg(index int, msg sdk.Msg, tx *juno.Tx) error {
+	if len(tx.Logs) == 0 {
+		return nil
+	}
+
+	return m.db.ExecuteTx(func(dbTx *database.DbTx) error {
+		switch cosmosMsg := msg.(type) {
+		case *wasm.MsgStoreCode:
+			return m.handleMsgStoreCode(dbTx, cosmosMsg, tx, index)
+		case *wasm.MsgInstantiateContract:
+			return m.handleMsgInstantiateContract(dbTx, cosmosMsg, tx, index)
+		case *wasm.MsgExecuteContract:
+			return m.handleMsgExecuteContract(dbTx, cosmosMsg, tx, index)
+		case *wasm.MsgMigrateContract:
+			return m.handleMsgMigrateContract(dbTx, cosmosMsg)
+		default:
+			return nil
+		}
+	})
+}
+
+func (m *Module) handleMsgStoreCode(dbTx *database.DbTx, msg *wasm.MsgStoreCode, tx *juno.Tx, index int) error {
+	if err := utils.ValidateContract(msg.WASMByteCode, utils.CW721); err != nil {
+		return nil
+	}
+
+	codeIDAttr := mutils.GetValueFromLogs(uint32(index), tx.Logs, wasm.EventTypeStoreCode, wasm.AttributeKeyCodeID)
+	codeID, err := strconv.ParseUint(codeIDAttr, 10, 64)
+	if err != nil {
+		return err
+	}
+
+	return dbTx.SaveCW721CodeID(codeID)
+}
+
+func (m *Module) handleMsgInstantiateContract(dbTx *database.DbTx, msg *wasm.MsgInstantiateContract, tx *juno.Tx, index int) error {
+	if found, err := dbTx.CW721CodeIDExists(msg.CodeID); !found {
+		return err
+	}
+
+	contractAddr := mutils.GetValueFromLogs(uint32(index), tx.Logs, wasm.EventTypeInstantiate, wasm.AttributeKeyContractAddr)
+	collection, err := m.source.CollectionInfo(contractAddr, tx.Height)
+	if err != nil {
+		return err
+	}
+	collection.CodeID = msg.CodeID
+	collection.Creator = msg.Sender
+
+	if err := dbTx.SaveCW721Collection(collection); err != nil {
+		return err
+	}
+
+	nfts, err := m.source.AllNfts(contractAddr, tx.Height)
+	if err != nil {
+		return err
+	}
+
+	return dbTx.SaveCW721Nfts(contractAddr, nfts)
+}
+
+func (m *Module) handleMsgExecuteContract(dbTx *database.DbTx, msg *wasm.MsgExecuteContract, tx *juno.Tx, index int) error {
+	if found, err := dbTx.CW721CollectionExists(msg.Contract); !found {
+		return err
+	}
+
+	msgExecute := types.CW721MsgExecute{}
+	if err := json.Unmarshal(msg.Msg, &msgExecute); err != nil {
+		return err
+	}
+
+	msgType := mutils.GetValueFromLogs(uint32(index), tx.Logs, wasm.WasmModuleEventType, sdk.AttributeKeyAction)
+	history := types.CW721History{Type: msgType, Sender: msg.Sender, Height: tx.Height, TxHash: tx.TxHash, MsgIndex: index}
+
+	switch types.TypeCW721MsgExecute(msgType) {
+	case types.TypeCW721TransferNft:
+		history.TokenID, history.To = msgExecute.TransferNft.TokenID, msgExecute.TransferNft.Recipient
+	case types.TypeCW721SendNft:
+		history.TokenID, history.To = msgExecute.SendNft.TokenID, msgExecute.SendNft.Contract
+	case types.TypeCW721Mint:
+		history.TokenID, history.To = msgExecute.Mint.TokenID, msgExecute.Mint.Owner
+	case types.TypeCW721Burn:
+		history.TokenID = msgExecute.Burn.TokenID
+	case types.TypeCW721Approve:
+		history.TokenID, history.To = msgExecute.Approve.TokenID, msgExecute.Approve.Spender
+	case types.TypeCW721Revoke:
+		history.TokenID, history.To = msgExecute.Revoke.TokenID, msgExecute.Revoke.Spender
+	case types.TypeCW721ApproveAll:
+		mm := msgExecute.ApproveAll
+		return dbTx.SaveCW721Operator(msg.Contract, types.CW721Operator{Owner: msg.Sender, Operator: mm.Operator, Expires: mm.Expires})
+	case types.TypeCW721RevokeAll:
+		return dbTx.DeleteCW721Operator(msg.Contract, msg.Sender, msgExecute.RevokeAll.Operator)
+	default:
+		return nil
+	}
+
+	owner, err := dbTx.GetCW721NftOwner(msg.Contract, history.TokenID)
+	if err != nil {
+		return err
+	}
+	history.From = owner
+
+	if err := dbTx.SaveCW721History(msg.Contract, history); err != nil {
+		return err
+	}
+
+	if types.TypeCW721MsgExecute(msgType) == types.TypeCW721Burn {
+		if err := dbTx.BurnCW721Nft(msg.Contract, history.TokenID); err != nil {
+			return err
+		}
+	} else {
+		nft, err := m.source.NftInfo(msg.Contract, history.TokenID, tx.Height)
+		if err != nil {
+			return err
+		}
+
+		if err := dbTx.SaveCW721Nfts(msg.Contract, []types.CW721Nft{nft}); err != nil {
+			return err
+		}
+	}
+
+	numTokens, err := m.source.NumTokens(msg.Contract, tx.Height)
+	if err != nil {
+		return err
+	}
+
+	return dbTx.UpdateCW721NumTokens(msg.Contract, numTokens)
+}
+
+func (m *Module) handleMsgMigrateContract(dbTx *database.DbTx, msg *wasm.MsgMigrateContract) error {
+	if found, err := dbTx.CW721CollectionExists(msg.Contract); !found {
+		return err
+	}
+
+	found, err := dbTx.CW721CodeIDExists(msg.CodeID)
+	if err != nil {
+		return err
+	}
+
+	if !found {
+		return dbTx.DeleteCW721Collection(msg.Contract)
+	}
+
+	return dbTx.UpdateCW721CodeID(msg.Contract, msg.CodeID)
+}
@@ -0,0 +1,175 @@
+package cw721token
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	wasm "github.com/CosmWasm/wasmd/x/wasm/types"
+	"github.com/CudoVentures/cudos-node/simapp"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	source "github.com/forbole/bdjuno/v2/modules/cw721token/source/mock"
+	"github.com/forbole/bdjuno/v2/types"
+	"github.com/forbole/bdjuno/v2/utils"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCW721Token_HandleMsg(t *testing.T) {
+	for testName, tc := range map[string]struct {
+		arrange func(s *source.MockSource, txb *utils.MockTxBuilder) sdk.Msg
+	}{
+		"instantiate": {
+			arrange: func(s *source.MockSource, txb *utils.MockTxBuilder) sdk.Msg {
+				s.C.Address = collectionAddr2
+				txb.WithEventInstantiateContract(collectionAddr2)
+				return &wasm.MsgInstantiateContract{CodeID: s.C.CodeID, Sender: s.C.Creator}
+			},
+		},
+		"execute mint": {
+			arrange: func(s *source.MockSource, txb *utils.MockTxBuilder) sdk.Msg {
+				s.Mint(token3, addr2)
+				txb.WithEventWasmAction(string(types.TypeCW721Mint))
+				return mockMsgExecute(t, types.CW721MsgExecute{Mint: types.CW721MsgMint{TokenID: token3, Owner: addr2}})
+			},
+		},
+		"execute transfer_nft": {
+			arrange: func(s *source.MockSource, txb *utils.MockTxBuilder) sdk.Msg {
+				s.Transfer(token1, addr2)
+				txb.WithEventWasmAction(string(types.TypeCW721TransferNft))
+				return mockMsgExecute(t, types.CW721MsgExecute{TransferNft: types.CW721MsgTransferNft{Recipient: addr2, TokenID: token1}})
+			},
+		},
+		"execute send_nft": {
+			arrange: func(s *source.MockSource, txb *utils.MockTxBuilder) sdk.Msg {
+				s.Transfer(token1, collectionAddr2)
+				txb.WithEventWasmAction(string(types.TypeCW721SendNft))
+				return mockMsgExecute(t, types.CW721MsgExecute{SendNft: types.CW721MsgSendNft{Contract: collectionAddr2, TokenID: token1}})
+			},
+		},
+		"execute burn": {
+			arrange: func(s *source.MockSource, txb *utils.MockTxBuilder) sdk.Msg {
+				s.Burn(token1)
+				txb.WithEventWasmAction(string(types.TypeCW721Burn))
+				return mockMsgExecute(t, types.CW721MsgExecute{Burn: types.CW721MsgBurn{TokenID: token1}})
+			},
+		},
+		"execute approve": {
+			arrange: func(s *source.MockSource, txb *utils.MockTxBuilder) sdk.Msg {
+				s.Approve(token1, addr2)
+				txb.WithEventWasmAction(string(types.TypeCW721Approve))
+				return mockMsgExecute(t, types.CW721MsgExecute{Approve: types.CW721MsgApprove{Spender: addr2, TokenID: token1}})
+			},
+		},
+		"execute revoke": {
+			arrange: func(s *source.MockSource, txb *utils.MockTxBuilder) sdk.Msg {
+				s.Revoke(token1, addr2)
+				txb.WithEventWasmAction(string(types.TypeCW721Revoke))
+				return mockMsgExecute(t, types.CW721MsgExecute{Revoke: types.CW721MsgRevoke{Spender: addr2, TokenID: token1}})
+			},
+		},
+		"migrate": {
+			arrange: func(s *source.MockSource, txb *utils.MockTxBuilder) sdk.Msg {
+				return &wasm.MsgMigrateContract{Contract: s.C.Address, CodeID: s.C.CodeID}
+			},
+		},
+	} {
+		t.Run(testName, func(t *testing.T) {
+			db, err := utils.NewTestDb("cw721TokenTest_handleMsg")
+			require.NoError(t, err)
+
+			s := source.NewMockSource(mockCollection, mockNfts)
+
+			_, err = db.Sqlx.Exec(`INSERT INTO cw721token_code_id VALUES ($1)`, s.C.CodeID)
+			require.NoError(t, err)
+
+			_, err = db.Sqlx.Exec(
+				`INSERT INTO cw721token_collection VALUES ($1, $2, $3, $4, $5, $6, $7)`,
+				s.C.Address, s.C.CodeID, s.C.Name, s.C.Symbol, s.C.Minter, s.C.NumTokens, s.C.Creator,
+			)
+			require.NoError(t, err)
+
+			for _, n := range s.Nfts {
+				_, err = db.Sqlx.Exec(
+					`INSERT INTO cw721token_nft (collection, token_id, owner) VALUES ($1, $2, $3)`,
+					s.C.Address, n.TokenID, n.Owner,
+				)
+				require.NoError(t, err)
+			}
+
+			_, err = db.Sqlx.Exec(
+				`INSERT INTO cw721token_approval (collection, token_id, spender) VALUES ($1, $2, $3)`,
+				s.C.Address, token1, addr2,
+			)
+			require.NoError(t, err)
+			s.Approve(token1, addr2)
+
+			m := NewModule(simapp.MakeTestEncodingConfig().Marshaler, db, s)
+			txb := utils.NewMockTxBuilder(t, time.Time{}, "", num1)
+			msg := tc.arrange(s, txb)
+
+			err = m.HandleMsg(0, msg, txb.Build())
+			require.NoError(t, err)
+
+			var numTokens uint64
+			err = db.Sqlx.QueryRow(`SELECT num_tokens FROM cw721token_collection WHERE address = $1`, s.C.Address).Scan(&numTokens)
+			require.NoError(t, err)
+			require.Equal(t, s.C.NumTokens, numTokens)
+
+			for _, n := range s.Nfts {
+				var owner string
+				err = db.Sqlx.QueryRow(
+					`SELECT owner FROM cw721token_nft WHERE collection = $1 AND token_id = $2 AND NOT burned`,
+					s.C.Address, n.TokenID,
+				).Scan(&owner)
+				require.NoError(t, err)
+				require.Equal(t, n.Owner, owner)
+
+				var approvals int
+				err = db.Sqlx.QueryRow(
+					`SELECT COUNT(*) FROM cw721token_approval WHERE collection = $1 AND token_id = $2`,
+					s.C.Address, n.TokenID,
+				).Scan(&approvals)
+				require.NoError(t, err)
+				require.Equal(t, len(n.Approvals), approvals)
+			}
+		})
+	}
+}
+
+const (
+	addr1           = "cudos1"
+	addr2           = "cudos2"
+	collectionAddr1 = "cudos1cw7211"
+	collectionAddr2 = "cudos1cw7212"
+	token1          = "token1"
+	token2          = "token2"
+	token3          = "token3"
+	str1            = "str1"
+	num1            = 1
+)
+
+var mockCollection = types.CW721Collection{
+	Address: collectionAddr1,
+	Name:    str1,
+	Symbol:  str1,
+	Minter:  addr1,
+	CodeID:  num1,
+	Creator: addr1,
+}
+
+var mockNfts = []types.CW721Nft{
+	{TokenID: token1, Owner: addr1},
+	{TokenID: token2, Owner: addr2},
+}
+
+func mockMsgExecute(t *testing.T, msg types.CW721MsgExecute) *wasm.MsgExecuteContract {
+	msgJSON, err := json.Marshal(msg)
+	require.NoError(t, err)
+
+	return &wasm.MsgExecuteContract{
+		Contract: collectionAddr1,
+		Sender:   addr1,
+		Msg:      msgJSON,
+	}
+}
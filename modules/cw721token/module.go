@@ -0,0 +1,32 @@
+package cw721token
+
+import (
+	"github.com/cosmos/cosmos-sdk/codec"
+	"github.com/forbole/juno/v2/modules"
+
+	"github.com/forbole/bdjuno/v2/database"
+	"github.com/forbole/bdjuno/v2/modules/cw721token/source"
+)
+
+var (
+	_ modules.Module        = &Module{}
+	_ modules.MessageModule = &Module{}
+)
+
+type Module struct {
+	cdc    codec.Codec
+	db     *database.Db
+	source source.Source
+}
+
+func NewModule(cdc codec.Codec, db *database.Db, source source.Source) *Module {
+	return &Module{
+		cdc:    cdc,
+		db:     db,
+		source: source,
+	}
+}
+
+func (m *Module) Name() string {
+	return "cw721token"
+}
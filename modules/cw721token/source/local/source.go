@@ -0,0 +1,62 @@
+package local
+
+import (
+	wasm "github.com/CosmWasm/wasmd/x/wasm/types"
+
+	"github.com/forbole/bdjuno/v2/modules/cw721token/source"
+	q "github.com/forbole/bdjuno/v2/modules/cw721token/source/queryhandler"
+	"github.com/forbole/bdjuno/v2/types"
+	"github.com/forbole/juno/v2/node/local"
+)
+
+var (
+	_ source.Source = &Source{}
+)
+
+type Source struct {
+	*local.Source
+	q *q.QueryHandler
+}
+
+func NewSource(source *local.Source, querier wasm.QueryServer) *Source {
+	return &Source{
+		Source: source,
+		q:      q.FromLocal(querier.SmartContractState),
+	}
+}
+
+func (s *Source) CollectionInfo(contractAddr string, height int64) (types.CW721Collection, error) {
+	ctx, err := s.LoadHeight(height)
+	if err != nil {
+		return types.CW721Collection{}, err
+	}
+
+	return s.q.CollectionInfo(ctx, contractAddr, height)
+}
+
+func (s *Source) AllNfts(contractAddr string, height int64) ([]types.CW721Nft, error) {
+	ctx, err := s.LoadHeight(height)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.q.AllNfts(ctx, contractAddr, height)
+}
+
+func (s *Source) NftInfo(contractAddr string, tokenID string, height int64) (types.CW721Nft, error) {
+	ctx, err := s.LoadHeight(height)
+	if err != nil {
+		return types.CW721Nft{}, err
+	}
+
+	return s.q.NftInfo(ctx, contractAddr, tokenID, height)
+}
+
+func (s *Source) NumTokens(contractAddr string, height int64) (uint64, error) {
+	ctx, err := s.LoadHeight(height)
+	if err != nil {
+		return 0, err
+	}
+
+	return s.q.NumTokens(ctx, contractAddr, height)
+}
@@ -0,0 +1,91 @@
+package source
+
+import (
+	"fmt"
+
+	"github.com/forbole/bdjuno/v2/modules/cw721token/source"
+	"github.com/forbole/bdjuno/v2/types"
+)
+
+var (
+	_ source.Source = &MockSource{}
+)
+
+type MockSource struct {
+	C    types.CW721Collection
+	Nfts []types.CW721Nft
+}
+
+func NewMockSource(collection types.CW721Collection, nfts []types.CW721Nft) *MockSource {
+	nftsCopy := []types.CW721Nft{}
+	for _, n := range nfts {
+		n.Approvals = append([]types.CW721Approval{}, n.Approvals...)
+		nftsCopy = append(nftsCopy, n)
+	}
+
+	collection.NumTokens = uint64(len(nftsCopy))
+	return &MockSource{collection, nftsCopy}
+}
+
+func (s *MockSource) CollectionInfo(contractAddr string, height int64) (types.CW721Collection, error) {
+	return s.C, nil
+}
+
+func (s *MockSource) AllNfts(contractAddr string, height int64) ([]types.CW721Nft, error) {
+	return s.Nfts, nil
+}
+
+func (s *MockSource) NftInfo(contractAddr string, tokenID string, height int64) (types.CW721Nft, error) {
+	i := s.getNftIndex(tokenID)
+	if i == -1 {
+		return types.CW721Nft{}, fmt.Errorf("cw721_base::state::TokenInfo<core::option::Option<cosmwasm_std::results::empty::Empty>> not found")
+	}
+
+	return s.Nfts[i], nil
+}
+
+func (s *MockSource) NumTokens(contractAddr string, height int64) (uint64, error) {
+	return s.C.NumTokens, nil
+}
+
+func (s *MockSource) getNftIndex(tokenID string) int {
+	for i, n := range s.Nfts {
+		if n.TokenID == tokenID {
+			return i
+		}
+	}
+
+	return -1
+}
+
+func (s *MockSource) Mint(tokenID string, owner string) {
+	s.Nfts = append(s.Nfts, types.CW721Nft{TokenID: tokenID, Owner: owner, Approvals: []types.CW721Approval{}})
+	s.C.NumTokens++
+}
+
+func (s *MockSource) Transfer(tokenID string, recipient string) {
+	i := s.getNftIndex(tokenID)
+	s.Nfts[i].Owner = recipient
+	s.Nfts[i].Approvals = []types.CW721Approval{}
+}
+
+func (s *MockSource) Burn(tokenID string) {
+	i := s.getNftIndex(tokenID)
+	s.Nfts = append(s.Nfts[:i], s.Nfts[i+1:]...)
+	s.C.NumTokens--
+}
+
+func (s *MockSource) Approve(tokenID string, spender string) {
+	i := s.getNftIndex(tokenID)
+	s.Nfts[i].Approvals = append(s.Nfts[i].Approvals, types.CW721Approval{Spender: spender, Expires: []byte(`{"never":{}}`)})
+}
+
+func (s *MockSource) Revoke(tokenID string, spender string) {
+	i := s.getNftIndex(tokenID)
+	for j, a := range s.Nfts[i].Approvals {
+		if a.Spender == spender {
+			s.Nfts[i].Approvals = append(s.Nfts[i].Approvals[:j], s.Nfts[i].Approvals[j+1:]...)
+			return
+		}
+	}
+}
@@ -0,0 +1,159 @@
+package queryhandler
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	wasm "github.com/CosmWasm/wasmd/x/wasm/types"
+	"google.golang.org/grpc"
+
+	"github.com/forbole/bdjuno/v2/types"
+)
+
+type queryFn func(ctx context.Context, in *wasm.QuerySmartContractStateRequest, opts ...grpc.CallOption) (*wasm.QuerySmartContractStateResponse, error)
+
+type queryFnLocal func(context.Context, *wasm.QuerySmartContractStateRequest) (*wasm.QuerySmartContractStateResponse, error)
+
+// allTokensQuery represents the smart query used to list the tokens of a collection
+type allTokensQuery struct {
+	AllTokens struct {
+		Limit      int    `json:"limit"`
+		StartAfter string `json:"start_after,omitempty"`
+	} `json:"all_tokens"`
+}
+
+// allNftInfoQuery represents the smart query used to get the details of a single token
+type allNftInfoQuery struct {
+	AllNftInfo struct {
+		TokenID string `json:"token_id"`
+	} `json:"all_nft_info"`
+}
+
+type QueryHandler struct {
+	Query queryFn
+}
+
+func FromLocal(q queryFnLocal) *QueryHandler {
+	queryFn := func(ctx context.Context, in *wasm.QuerySmartContractStateRequest, opts ...grpc.CallOption) (*wasm.QuerySmartContractStateResponse, error) {
+		return q(ctx, in)
+	}
+
+	return &QueryHandler{queryFn}
+}
+
+func (q *QueryHandler) CollectionInfo(ctx context.Context, contractAddr string, height int64) (types.CW721Collection, error) {
+	collection := types.CW721Collection{}
+
+	if err := q.query(ctx, contractAddr, json.RawMessage(`{"contract_info":{}}`), &collection); err != nil {
+		return types.CW721Collection{}, err
+	}
+
+	if err := q.query(ctx, contractAddr, json.RawMessage(`{"minter":{}}`), &collection); err != nil {
+		return types.CW721Collection{}, fmt.Errorf("error while querying minter: %s", err)
+	}
+
+	numTokens, err := q.NumTokens(ctx, contractAddr, height)
+	if err != nil {
+		return types.CW721Collection{}, err
+	}
+
+	collection.Address = contractAddr
+	collection.NumTokens = numTokens
+	return collection, nil
+}
+
+func (q *QueryHandler) AllNfts(ctx context.Context, contractAddr string, height int64) ([]types.CW721Nft, error) {
+	nfts := []types.CW721Nft{}
+
+	for {
+		var query allTokensQuery
+		query.AllTokens.Limit = 30
+		if len(nfts) > 0 {
+			query.AllTokens.StartAfter = nfts[len(nfts)-1].TokenID
+		}
+
+		tokens := struct {
+			Tokens []string `json:"tokens"`
+		}{}
+
+		if err := q.query(ctx, contractAddr, query, &tokens); err != nil {
+			return nil, err
+		}
+
+		if len(tokens.Tokens) == 0 {
+			break
+		}
+
+		for _, t := range tokens.Tokens {
+			nft, err := q.NftInfo(ctx, contractAddr, t, height)
+			if err != nil {
+				return nil, err
+			}
+
+			nfts = append(nfts, nft)
+		}
+	}
+
+	return nfts, nil
+}
+
+func (q *QueryHandler) NftInfo(ctx context.Context, contractAddr string, tokenID string, height int64) (types.CW721Nft, error) {
+	info := struct {
+		Access struct {
+			Owner     string                `json:"owner"`
+			Approvals []types.CW721Approval `json:"approvals"`
+		} `json:"access"`
+		Info struct {
+			TokenURI  string          `json:"token_uri"`
+			Extension json.RawMessage `json:"extension"`
+		} `json:"info"`
+	}{}
+
+	var query allNftInfoQuery
+	query.AllNftInfo.TokenID = tokenID
+	if err := q.query(ctx, contractAddr, query, &info); err != nil {
+		return types.CW721Nft{}, err
+	}
+
+	return types.CW721Nft{
+		TokenID:   tokenID,
+		Owner:     info.Access.Owner,
+		TokenURI:  info.Info.TokenURI,
+		Extension: info.Info.Extension,
+		Approvals: info.Access.Approvals,
+	}, nil
+}
+
+func (q *QueryHandler) NumTokens(ctx context.Context, contractAddr string, height int64) (uint64, error) {
+	numTokens := struct {
+		Count uint64 `json:"count"`
+	}{}
+
+	err := q.query(ctx, contractAddr, json.RawMessage(`{"num_tokens":{}}`), &numTokens)
+
+	return numTokens.Count, err
+}
+
+func (q *QueryHandler) query(ctx context.Context, contractAddr string, query interface{}, dest interface{}) error {
+	if dest == nil {
+		return nil
+	}
+
+	queryData, err := json.Marshal(query)
+	if err != nil {
+		return fmt.Errorf("error while marshaling query: %s", err)
+	}
+
+	req := &wasm.QuerySmartContractStateRequest{
+		Address:   contractAddr,
+		QueryData: queryData,
+	}
+
+	res, err := q.Query(ctx, req)
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(res.Data, dest)
+}
@@ -0,0 +1,46 @@
+package remote
+
+import (
+	wasm "github.com/CosmWasm/wasmd/x/wasm/types"
+	"github.com/forbole/juno/v2/node/remote"
+
+	"github.com/forbole/bdjuno/v2/modules/cw721token/source"
+	q "github.com/forbole/bdjuno/v2/modules/cw721token/source/queryhandler"
+	"github.com/forbole/bdjuno/v2/types"
+)
+
+var (
+	_ source.Source = &Source{}
+)
+
+type Source struct {
+	*remote.Source
+	q *q.QueryHandler
+}
+
+func NewSource(source *remote.Source, querier wasm.QueryClient) *Source {
+	return &Source{
+		Source: source,
+		q:      &q.QueryHandler{Query: querier.SmartContractState},
+	}
+}
+
+func (s *Source) CollectionInfo(contractAddr string, height int64) (types.CW721Collection, error) {
+	ctx := remote.GetHeightRequestContext(s.Ctx, height)
+	return s.q.CollectionInfo(ctx, contractAddr, height)
+}
+
+func (s *Source) AllNfts(contractAddr string, height int64) ([]types.CW721Nft, error) {
+	ctx := remote.GetHeightRequestContext(s.Ctx, height)
+	return s.q.AllNfts(ctx, contractAddr, height)
+}
+
+func (s *Source) NftInfo(contractAddr string, tokenID string, height int64) (types.CW721Nft, error) {
+	ctx := remote.GetHeightRequestContext(s.Ctx, height)
+	return s.q.NftInfo(ctx, contractAddr, tokenID, height)
+}
+
+func (s *Source) NumTokens(contractAddr string, height int64) (uint64, error) {
+	ctx := remote.GetHeightRequestContext(s.Ctx, height)
+	return s.q.NumTokens(ctx, contractAddr, height)
+}
@@ -0,0 +1,12 @@
+package source
+
+import (
+	"github.com/forbole/bdjuno/v2/types"
+)
+
+type Source interface {
+	CollectionInfo(contractAddr string, height int64) (types.CW721Collection, error)
+	AllNfts(contractAddr string, height int64) ([]types.CW721Nft, error)
+	NftInfo(contractAddr string, tokenID string, height int64) (types.CW721Nft, error)
+	NumTokens(contractAddr string, height int64) (uint64, error)
+}
@@ -1,6 +1,8 @@
 package gov
 
 import (
+	"time"
+
 	stakingtypes "github.com/cosmos/cosmos-sdk/x/staking/types"
 	tmctypes "github.com/tendermint/tendermint/rpc/core/types"
 
@@ -26,3 +28,8 @@ type StakingModule interface {
 	GetValidatorsStatuses(height int64, validators []stakingtypes.Validator) ([]types.ValidatorStatus, error)
 	UpdateParams(height int64) error
 }
+
+type AlertModule interface {
+	NotifyProposalSubmitted(height int64, timestamp time.Time, proposal types.Proposal)
+	NotifyProposalStatusChanged(height int64, timestamp time.Time, proposalID uint64, oldStatus string, newStatus string)
+}
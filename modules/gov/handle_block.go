@@ -2,6 +2,7 @@ package gov
 
 import (
 	"fmt"
+	"time"
 
 	juno "github.com/forbole/juno/v2/types"
 
@@ -14,7 +15,7 @@ import (
 func (m *Module) HandleBlock(
 	b *tmctypes.ResultBlock, _ *tmctypes.ResultBlockResults, _ []*juno.Tx, vals *tmctypes.ResultValidators,
 ) error {
-	err := m.updateProposals(b.Block.Height, vals)
+	err := m.updateProposals(b.Block.Height, b.Block.Time, vals)
 	if err != nil {
 		log.Error().Str("module", "gov").Int64("height", b.Block.Height).
 			Err(err).Msg("error while updating proposals")
@@ -23,14 +24,14 @@ func (m *Module) HandleBlock(
 }
 
 // updateProposals updates the proposals
-func (m *Module) updateProposals(height int64, blockVals *tmctypes.ResultValidators) error {
+func (m *Module) updateProposals(height int64, timestamp time.Time, blockVals *tmctypes.ResultValidators) error {
 	ids, err := m.db.GetOpenProposalsIds()
 	if err != nil {
 		log.Error().Err(err).Str("module", "gov").Msg("error while getting open ids")
 	}
 
 	for _, id := range ids {
-		err = m.UpdateProposal(height, blockVals, id)
+		err = m.UpdateProposal(height, timestamp, blockVals, id)
 		if err != nil {
 			return fmt.Errorf("error while updating proposal: %s", err)
 		}
@@ -2,6 +2,7 @@ package gov
 
 import (
 	"fmt"
+	"time"
 
 	"strconv"
 
@@ -84,6 +85,13 @@ func (m *Module) handleMsgSubmitProposal(tx *juno.Tx, index int, msg *govtypes.M
 		return err
 	}
 
+	timestamp, err := time.Parse(time.RFC3339, tx.Timestamp)
+	if err != nil {
+		return fmt.Errorf("error while parsing time: %s", err)
+	}
+
+	m.alertModule.NotifyProposalSubmitted(tx.Height, timestamp, proposalObj)
+
 	// Store the deposit
 	deposit := types.NewDeposit(proposal.ProposalId, msg.Proposer, msg.InitialDeposit, tx.Height)
 	return m.db.SaveDeposits([]types.Deposit{deposit})
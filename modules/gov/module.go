@@ -28,6 +28,7 @@ type Module struct {
 	distrModule                DistrModule
 	slashingModule             SlashingModule
 	stakingModule              StakingModule
+	alertModule                AlertModule
 	proposalNotFoundCount      map[uint64]int
 	proposalNotFoundCountMutex sync.Mutex
 }
@@ -39,6 +40,7 @@ func NewModule(
 	distrModule DistrModule,
 	slashingModule SlashingModule,
 	stakingModule StakingModule,
+	alertModule AlertModule,
 	cdc codec.Codec,
 	db *database.Db,
 ) *Module {
@@ -49,6 +51,7 @@ func NewModule(
 		distrModule:           distrModule,
 		slashingModule:        slashingModule,
 		stakingModule:         stakingModule,
+		alertModule:           alertModule,
 		db:                    db,
 		proposalNotFoundCount: make(map[uint64]int),
 	}
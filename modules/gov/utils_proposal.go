@@ -3,6 +3,7 @@ package gov
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	proposaltypes "github.com/cosmos/cosmos-sdk/x/params/types/proposal"
 	slashingtypes "github.com/cosmos/cosmos-sdk/x/slashing/types"
@@ -18,7 +19,9 @@ import (
 	govtypes "github.com/cosmos/cosmos-sdk/x/gov/types"
 )
 
-func (m *Module) UpdateProposal(height int64, blockVals *tmctypes.ResultValidators, id uint64) error {
+func (m *Module) UpdateProposal(
+	height int64, timestamp time.Time, blockVals *tmctypes.ResultValidators, id uint64,
+) error {
 	// Get the proposal
 	proposal, err := m.source.Proposal(height, id)
 	if err != nil {
@@ -45,7 +48,7 @@ func (m *Module) UpdateProposal(height int64, blockVals *tmctypes.ResultValidato
 		return fmt.Errorf("error while updating params from ParamChangeProposal: %s", err)
 	}
 
-	err = m.updateProposalStatus(proposal)
+	err = m.updateProposalStatus(height, timestamp, proposal)
 	if err != nil {
 		return fmt.Errorf("error while updating proposal status: %s", err)
 	}
@@ -136,9 +139,14 @@ func (m *Module) handleParamChangeProposal(height int64, proposal govtypes.Propo
 	return nil
 }
 
-// updateProposalStatus updates the given proposal status
-func (m *Module) updateProposalStatus(proposal govtypes.Proposal) error {
-	return m.db.UpdateProposal(
+// updateProposalStatus updates the given proposal status, notifying the alert module when it changes
+func (m *Module) updateProposalStatus(height int64, timestamp time.Time, proposal govtypes.Proposal) error {
+	oldStatus, err := m.db.GetProposalStatus(proposal.ProposalId)
+	if err != nil {
+		return fmt.Errorf("error while getting proposal status: %s", err)
+	}
+
+	err = m.db.UpdateProposal(
 		types.NewProposalUpdate(
 			proposal.ProposalId,
 			proposal.Status.String(),
@@ -146,6 +154,12 @@ func (m *Module) updateProposalStatus(proposal govtypes.Proposal) error {
 			proposal.VotingEndTime,
 		),
 	)
+	if err != nil {
+		return err
+	}
+
+	m.alertModule.NotifyProposalStatusChanged(height, timestamp, proposal.ProposalId, oldStatus, proposal.Status.String())
+	return nil
 }
 
 // updateProposalTallyResult updates the tally result associated with the given proposal
@@ -0,0 +1,50 @@
+package gravity
+
+import (
+	"fmt"
+
+	gravityTypes "github.com/althea-net/cosmos-gravity-bridge/module/x/gravity/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	stakingtypes "github.com/cosmos/cosmos-sdk/x/staking/types"
+)
+
+// getBondedValidatorsPower returns the consensus power of the bonded validators at the given height, by operator address
+func (m *Module) getBondedValidatorsPower(height int64) (map[string]int64, error) {
+	validators, err := m.stakingSource.GetValidatorsWithStatus(height, stakingtypes.BondStatusBonded)
+	if err != nil {
+		return nil, fmt.Errorf("error while getting bonded validators: %s", err)
+	}
+
+	powers := make(map[string]int64, len(validators))
+	for _, validator := range validators {
+		powers[validator.OperatorAddress] = validator.GetConsensusPower(sdk.DefaultPowerReduction)
+	}
+	return powers, nil
+}
+
+// getTotalPower returns the power attestations are weighted against at the given height, which is
+// the sum of the power of the bonded gravity validators
+func (m *Module) getTotalPower(height int64, powers map[string]int64) (int64, error) {
+	validators, err := m.db.GetGravityValidators(height)
+	if err != nil {
+		return 0, fmt.Errorf("error while getting gravity validators: %s", err)
+	}
+
+	var totalPower int64
+	for _, validator := range validators {
+		totalPower += powers[validator]
+	}
+	return totalPower, nil
+}
+
+// isConsensusReached tells whether the given attestation power reaches the share of the total power the chain
+// requires to observe an attestation. Attestations without power are never observed, as that can only happen
+// when none of their voters is known to be a bonded gravity validator
+func isConsensusReached(attestationPower, totalPower int64) bool {
+	if attestationPower <= 0 || totalPower <= 0 {
+		return false
+	}
+
+	requiredPower := gravityTypes.AttestationVotesPowerThreshold.Mul(sdk.NewInt(totalPower)).Quo(sdk.NewInt(100))
+	return sdk.NewInt(attestationPower).GTE(requiredPower)
+}
@@ -0,0 +1,71 @@
+package gravity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_isAttestationConsensusReached(t *testing.T) {
+	type input struct {
+		attestationPower int64
+		totalPower       int64
+		result           bool
+	}
+	inputs := []input{
+		{
+			attestationPower: 1,
+			totalPower:       10,
+			result:           false,
+		},
+		{
+			attestationPower: 1,
+			totalPower:       1,
+			result:           true,
+		},
+		{
+			attestationPower: 0,
+			totalPower:       1,
+			result:           false,
+		},
+		{
+			attestationPower: 0,
+			totalPower:       0,
+			result:           false,
+		},
+		{
+			attestationPower: 6,
+			totalPower:       10,
+			result:           true,
+		},
+		{
+			attestationPower: 5,
+			totalPower:       10,
+			result:           false,
+		},
+		{
+			attestationPower: 65,
+			totalPower:       100,
+			result:           false,
+		},
+		{
+			attestationPower: 66,
+			totalPower:       100,
+			result:           true,
+		},
+		{
+			attestationPower: 1000,
+			totalPower:       1520,
+			result:           false,
+		},
+		{
+			attestationPower: 1000,
+			totalPower:       1516,
+			result:           true,
+		},
+	}
+
+	for i := 0; i < len(inputs); i++ {
+		require.Equal(t, inputs[i].result, isConsensusReached(inputs[i].attestationPower, inputs[i].totalPower), "case %d", i)
+	}
+}
@@ -0,0 +1,79 @@
+package gravity
+
+import (
+	"fmt"
+	"strconv"
+
+	gravityTypes "github.com/althea-net/cosmos-gravity-bridge/module/x/gravity/types"
+	juno "github.com/forbole/juno/v2/types"
+	"github.com/rs/zerolog/log"
+	abci "github.com/tendermint/tendermint/abci/types"
+	tmctypes "github.com/tendermint/tendermint/rpc/core/types"
+
+	"github.com/forbole/bdjuno/v2/types"
+)
+
+// HandleBlock implements BlockModule
+func (m *Module) HandleBlock(
+	block *tmctypes.ResultBlock, res *tmctypes.ResultBlockResults, _ []*juno.Tx, _ *tmctypes.ResultValidators,
+) error {
+	// Batches created automatically by the BeginBlocker
+	for _, event := range juno.FindEventsByType(res.BeginBlockEvents, gravityTypes.EventTypeOutgoingBatch) {
+		nonce, err := getBatchNonceFromEvent(event)
+		if err != nil {
+			return fmt.Errorf("error while getting outgoing batch nonce: %s", err)
+		}
+
+		if err := m.saveBatchFromChain(block.Block.Height, nonce); err != nil {
+			return err
+		}
+	}
+
+	// Batches timed out or superseded by an executed one inside the EndBlocker
+	for _, event := range juno.FindEventsByType(res.EndBlockEvents, gravityTypes.EventTypeOutgoingBatchCanceled) {
+		nonce, err := getBatchNonceFromEvent(event)
+		if err != nil {
+			return fmt.Errorf("error while getting canceled batch nonce: %s", err)
+		}
+
+		if err := m.db.CancelGravityBatch(nonce); err != nil {
+			return fmt.Errorf("error while cancelling gravity batch %d: %s", nonce, err)
+		}
+	}
+
+	return nil
+}
+
+// saveBatchFromChain queries the batch with the given nonce as it is stored on chain at the given height, and saves it
+func (m *Module) saveBatchFromChain(height int64, nonce uint64) error {
+	batches, err := m.source.GetOutgoingTxBatches(height)
+	if err != nil {
+		return fmt.Errorf("error while getting outgoing batches: %s", err)
+	}
+
+	for _, batch := range batches {
+		if batch.BatchNonce != nonce {
+			continue
+		}
+
+		denom, err := m.source.GetERC20Denom(height, batch.TokenContract)
+		if err != nil {
+			return fmt.Errorf("error while getting denom of token %s: %s", batch.TokenContract, err)
+		}
+
+		return m.db.SaveGravityBatchFromChain(types.NewGravityBatch(batch, denom, height))
+	}
+
+	log.Warn().Str("module", "gravity").Int64("height", height).Uint64("nonce", nonce).
+		Msg("gravity batch not found on chain")
+	return nil
+}
+
+func getBatchNonceFromEvent(event abci.Event) (uint64, error) {
+	attribute, err := juno.FindAttributeByKey(event, gravityTypes.AttributeKeyOutgoingBatchID)
+	if err != nil {
+		return 0, err
+	}
+
+	return strconv.ParseUint(string(attribute.Value), 10, 64)
+}
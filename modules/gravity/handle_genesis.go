@@ -5,8 +5,11 @@ import (
 	"fmt"
 
 	gravityTypes "github.com/althea-net/cosmos-gravity-bridge/module/x/gravity/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/rs/zerolog/log"
 	tmtypes "github.com/tendermint/tendermint/types"
+
+	"github.com/forbole/bdjuno/v2/types"
 )
 
 func (m *Module) HandleGenesis(doc *tmtypes.GenesisDoc, _ map[string]json.RawMessage) error {
@@ -14,7 +17,8 @@ func (m *Module) HandleGenesis(doc *tmtypes.GenesisDoc, _ map[string]json.RawMes
 
 	type genesis struct {
 		Gravity struct {
-			DelegateKeys []*gravityTypes.MsgSetOrchestratorAddress `json:"delegate_keys,omitempty"`
+			DelegateKeys         []*gravityTypes.MsgSetOrchestratorAddress `json:"delegate_keys,omitempty"`
+			StaticValCosmosAddrs []string                                  `json:"static_val_cosmos_addrs,omitempty"`
 		} `json:"gravity"`
 	}
 
@@ -24,10 +28,30 @@ func (m *Module) HandleGenesis(doc *tmtypes.GenesisDoc, _ map[string]json.RawMes
 	}
 
 	for _, delegateKey := range state.Gravity.DelegateKeys {
-		if err := m.db.SaveOrchestrator(delegateKey.Orchestrator); err != nil {
+		err := m.db.SaveOrchestrator(types.GravityOrchestrator{
+			Address:          delegateKey.Orchestrator,
+			ValidatorAddress: delegateKey.Validator,
+			EthAddress:       delegateKey.EthAddress,
+			Height:           doc.InitialHeight,
+		})
+		if err != nil {
 			return fmt.Errorf("saving orchestrator failed: %v", err)
 		}
 	}
 
+	// Static validators are stored by their cosmos address, while they are referred to by their operator address
+	staticValidators := make([]string, len(state.Gravity.StaticValCosmosAddrs))
+	for i, cosmosAddr := range state.Gravity.StaticValCosmosAddrs {
+		accAddr, err := sdk.AccAddressFromBech32(cosmosAddr)
+		if err != nil {
+			return fmt.Errorf("invalid static validator address %s: %v", cosmosAddr, err)
+		}
+		staticValidators[i] = sdk.ValAddress(accAddr).String()
+	}
+
+	if err := m.db.SaveStaticValidators(staticValidators); err != nil {
+		return fmt.Errorf("saving static validators failed: %v", err)
+	}
+
 	return nil
 }
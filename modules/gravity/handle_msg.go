@@ -7,54 +7,193 @@ import (
 	gravityTypes "github.com/althea-net/cosmos-gravity-bridge/module/x/gravity/types"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/forbole/bdjuno/v2/modules/utils"
+	"github.com/forbole/bdjuno/v2/types"
 	juno "github.com/forbole/juno/v2/types"
 )
 
-const requiredConsensusPercent = 60
-
 func (m *Module) HandleMsg(index int, msg sdk.Msg, tx *juno.Tx) error {
 	if len(tx.Logs) == 0 {
 		return nil
 	}
 
-	if cosmosMsg, ok := msg.(*gravityTypes.MsgSendToCosmosClaim); ok {
+	switch cosmosMsg := msg.(type) {
+	case *gravityTypes.MsgSetOrchestratorAddress:
+		return m.handleMsgSetOrchestratorAddress(cosmosMsg, tx)
+	case *gravityTypes.MsgSendToCosmosClaim:
 		return m.handleMsgSendToCosmosClaim(index, cosmosMsg, tx)
+	case *gravityTypes.MsgSendToEth:
+		return m.handleMsgSendToEth(index, cosmosMsg, tx)
+	case *gravityTypes.MsgCancelSendToEth:
+		return m.handleMsgCancelSendToEth(cosmosMsg, tx)
+	case *gravityTypes.MsgRequestBatch:
+		return m.handleMsgRequestBatch(index, cosmosMsg, tx)
+	case *gravityTypes.MsgConfirmBatch:
+		return m.handleMsgConfirmBatch(cosmosMsg, tx)
+	case *gravityTypes.MsgBatchSendToEthClaim:
+		return m.handleMsgBatchSendToEthClaim(index, cosmosMsg, tx)
+	case *gravityTypes.MsgValsetConfirm:
+		return m.handleMsgValsetConfirm(cosmosMsg, tx)
+	case *gravityTypes.MsgValsetUpdatedClaim:
+		return m.handleMsgValsetUpdatedClaim(index, cosmosMsg, tx)
 	}
 	return nil
 }
 
 func (m *Module) handleMsgSendToCosmosClaim(index int, msg *gravityTypes.MsgSendToCosmosClaim, tx *juno.Tx) error {
+	_, _, err := m.handleClaim(index, msg, msg.Type(), msg.CosmosReceiver, msg.Orchestrator, tx)
+	return err
+}
+
+func (m *Module) handleMsgBatchSendToEthClaim(index int, msg *gravityTypes.MsgBatchSendToEthClaim, tx *juno.Tx) error {
+	_, consensus, err := m.handleClaim(index, msg, msg.Type(), "", msg.Orchestrator, tx)
+	if err != nil || !consensus {
+		return err
+	}
+
+	// Batches that have not been indexed are read from the chain before the claim removes them from the state
+	exists, err := m.db.HasGravityBatch(msg.BatchNonce)
+	if err != nil {
+		return fmt.Errorf("failed to check gravity batch %d: %v", msg.BatchNonce, err)
+	}
+
+	if !exists {
+		if err := m.saveBatchFromChain(tx.Height-1, msg.BatchNonce); err != nil {
+			return err
+		}
+	}
+
+	if err := m.db.SetGravityBatchExecuted(msg.BatchNonce, msg.TokenContract, tx.Height); err != nil {
+		return fmt.Errorf("failed to set gravity batch %d as executed: %v", msg.BatchNonce, err)
+	}
+
+	return nil
+}
+
+func (m *Module) handleMsgValsetUpdatedClaim(index int, msg *gravityTypes.MsgValsetUpdatedClaim, tx *juno.Tx) error {
+	attestationID, _, err := m.handleClaim(index, msg, msg.Type(), "", msg.Orchestrator, tx)
+	if err != nil {
+		return err
+	}
+
+	rewardAmount := "0"
+	if !msg.RewardAmount.IsNil() {
+		rewardAmount = msg.RewardAmount.String()
+	}
+
+	return m.db.SaveGravityValsetUpdate(types.GravityValsetUpdate{
+		ValsetNonce:    msg.ValsetNonce,
+		AttestationID:  attestationID,
+		Members:        msg.Members,
+		RewardAmount:   rewardAmount,
+		RewardToken:    msg.RewardToken,
+		EthBlockHeight: msg.BlockHeight,
+		Height:         tx.Height,
+		TxHash:         tx.TxHash,
+	})
+}
+
+func (m *Module) handleMsgValsetConfirm(msg *gravityTypes.MsgValsetConfirm, tx *juno.Tx) error {
+	return m.db.SaveGravityValsetConfirm(msg.Nonce, msg.Orchestrator, msg.EthAddress, tx.Height, tx.TxHash)
+}
+
+// handleClaim stores the vote of the given orchestrator over the attestation the claim belongs to,
+// and returns the attestation id along with whether the attestation has reached consensus
+func (m *Module) handleClaim(index int, msg sdk.Msg, msgType, receiver, orchestrator string, tx *juno.Tx) (string, bool, error) {
 	attestationID := utils.GetValueFromLogs(uint32(index), tx.Logs, sdk.EventTypeMessage, gravityTypes.AttributeKeyAttestationID)
 	if len(attestationID) == 0 {
-		return fmt.Errorf("attestation id not found: %+v", msg)
+		return "", false, fmt.Errorf("attestation id not found: %+v", msg)
 	}
 
 	attestationID = strconv.QuoteToASCII(attestationID)
 
-	if err := m.db.SaveMsgSendToCosmosClaim(tx.TxHash, msg.Type(), attestationID, msg.CosmosReceiver, msg.Orchestrator, tx.Height); err != nil {
-		return fmt.Errorf("failed to save send to cosmos claim %+v: %v", msg, err)
+	powers, err := m.getBondedValidatorsPower(tx.Height)
+	if err != nil {
+		return "", false, err
+	}
+
+	validator, err := m.db.GetOrchestratorValidator(orchestrator, tx.Height)
+	if err != nil {
+		return "", false, fmt.Errorf("failed to get orchestrator validator: %v", err)
 	}
 
-	votes, err := m.db.GetGravityTransactionVotes(attestationID)
+	err = m.db.SaveGravityClaim(msgType, receiver, types.GravityAttestationVote{
+		AttestationID:    attestationID,
+		Orchestrator:     orchestrator,
+		ValidatorAddress: validator,
+		Power:            powers[validator],
+		Height:           tx.Height,
+		TxHash:           tx.TxHash,
+	})
 	if err != nil {
-		return fmt.Errorf("failed to get gravity transaction votes: %v", err)
+		return "", false, fmt.Errorf("failed to save gravity claim %+v: %v", msg, err)
 	}
 
-	orchestratorCount, err := m.db.GetOrchestratorsCount()
+	attestationPower, err := m.db.GetGravityTransactionPower(attestationID)
 	if err != nil {
-		return fmt.Errorf("failed to get orchestrators count: %v", err)
+		return "", false, fmt.Errorf("failed to get gravity transaction power: %v", err)
 	}
 
-	if isConsensusReached(votes, orchestratorCount) {
-		if err := m.db.SetGravityTransactionConsensus(attestationID, true); err != nil {
-			return fmt.Errorf("setting gravity transaction consensus failed")
-		}
+	totalPower, err := m.getTotalPower(tx.Height, powers)
+	if err != nil {
+		return "", false, err
 	}
 
-	return nil
+	if !isConsensusReached(attestationPower, totalPower) {
+		return attestationID, false, nil
+	}
+
+	if err := m.db.SetGravityTransactionConsensus(attestationID, true); err != nil {
+		return "", false, fmt.Errorf("setting gravity transaction consensus failed")
+	}
+
+	return attestationID, true, nil
+}
+
+func (m *Module) handleMsgSetOrchestratorAddress(msg *gravityTypes.MsgSetOrchestratorAddress, tx *juno.Tx) error {
+	return m.db.SaveOrchestrator(types.GravityOrchestrator{
+		Address:          msg.Orchestrator,
+		ValidatorAddress: msg.Validator,
+		EthAddress:       msg.EthAddress,
+		Height:           tx.Height,
+		TxHash:           tx.TxHash,
+	})
+}
+
+func (m *Module) handleMsgSendToEth(index int, msg *gravityTypes.MsgSendToEth, tx *juno.Tx) error {
+	txID, err := getUint64FromLogs(index, tx, gravityTypes.AttributeKeyOutgoingTXID)
+	if err != nil {
+		return fmt.Errorf("outgoing tx id not found: %+v: %v", msg, err)
+	}
+
+	return m.db.SaveGravityOutgoingTransfer(types.GravityOutgoingTransfer{
+		ID:        txID,
+		Sender:    msg.Sender,
+		EthDest:   msg.EthDest,
+		Amount:    msg.Amount,
+		BridgeFee: msg.BridgeFee,
+		Height:    tx.Height,
+		TxHash:    tx.TxHash,
+	})
+}
+
+func (m *Module) handleMsgCancelSendToEth(msg *gravityTypes.MsgCancelSendToEth, tx *juno.Tx) error {
+	return m.db.CancelGravityOutgoingTransfer(msg.TransactionId, tx.TxHash)
+}
+
+func (m *Module) handleMsgRequestBatch(index int, msg *gravityTypes.MsgRequestBatch, tx *juno.Tx) error {
+	nonce, err := getUint64FromLogs(index, tx, gravityTypes.AttributeKeyBatchNonce)
+	if err != nil {
+		return fmt.Errorf("batch nonce not found: %+v: %v", msg, err)
+	}
+
+	return m.db.SaveGravityBatch(nonce, msg.Denom, msg.Sender, tx.Height, tx.TxHash)
+}
+
+func (m *Module) handleMsgConfirmBatch(msg *gravityTypes.MsgConfirmBatch, tx *juno.Tx) error {
+	return m.db.SaveGravityBatchConfirm(msg.Nonce, msg.TokenContract, msg.Orchestrator, msg.EthSigner, tx.Height, tx.TxHash)
 }
 
-func isConsensusReached(votes, orchestratorsCount int) bool {
-	consensusPercent := (float64(votes) * float64(100)) / float64(orchestratorsCount)
-	return consensusPercent >= requiredConsensusPercent
+func getUint64FromLogs(index int, tx *juno.Tx, attributeKey string) (uint64, error) {
+	value := utils.GetValueFromLogs(uint32(index), tx.Logs, sdk.EventTypeMessage, attributeKey)
+	return strconv.ParseUint(value, 10, 64)
 }
@@ -5,25 +5,32 @@ import (
 	"github.com/forbole/juno/v2/modules"
 
 	"github.com/forbole/bdjuno/v2/database"
+	gravitysource "github.com/forbole/bdjuno/v2/modules/gravity/source"
+	stakingsource "github.com/forbole/bdjuno/v2/modules/staking/source"
 )
 
 var (
 	_ modules.Module        = &Module{}
 	_ modules.MessageModule = &Module{}
 	_ modules.GenesisModule = &Module{}
+	_ modules.BlockModule   = &Module{}
 )
 
 // Module represents the gravity module
 type Module struct {
-	cdc codec.Codec
-	db  *database.Db
+	cdc           codec.Codec
+	db            *database.Db
+	source        gravitysource.Source
+	stakingSource stakingsource.Source
 }
 
 // NewModule returns a new Module instance
-func NewModule(cdc codec.Codec, db *database.Db) *Module {
+func NewModule(source gravitysource.Source, stakingSource stakingsource.Source, cdc codec.Codec, db *database.Db) *Module {
 	return &Module{
-		cdc: cdc,
-		db:  db,
+		cdc:           cdc,
+		db:            db,
+		source:        source,
+		stakingSource: stakingSource,
 	}
 }
 
@@ -0,0 +1,20 @@
+package gravity
+
+import (
+	"os"
+
+	"github.com/CudoVentures/cudos-node/simapp"
+	gravitykeeper "github.com/althea-net/cosmos-gravity-bridge/module/x/gravity/keeper"
+	"github.com/tendermint/tendermint/libs/log"
+	dbm "github.com/tendermint/tm-db"
+)
+
+// GetGravityKeeper returns the gravity keeper of a Cudos app reading from the given database.
+// The Cudos bech32 prefixes must have already been set, as cw20token.GetWasmKeeper does
+func GetGravityKeeper(homePath string, db dbm.DB) gravitykeeper.Keeper {
+	app := simapp.NewSimApp(
+		log.NewTMLogger(log.NewSyncWriter(os.Stdout)), db, nil, true, map[int64]bool{}, homePath, 0, simapp.MakeTestEncodingConfig(), simapp.EmptyAppOptions{},
+	)
+
+	return app.GravityKeeper
+}
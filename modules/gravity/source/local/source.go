@@ -0,0 +1,59 @@
+package local
+
+import (
+	"fmt"
+
+	gravitytypes "github.com/althea-net/cosmos-gravity-bridge/module/x/gravity/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/forbole/juno/v2/node/local"
+
+	gravitysource "github.com/forbole/bdjuno/v2/modules/gravity/source"
+)
+
+var (
+	_ gravitysource.Source = &Source{}
+)
+
+// Source implements gravitysource.Source using a local node
+type Source struct {
+	*local.Source
+	querier gravitytypes.QueryServer
+}
+
+// NewSource implements a new Source instance
+func NewSource(source *local.Source, querier gravitytypes.QueryServer) *Source {
+	return &Source{
+		Source:  source,
+		querier: querier,
+	}
+}
+
+// GetOutgoingTxBatches implements gravitysource.Source
+func (s Source) GetOutgoingTxBatches(height int64) ([]*gravitytypes.OutgoingTxBatch, error) {
+	ctx, err := s.LoadHeight(height)
+	if err != nil {
+		return nil, fmt.Errorf("error while loading height: %s", err)
+	}
+
+	res, err := s.querier.OutgoingTxBatches(sdk.WrapSDKContext(ctx), &gravitytypes.QueryOutgoingTxBatchesRequest{})
+	if err != nil {
+		return nil, err
+	}
+
+	return res.Batches, nil
+}
+
+// GetERC20Denom implements gravitysource.Source
+func (s Source) GetERC20Denom(height int64, erc20 string) (string, error) {
+	ctx, err := s.LoadHeight(height)
+	if err != nil {
+		return "", fmt.Errorf("error while loading height: %s", err)
+	}
+
+	res, err := s.querier.ERC20ToDenom(sdk.WrapSDKContext(ctx), &gravitytypes.QueryERC20ToDenomRequest{Erc20: erc20})
+	if err != nil {
+		return "", err
+	}
+
+	return res.Denom, nil
+}
@@ -0,0 +1,52 @@
+package remote
+
+import (
+	gravitytypes "github.com/althea-net/cosmos-gravity-bridge/module/x/gravity/types"
+	"github.com/forbole/juno/v2/node/remote"
+
+	gravitysource "github.com/forbole/bdjuno/v2/modules/gravity/source"
+)
+
+var (
+	_ gravitysource.Source = &Source{}
+)
+
+// Source implements gravitysource.Source using a remote node
+type Source struct {
+	*remote.Source
+	querier gravitytypes.QueryClient
+}
+
+// NewSource returns a new Source implementation
+func NewSource(source *remote.Source, querier gravitytypes.QueryClient) *Source {
+	return &Source{
+		Source:  source,
+		querier: querier,
+	}
+}
+
+// GetOutgoingTxBatches implements gravitysource.Source
+func (s Source) GetOutgoingTxBatches(height int64) ([]*gravitytypes.OutgoingTxBatch, error) {
+	res, err := s.querier.OutgoingTxBatches(
+		remote.GetHeightRequestContext(s.Ctx, height),
+		&gravitytypes.QueryOutgoingTxBatchesRequest{},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return res.Batches, nil
+}
+
+// GetERC20Denom implements gravitysource.Source
+func (s Source) GetERC20Denom(height int64, erc20 string) (string, error) {
+	res, err := s.querier.ERC20ToDenom(
+		remote.GetHeightRequestContext(s.Ctx, height),
+		&gravitytypes.QueryERC20ToDenomRequest{Erc20: erc20},
+	)
+	if err != nil {
+		return "", err
+	}
+
+	return res.Denom, nil
+}
@@ -0,0 +1,10 @@
+package source
+
+import (
+	gravitytypes "github.com/althea-net/cosmos-gravity-bridge/module/x/gravity/types"
+)
+
+type Source interface {
+	GetOutgoingTxBatches(height int64) ([]*gravitytypes.OutgoingTxBatch, error)
+	GetERC20Denom(height int64, erc20 string) (string, error)
+}
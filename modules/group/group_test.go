@@ -10,6 +10,7 @@ import (
 	"github.com/go-co-op/gocron"
 	"github.com/lib/pq"
 
+	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
 
 	"github.com/cosmos/cosmos-sdk/simapp"
@@ -19,6 +20,7 @@ import (
 	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
 	"github.com/forbole/bdjuno/v2/database"
 	dbtypes "github.com/forbole/bdjuno/v2/database/types"
+	bdjunotypes "github.com/forbole/bdjuno/v2/types"
 	"github.com/forbole/bdjuno/v2/utils"
 )
 
@@ -57,6 +59,9 @@ func (suite *GroupModuleTestSuite) SetupTest() {
 	_, err = db.Sql.Exec(`INSERT INTO group_with_policy VALUES ($1, $2, $3, $4, $5, $6, 0)`, one, oneStr, oneStr, oneStr, one, one)
 	suite.Require().NoError(err)
 
+	_, err = db.Sql.Exec(`INSERT INTO group_policy VALUES ($1, $2, $3, $4, $5, $6, 0)`, oneStr, one, oneStr, oneStr, one, one)
+	suite.Require().NoError(err)
+
 	_, err = db.Sql.Exec(`INSERT INTO group_member VALUES ($1, $2, $3, $4, $5)`, one, oneStr, oneStr, oneStr, timestamp)
 	suite.Require().NoError(err)
 
@@ -95,12 +100,27 @@ func (suite *GroupModuleTestSuite) TestGroup_HandleMsgCreateGroupWithPolicy() {
 		Threshold:          two,
 		VotingPeriod:       two,
 		MinExecutionPeriod: 0,
+		Admin:              twoStr,
 	}}
 	var actualGroup []dbtypes.GroupRow
 	err = suite.db.Sqlx.Select(&actualGroup, `SELECT * FROM group_with_policy where id = $1`, two)
 	suite.Require().NoError(err)
 	suite.Require().Equal(expectedGroup, actualGroup)
 
+	expectedPolicy := []dbtypes.GroupPolicyRow{{
+		Address:            twoStr,
+		GroupID:            two,
+		Admin:              twoStr,
+		Metadata:           dbtypes.ToNullString(twoStr),
+		Threshold:          two,
+		VotingPeriod:       two,
+		MinExecutionPeriod: 0,
+	}}
+	var actualPolicy []dbtypes.GroupPolicyRow
+	err = suite.db.Sqlx.Select(&actualPolicy, `SELECT * FROM group_policy where group_id = $1`, two)
+	suite.Require().NoError(err)
+	suite.Require().Equal(expectedPolicy, actualPolicy)
+
 	expectedMember := []dbtypes.GroupMemberRow{{
 		Address:  twoStr,
 		GroupID:  two,
@@ -114,6 +134,100 @@ func (suite *GroupModuleTestSuite) TestGroup_HandleMsgCreateGroupWithPolicy() {
 	suite.Require().Equal(expectedMember, actualMember)
 }
 
+func (suite *GroupModuleTestSuite) TestGroup_HandleMsgCreateGroupWithPolicy_Percentage() {
+	tx := utils.NewMockTxBuilder(suite.T(), timestamp, oneStr, one).WithEventCreateGroup(two, twoStr).Build()
+
+	decisionPolicy, err := codectypes.NewAnyWithValue(group.NewPercentageDecisionPolicy("0.5", time.Second*time.Duration(two), 0))
+	suite.Require().NoError(err)
+
+	msg := group.MsgCreateGroupWithPolicy{
+		Admin:               twoStr,
+		Members:             []group.MemberRequest{{Address: twoStr, Weight: twoStr, Metadata: twoStr}},
+		GroupMetadata:       twoStr,
+		GroupPolicyMetadata: twoStr,
+		DecisionPolicy:      decisionPolicy,
+	}
+
+	err = suite.module.HandleMsg(0, &msg, tx)
+	suite.Require().NoError(err)
+
+	expectedPolicy := []dbtypes.GroupPolicyRow{{
+		Address:            twoStr,
+		GroupID:            two,
+		Admin:              twoStr,
+		Metadata:           dbtypes.ToNullString(twoStr),
+		Threshold:          0,
+		VotingPeriod:       two,
+		MinExecutionPeriod: 0,
+		Percentage:         dbtypes.ToNullString("0.500000000000000000"),
+	}}
+	var actualPolicy []dbtypes.GroupPolicyRow
+	err = suite.db.Sqlx.Select(&actualPolicy, `SELECT * FROM group_policy where group_id = $1`, two)
+	suite.Require().NoError(err)
+	suite.Require().Equal(expectedPolicy, actualPolicy)
+
+	var percentage string
+	err = suite.db.Sqlx.QueryRow(`SELECT percentage FROM group_with_policy WHERE id = $1`, two).Scan(&percentage)
+	suite.Require().NoError(err)
+	suite.Require().Equal("0.500000000000000000", percentage)
+}
+
+func (suite *GroupModuleTestSuite) TestGroup_HandleMsgVote_Percentage() {
+	_, err := suite.db.Sql.Exec(`UPDATE group_policy SET threshold = 0, percentage = 0.5 WHERE address = $1`, oneStr)
+	suite.Require().NoError(err)
+
+	_, err = suite.db.Sql.Exec(`UPDATE group_proposal SET policy_address = $1 WHERE id = $2`, oneStr, one)
+	suite.Require().NoError(err)
+
+	_, err = suite.db.Sql.Exec(`INSERT INTO group_member VALUES ($1, $2, $3, $4, $5)`, one, twoStr, one, twoStr, timestamp)
+	suite.Require().NoError(err)
+
+	tx := utils.NewMockTxBuilder(suite.T(), timestamp, oneStr, one).WithEventVote().Build()
+
+	msg := group.MsgVote{ProposalId: one, Voter: oneStr, Option: voteYes}
+
+	err = suite.module.HandleMsg(0, &msg, tx)
+	suite.Require().NoError(err)
+
+	expectedStatus := statusAccepted.String()
+	var actualStatus string
+	err = suite.db.Sqlx.QueryRow(`SELECT status from group_proposal WHERE id = $1`, one).Scan(&actualStatus)
+	suite.Require().NoError(err)
+	suite.Require().Equal(expectedStatus, actualStatus)
+}
+
+func TestTallyProposal(t *testing.T) {
+	thresholdPolicy := bdjunotypes.GroupDecisionPolicy{Threshold: 3}
+	percentagePolicy := bdjunotypes.GroupDecisionPolicy{Percentage: "0.5"}
+
+	testCases := []struct {
+		name           string
+		policy         bdjunotypes.GroupDecisionPolicy
+		yes, voted     int
+		total          int
+		expectedStatus group.ProposalStatus
+	}{
+		{name: "threshold reached", policy: thresholdPolicy, yes: 3, voted: 3, total: 5, expectedStatus: statusAccepted},
+		{name: "threshold still reachable", policy: thresholdPolicy, yes: 1, voted: 2, total: 5},
+		{name: "threshold unreachable", policy: thresholdPolicy, yes: 1, voted: 4, total: 5, expectedStatus: statusRejected},
+		{name: "threshold above total weight reached", policy: thresholdPolicy, yes: 2, voted: 2, total: 2, expectedStatus: statusAccepted},
+		{name: "threshold above total weight unreachable", policy: thresholdPolicy, yes: 1, voted: 2, total: 2, expectedStatus: statusRejected},
+		{name: "percentage reached", policy: percentagePolicy, yes: 5, voted: 5, total: 10, expectedStatus: statusAccepted},
+		{name: "percentage still reachable", policy: percentagePolicy, yes: 4, voted: 9, total: 10},
+		{name: "percentage unreachable", policy: percentagePolicy, yes: 2, voted: 8, total: 10, expectedStatus: statusRejected},
+		{name: "empty group", policy: thresholdPolicy},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			status, err := tallyProposal(tc.policy, tc.yes, tc.voted, tc.total)
+			require.NoError(t, err)
+			require.Equal(t, tc.expectedStatus, status)
+		})
+	}
+}
+
 func (suite *GroupModuleTestSuite) TestGroup_HandleMsgSubmitProposal() {
 	tx := utils.NewMockTxBuilder(suite.T(), timestamp, oneStr, one).WithEventSubmitProposal(two).Build()
 
@@ -134,6 +248,7 @@ func (suite *GroupModuleTestSuite) TestGroup_HandleMsgSubmitProposal() {
 		BlockHeight:      int64(one),
 		SubmitTime:       timestamp,
 		MemberCount:      int(one),
+		PolicyAddress:    dbtypes.ToNullString(oneStr),
 	}}
 	var actualProposal []dbtypes.GroupProposalRow
 	err = suite.db.Sqlx.Select(&actualProposal, `SELECT * FROM group_proposal where id = $1`, two)
@@ -241,7 +356,7 @@ func (suite *GroupModuleTestSuite) TestGroup_HandleMsgExec() {
 func (suite *GroupModuleTestSuite) TestGroup_HandleMsgExec_HandleMsgUpdateGroup() {
 	tx := utils.NewMockTxBuilder(suite.T(), timestamp, oneStr, one).WithEventSubmitProposal(two).WithEventVote().WithEventExec(resultSuccess).Build()
 
-	msgJson := fmt.Sprintf(`{"group_policy_address": "%[1]d","proposers": ["%[1]d"],"metadata": "","messages": [{"@type": "/cosmos.group.v1.MsgUpdateGroupMetadata","admin": "%[1]d","group_id": "%[1]d","metadata": "%[2]d"},{"@type": "/cosmos.group.v1.MsgUpdateGroupPolicyMetadata","admin": "%[1]d","group_id": "%[1]d","metadata": "%[2]d"},{"@type": "/cosmos.group.v1.MsgUpdateGroupMembers","admin": "%[1]d","group_id": "%[1]d","member_updates": [{ "weight": "0", "address": "%[1]d", "metadata": "%[2]d" },{ "weight": "%[2]d", "address": "%[2]d", "metadata": "%[2]d" }]},{"@type": "/cosmos.group.v1.MsgUpdateGroupPolicyDecisionPolicy","admin": "%[1]d","group_id": "%[1]d","decision_policy": {"@type":"/cosmos.group.v1.ThresholdDecisionPolicy","threshold":"%[2]d","windows": {"voting_period": "%[2]ds", "min_execution_period": "%[2]ds"}}}]}`, one, two)
+	msgJson := fmt.Sprintf(`{"group_policy_address": "%[1]d","proposers": ["%[1]d"],"metadata": "","messages": [{"@type": "/cosmos.group.v1.MsgUpdateGroupMetadata","admin": "%[1]d","group_id": "%[1]d","metadata": "%[2]d"},{"@type": "/cosmos.group.v1.MsgUpdateGroupPolicyMetadata","admin": "%[1]d","group_policy_address": "%[1]d","metadata": "%[2]d"},{"@type": "/cosmos.group.v1.MsgUpdateGroupMembers","admin": "%[1]d","group_id": "%[1]d","member_updates": [{ "weight": "0", "address": "%[1]d", "metadata": "%[2]d" },{ "weight": "%[2]d", "address": "%[2]d", "metadata": "%[2]d" }]},{"@type": "/cosmos.group.v1.MsgUpdateGroupPolicyDecisionPolicy","admin": "%[1]d","group_policy_address": "%[1]d","decision_policy": {"@type":"/cosmos.group.v1.ThresholdDecisionPolicy","threshold":"%[2]d","windows": {"voting_period": "%[2]ds", "min_execution_period": "%[2]ds"}}}]}`, one, two)
 	msg := group.MsgSubmitProposal{Exec: execTry}
 	err := json.Unmarshal([]byte(msgJson), &msg)
 	suite.Require().NoError(err)
@@ -282,6 +397,182 @@ func (suite *GroupModuleTestSuite) TestGroup_HandleMsgExec_HandleMsgUpdateGroup(
 	suite.Require().Equal(expectedStatuses, actualStatuses)
 }
 
+func (suite *GroupModuleTestSuite) TestGroup_HandleMsgCreateGroup() {
+	tx := utils.NewMockTxBuilder(suite.T(), timestamp, oneStr, one).WithEventCreateGroup(two, twoStr).Build()
+
+	msg := group.MsgCreateGroup{
+		Admin:    twoStr,
+		Members:  []group.MemberRequest{{Address: twoStr, Weight: twoStr, Metadata: twoStr}},
+		Metadata: twoStr,
+	}
+
+	err := suite.module.HandleMsg(0, &msg, tx)
+	suite.Require().NoError(err)
+
+	expectedGroup := []dbtypes.GroupRow{{
+		ID:            two,
+		GroupMetadata: twoStr,
+		Admin:         twoStr,
+	}}
+	var actualGroup []dbtypes.GroupRow
+	err = suite.db.Sqlx.Select(&actualGroup, `SELECT * FROM group_with_policy where id = $1`, two)
+	suite.Require().NoError(err)
+	suite.Require().Equal(expectedGroup, actualGroup)
+
+	var memberCount int
+	err = suite.db.Sqlx.QueryRow(`SELECT COUNT(*) FROM group_member WHERE group_id = $1`, two).Scan(&memberCount)
+	suite.Require().NoError(err)
+	suite.Require().Equal(1, memberCount)
+}
+
+func (suite *GroupModuleTestSuite) TestGroup_HandleMsgCreateGroupPolicy() {
+	tx := utils.NewMockTxBuilder(suite.T(), timestamp, oneStr, one).WithEventCreateGroupPolicy(twoStr).Build()
+
+	decisionPolicy, err := codectypes.NewAnyWithValue(group.NewThresholdDecisionPolicy(twoStr, time.Second*time.Duration(two), 0))
+	suite.Require().NoError(err)
+
+	msg := group.MsgCreateGroupPolicy{Admin: oneStr, GroupId: one, Metadata: twoStr, DecisionPolicy: decisionPolicy}
+
+	err = suite.module.HandleMsg(0, &msg, tx)
+	suite.Require().NoError(err)
+
+	expectedPolicies := []dbtypes.GroupPolicyRow{
+		{
+			Address:            oneStr,
+			GroupID:            one,
+			Admin:              oneStr,
+			Metadata:           dbtypes.ToNullString(oneStr),
+			Threshold:          one,
+			VotingPeriod:       one,
+			MinExecutionPeriod: 0,
+		},
+		{
+			Address:            twoStr,
+			GroupID:            one,
+			Admin:              oneStr,
+			Metadata:           dbtypes.ToNullString(twoStr),
+			Threshold:          two,
+			VotingPeriod:       two,
+			MinExecutionPeriod: 0,
+		},
+	}
+	var actualPolicies []dbtypes.GroupPolicyRow
+	err = suite.db.Sqlx.Select(&actualPolicies, `SELECT * FROM group_policy WHERE group_id = $1 ORDER BY address`, one)
+	suite.Require().NoError(err)
+	suite.Require().Equal(expectedPolicies, actualPolicies)
+
+	// The group keeps mirroring its first policy
+	var address string
+	err = suite.db.Sqlx.QueryRow(`SELECT address FROM group_with_policy WHERE id = $1`, one).Scan(&address)
+	suite.Require().NoError(err)
+	suite.Require().Equal(oneStr, address)
+}
+
+func (suite *GroupModuleTestSuite) TestGroup_HandleMsgUpdateGroupMembers() {
+	tx := utils.NewMockTxBuilder(suite.T(), timestamp, oneStr, one).WithEventUpdateGroup(one).Build()
+
+	msg := group.MsgUpdateGroupMembers{
+		Admin:   oneStr,
+		GroupId: one,
+		MemberUpdates: []group.MemberRequest{
+			{Address: oneStr, Weight: "0", Metadata: oneStr},
+			{Address: twoStr, Weight: twoStr, Metadata: twoStr},
+		},
+	}
+
+	err := suite.module.HandleMsg(0, &msg, tx)
+	suite.Require().NoError(err)
+
+	expectedMember := []dbtypes.GroupMemberRow{{
+		Address:  twoStr,
+		GroupID:  one,
+		Weight:   two,
+		Metadata: twoStr,
+		AddTime:  timestamp,
+	}}
+	var actualMember []dbtypes.GroupMemberRow
+	err = suite.db.Sqlx.Select(&actualMember, `SELECT * FROM group_member WHERE weight > 0 AND group_id = $1`, one)
+	suite.Require().NoError(err)
+	suite.Require().Equal(expectedMember, actualMember)
+
+	var status string
+	err = suite.db.Sqlx.QueryRow(`SELECT status FROM group_proposal WHERE id = $1`, one).Scan(&status)
+	suite.Require().NoError(err)
+	suite.Require().Equal(group.PROPOSAL_STATUS_ABORTED.String(), status)
+}
+
+func (suite *GroupModuleTestSuite) TestGroup_HandleMsgUpdateGroupAdmin() {
+	tx := utils.NewMockTxBuilder(suite.T(), timestamp, oneStr, one).WithEventUpdateGroup(one).Build()
+
+	msg := group.MsgUpdateGroupAdmin{Admin: oneStr, GroupId: one, NewAdmin: twoStr}
+
+	err := suite.module.HandleMsg(0, &msg, tx)
+	suite.Require().NoError(err)
+
+	var admin string
+	err = suite.db.Sqlx.QueryRow(`SELECT admin FROM group_with_policy WHERE id = $1`, one).Scan(&admin)
+	suite.Require().NoError(err)
+	suite.Require().Equal(twoStr, admin)
+}
+
+func (suite *GroupModuleTestSuite) TestGroup_HandleMsgUpdateGroupPolicyDecisionPolicy() {
+	_, err := suite.db.Sql.Exec(`UPDATE group_proposal SET policy_address = $1 WHERE id = $2`, oneStr, one)
+	suite.Require().NoError(err)
+
+	tx := utils.NewMockTxBuilder(suite.T(), timestamp, oneStr, one).WithEventUpdateGroupPolicy(oneStr).Build()
+
+	msg := group.MsgUpdateGroupPolicyDecisionPolicy{Admin: oneStr, GroupPolicyAddress: oneStr}
+	err = msg.SetDecisionPolicy(group.NewThresholdDecisionPolicy(twoStr, time.Second*time.Duration(two), time.Second))
+	suite.Require().NoError(err)
+
+	err = suite.module.HandleMsg(0, &msg, tx)
+	suite.Require().NoError(err)
+
+	expectedPolicy := []dbtypes.GroupPolicyRow{{
+		Address:            oneStr,
+		GroupID:            one,
+		Admin:              oneStr,
+		Metadata:           dbtypes.ToNullString(oneStr),
+		Threshold:          two,
+		VotingPeriod:       two,
+		MinExecutionPeriod: one,
+	}}
+	var actualPolicy []dbtypes.GroupPolicyRow
+	err = suite.db.Sqlx.Select(&actualPolicy, `SELECT * FROM group_policy WHERE address = $1`, oneStr)
+	suite.Require().NoError(err)
+	suite.Require().Equal(expectedPolicy, actualPolicy)
+
+	var threshold uint64
+	err = suite.db.Sqlx.QueryRow(`SELECT threshold FROM group_with_policy WHERE id = $1`, one).Scan(&threshold)
+	suite.Require().NoError(err)
+	suite.Require().Equal(two, threshold)
+
+	// Proposals submitted to the policy become unexecutable once it is updated
+	var status string
+	err = suite.db.Sqlx.QueryRow(`SELECT status FROM group_proposal WHERE id = $1`, one).Scan(&status)
+	suite.Require().NoError(err)
+	suite.Require().Equal(group.PROPOSAL_STATUS_ABORTED.String(), status)
+}
+
+func (suite *GroupModuleTestSuite) TestGroup_HandleMsgLeaveGroup() {
+	tx := utils.NewMockTxBuilder(suite.T(), timestamp, oneStr, one).WithEventLeaveGroup(one, oneStr).Build()
+
+	msg := group.MsgLeaveGroup{Address: oneStr, GroupId: one}
+
+	err := suite.module.HandleMsg(0, &msg, tx)
+	suite.Require().NoError(err)
+
+	var weight uint64
+	err = suite.db.Sqlx.QueryRow(`SELECT weight FROM group_member WHERE group_id = $1 AND address = $2`, one, oneStr).Scan(&weight)
+	suite.Require().NoError(err)
+	suite.Require().Zero(weight)
+
+	var status string
+	err = suite.db.Sqlx.QueryRow(`SELECT status FROM group_proposal WHERE id = $1`, one).Scan(&status)
+	suite.Require().NoError(err)
+	suite.Require().Equal(group.PROPOSAL_STATUS_ABORTED.String(), status)
+}
+
 func (suite *GroupModuleTestSuite) TestGroup_HandleMsgWithdrawProposal() {
 	tx := utils.NewMockTxBuilder(suite.T(), timestamp, oneStr, one).WithEventWithdrawProposal().Build()
 
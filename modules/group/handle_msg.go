@@ -23,8 +23,26 @@ func (m *Module) HandleMsg(index int, msg sdk.Msg, tx *juno.Tx) error {
 
 	return m.db.ExecuteTx(func(dbTx *database.DbTx) error {
 		switch cosmosMsg := msg.(type) {
+		case *group.MsgCreateGroup:
+			return m.handleMsgCreateGroup(dbTx, tx, index, cosmosMsg)
+		case *group.MsgCreateGroupPolicy:
+			return m.handleMsgCreateGroupPolicy(dbTx, tx, index, cosmosMsg)
 		case *group.MsgCreateGroupWithPolicy:
 			return m.handleMsgCreateGroupWithPolicy(dbTx, tx, index, cosmosMsg)
+		case *group.MsgUpdateGroupMembers:
+			return m.handleMsgUpdateGroupMembers(dbTx, tx, index, cosmosMsg)
+		case *group.MsgUpdateGroupAdmin:
+			return m.handleMsgUpdateGroupAdmin(dbTx, tx, index, cosmosMsg)
+		case *group.MsgUpdateGroupMetadata:
+			return m.handleMsgUpdateGroupMetadata(dbTx, tx, index, cosmosMsg)
+		case *group.MsgUpdateGroupPolicyAdmin:
+			return m.handleMsgUpdateGroupPolicyAdmin(dbTx, tx, index, cosmosMsg)
+		case *group.MsgUpdateGroupPolicyMetadata:
+			return m.handleMsgUpdateGroupPolicyMetadata(dbTx, tx, index, cosmosMsg)
+		case *group.MsgUpdateGroupPolicyDecisionPolicy:
+			return m.handleMsgUpdateGroupPolicyDecisionPolicy(dbTx, tx, index, cosmosMsg)
+		case *group.MsgLeaveGroup:
+			return m.handleMsgLeaveGroup(dbTx, tx, index, cosmosMsg)
 		case *group.MsgSubmitProposal:
 			return m.handleMsgSubmitProposal(dbTx, tx, index, cosmosMsg)
 		case *group.MsgVote:
@@ -40,13 +58,36 @@ func (m *Module) HandleMsg(index int, msg sdk.Msg, tx *juno.Tx) error {
 
 }
 
-func (m *Module) handleMsgCreateGroupWithPolicy(dbTx *database.DbTx, tx *juno.Tx, index int, msg *group.MsgCreateGroupWithPolicy) error {
-	groupIDAttr := utils.GetValueFromLogs(uint32(index), tx.Logs, "cosmos.group.v1.EventCreateGroup", "group_id")
-	if groupIDAttr == "" {
-		return errors.New("error while getting EventCreateGroup")
+func (m *Module) handleMsgCreateGroup(dbTx *database.DbTx, tx *juno.Tx, index int, msg *group.MsgCreateGroup) error {
+	groupID, err := getGroupIDFromLogs(index, tx, "cosmos.group.v1.EventCreateGroup")
+	if err != nil {
+		return err
+	}
+
+	return m.saveGroup(dbTx, tx, types.NewGroup(groupID, msg.Admin, msg.Metadata), msg.Members)
+}
+
+func (m *Module) handleMsgCreateGroupPolicy(dbTx *database.DbTx, tx *juno.Tx, index int, msg *group.MsgCreateGroupPolicy) error {
+	address := utils.GetValueFromLogs(uint32(index), tx.Logs, "cosmos.group.v1.EventCreateGroupPolicy", "address")
+	if address == "" {
+		return errors.New("error while getting EventCreateGroupPolicy")
+	}
+
+	decisionPolicy, err := msg.GetDecisionPolicy()
+	if err != nil {
+		return err
+	}
+
+	policy, err := parseDecisionPolicy(decisionPolicy)
+	if err != nil {
+		return err
 	}
 
-	groupID, err := strconv.ParseUint(groupIDAttr, 10, 64)
+	return dbTx.SaveGroupPolicy(types.NewGroupPolicy(address, msg.GroupId, msg.Admin, msg.Metadata, policy))
+}
+
+func (m *Module) handleMsgCreateGroupWithPolicy(dbTx *database.DbTx, tx *juno.Tx, index int, msg *group.MsgCreateGroupWithPolicy) error {
+	groupID, err := getGroupIDFromLogs(index, tx, "cosmos.group.v1.EventCreateGroup")
 	if err != nil {
 		return err
 	}
@@ -56,32 +97,36 @@ func (m *Module) handleMsgCreateGroupWithPolicy(dbTx *database.DbTx, tx *juno.Tx
 		return errors.New("error while getting EventCreateGroupPolicy")
 	}
 
-	decisionPolicy, ok := msg.DecisionPolicy.GetCachedValue().(*group.ThresholdDecisionPolicy)
-	if !ok {
-		return errors.New("error while parsing decision policy")
+	decisionPolicy, err := msg.GetDecisionPolicy()
+	if err != nil {
+		return err
 	}
 
-	threshold, err := strconv.ParseUint(decisionPolicy.Threshold, 10, 64)
+	policy, err := parseDecisionPolicy(decisionPolicy)
 	if err != nil {
 		return err
 	}
 
-	votingPeriod := uint64(decisionPolicy.Windows.VotingPeriod.Seconds())
-	minExecutionPeriod := uint64(decisionPolicy.Windows.MinExecutionPeriod.Seconds())
-	group := types.NewGroup(groupID, address, msg.GroupMetadata, msg.GroupPolicyMetadata, threshold, votingPeriod, minExecutionPeriod)
+	admin := msg.Admin
+	if msg.GroupPolicyAsAdmin {
+		admin = address
+	}
 
-	if err := dbTx.SaveGroup(group); err != nil {
+	if err := m.saveGroup(dbTx, tx, types.NewGroup(groupID, admin, msg.GroupMetadata), msg.Members); err != nil {
 		return err
 	}
 
-	members := make([]*types.Member, len(msg.Members))
-	for i, m := range msg.Members {
-		weight, err := strconv.ParseUint(m.Weight, 10, 64)
-		if err != nil {
-			return err
-		}
+	return dbTx.SaveGroupPolicy(types.NewGroupPolicy(address, groupID, admin, msg.GroupPolicyMetadata, policy))
+}
 
-		members[i] = types.NewMember(m.Address, weight, m.Metadata)
+func (m *Module) saveGroup(dbTx *database.DbTx, tx *juno.Tx, groupInfo *types.Group, memberRequests []group.MemberRequest) error {
+	if err := dbTx.SaveGroup(groupInfo); err != nil {
+		return err
+	}
+
+	members, err := convertMembers(memberRequests)
+	if err != nil {
+		return err
 	}
 
 	timestamp, err := time.Parse(time.RFC3339, tx.Timestamp)
@@ -89,7 +134,83 @@ func (m *Module) handleMsgCreateGroupWithPolicy(dbTx *database.DbTx, tx *juno.Tx
 		return err
 	}
 
-	return dbTx.SaveMembers(groupID, members, timestamp)
+	return dbTx.SaveMembers(groupInfo.ID, members, timestamp)
+}
+
+func (m *Module) handleMsgUpdateGroupMembers(dbTx *database.DbTx, tx *juno.Tx, index int, msg *group.MsgUpdateGroupMembers) error {
+	if _, err := getGroupIDFromLogs(index, tx, "cosmos.group.v1.EventUpdateGroup"); err != nil {
+		return err
+	}
+
+	members, err := convertMembers(msg.MemberUpdates)
+	if err != nil {
+		return err
+	}
+
+	return m.updateGroupMembers(dbTx, tx, msg.GroupId, members)
+}
+
+func (m *Module) handleMsgUpdateGroupAdmin(dbTx *database.DbTx, tx *juno.Tx, index int, msg *group.MsgUpdateGroupAdmin) error {
+	if _, err := getGroupIDFromLogs(index, tx, "cosmos.group.v1.EventUpdateGroup"); err != nil {
+		return err
+	}
+
+	return m.updateGroupAdmin(dbTx, msg.GroupId, msg.NewAdmin)
+}
+
+func (m *Module) handleMsgUpdateGroupMetadata(dbTx *database.DbTx, tx *juno.Tx, index int, msg *group.MsgUpdateGroupMetadata) error {
+	if _, err := getGroupIDFromLogs(index, tx, "cosmos.group.v1.EventUpdateGroup"); err != nil {
+		return err
+	}
+
+	return m.updateGroupMetadata(dbTx, msg.GroupId, msg.Metadata)
+}
+
+func (m *Module) handleMsgUpdateGroupPolicyAdmin(dbTx *database.DbTx, tx *juno.Tx, index int, msg *group.MsgUpdateGroupPolicyAdmin) error {
+	if err := checkGroupPolicyUpdated(index, tx); err != nil {
+		return err
+	}
+
+	return m.updateGroupPolicyAdmin(dbTx, msg.GroupPolicyAddress, msg.NewAdmin)
+}
+
+func (m *Module) handleMsgUpdateGroupPolicyMetadata(dbTx *database.DbTx, tx *juno.Tx, index int, msg *group.MsgUpdateGroupPolicyMetadata) error {
+	if err := checkGroupPolicyUpdated(index, tx); err != nil {
+		return err
+	}
+
+	return m.updateGroupPolicyMetadata(dbTx, msg.GroupPolicyAddress, msg.Metadata)
+}
+
+func (m *Module) handleMsgUpdateGroupPolicyDecisionPolicy(dbTx *database.DbTx, tx *juno.Tx, index int, msg *group.MsgUpdateGroupPolicyDecisionPolicy) error {
+	if err := checkGroupPolicyUpdated(index, tx); err != nil {
+		return err
+	}
+
+	decisionPolicy, err := msg.GetDecisionPolicy()
+	if err != nil {
+		return err
+	}
+
+	policy, err := parseDecisionPolicy(decisionPolicy)
+	if err != nil {
+		return err
+	}
+
+	return m.updateDecisionPolicy(dbTx, msg.GroupPolicyAddress, policy)
+}
+
+func (m *Module) handleMsgLeaveGroup(dbTx *database.DbTx, tx *juno.Tx, index int, msg *group.MsgLeaveGroup) error {
+	if _, err := getGroupIDFromLogs(index, tx, "cosmos.group.v1.EventLeaveGroup"); err != nil {
+		return err
+	}
+
+	// Leaving a group bumps its version, which makes all its pending proposals unexecutable
+	if err := dbTx.UpdateActiveProposalStatusesByGroup(msg.GroupId, group.PROPOSAL_STATUS_ABORTED.String()); err != nil {
+		return err
+	}
+
+	return dbTx.RemoveMember(msg.GroupId, msg.Address)
 }
 
 func (m *Module) handleMsgSubmitProposal(dbTx *database.DbTx, tx *juno.Tx, index int, msg *group.MsgSubmitProposal) error {
@@ -127,7 +248,7 @@ func (m *Module) handleMsgSubmitProposal(dbTx *database.DbTx, tx *juno.Tx, index
 		return err
 	}
 
-	proposal := types.NewGroupProposal(proposalID, groupID, msg.Metadata, msg.Proposers[0], status, result, msgs, tx.Height, timestamp, memberCount)
+	proposal := types.NewGroupProposal(proposalID, groupID, msg.GroupPolicyAddress, msg.Metadata, msg.Proposers[0], status, result, msgs, tx.Height, timestamp, memberCount)
 
 	if err := dbTx.SaveProposal(proposal); err != nil {
 		return err
@@ -186,41 +307,27 @@ func (m *Module) handleMsgVote(dbTx *database.DbTx, tx *juno.Tx, index int, msg
 }
 
 func (m *Module) updateProposalStatus(dbTx *database.DbTx, proposalID uint64, groupID uint64) (group.ProposalStatus, error) {
-	votes, err := dbTx.GetProposalVotes(proposalID)
+	yesWeight, votedWeight, err := dbTx.GetProposalTally(proposalID)
 	if err != nil {
 		return 0, err
 	}
 
-	votesYes := 0
-	for _, v := range votes {
-		if v == group.VOTE_OPTION_YES.String() {
-			votesYes++
-		}
-	}
-
-	threshold, err := dbTx.GetGroupThreshold(groupID)
+	policy, err := dbTx.GetProposalDecisionPolicy(proposalID)
 	if err != nil {
 		return 0, err
 	}
 
-	if votesYes >= threshold {
-		err := dbTx.UpdateProposalStatus(proposalID, group.PROPOSAL_STATUS_ACCEPTED.String())
-		return group.PROPOSAL_STATUS_ACCEPTED, err
-	}
-
 	totalPower, err := dbTx.GetGroupTotalVotingPower(groupID)
 	if err != nil {
 		return 0, err
 	}
 
-	votesRemaining := totalPower - len(votes)
-	maxPossibleYesCount := votesYes + votesRemaining
-	if maxPossibleYesCount < threshold {
-		err := dbTx.UpdateProposalStatus(proposalID, group.PROPOSAL_STATUS_REJECTED.String())
-		return group.PROPOSAL_STATUS_REJECTED, err
+	status, err := tallyProposal(policy, yesWeight, votedWeight, totalPower)
+	if err != nil || status == 0 {
+		return 0, err
 	}
 
-	return 0, nil
+	return status, dbTx.UpdateProposalStatus(proposalID, status.String())
 }
 
 func (m *Module) handleMsgExec(dbTx *database.DbTx, tx *juno.Tx, index int, msg *group.MsgExec) error {
@@ -260,11 +367,6 @@ func (m *Module) handleMsgUpdateGroup(dbTx *database.DbTx, tx *juno.Tx, proposal
 		return errors.New("error while executing handleMsgUpdateGroup")
 	}
 
-	abort := group.PROPOSAL_STATUS_ABORTED.String()
-	if err := dbTx.UpdateActiveProposalStatusesByGroup(proposal.GroupID, abort); err != nil {
-		return err
-	}
-
 	var msgTypes []types.MsgType
 	if err := json.Unmarshal([]byte(proposal.Messages), &msgTypes); err != nil {
 		return err
@@ -283,12 +385,16 @@ func (m *Module) handleMsgUpdateGroup(dbTx *database.DbTx, tx *juno.Tx, proposal
 				return err
 			}
 
-			timestamp, err := time.Parse(time.RFC3339, tx.Timestamp)
-			if err != nil {
+			if err := m.updateGroupMembers(dbTx, tx, msg.GroupID, msg.MemberUpdates); err != nil {
+				return err
+			}
+		case "/cosmos.group.v1.MsgUpdateGroupAdmin":
+			var msg types.MsgUpdateGroupAdmin
+			if err := json.Unmarshal(msgs[i], &msg); err != nil {
 				return err
 			}
 
-			if err := dbTx.SaveMembers(msg.GroupID, msg.MemberUpdates, timestamp); err != nil {
+			if err := m.updateGroupAdmin(dbTx, msg.GroupID, msg.NewAdmin); err != nil {
 				return err
 			}
 		case "/cosmos.group.v1.MsgUpdateGroupMetadata":
@@ -297,37 +403,39 @@ func (m *Module) handleMsgUpdateGroup(dbTx *database.DbTx, tx *juno.Tx, proposal
 				return err
 			}
 
-			if err := dbTx.UpdateGroupMetadata(proposal.GroupID, msg.Metadata); err != nil {
+			if err := m.updateGroupMetadata(dbTx, msg.GroupID, msg.Metadata); err != nil {
 				return err
 			}
-		case "/cosmos.group.v1.MsgUpdateGroupPolicyMetadata":
-			var msg group.MsgUpdateGroupPolicyMetadata
+		case "/cosmos.group.v1.MsgUpdateGroupPolicyAdmin":
+			var msg types.MsgUpdateGroupPolicyAdmin
 			if err := json.Unmarshal(msgs[i], &msg); err != nil {
 				return err
 			}
 
-			if err := dbTx.UpdateGroupPolicyMetadata(proposal.GroupID, msg.Metadata); err != nil {
+			if err := m.updateGroupPolicyAdmin(dbTx, msg.GroupPolicyAddress, msg.NewAdmin); err != nil {
 				return err
 			}
-		case "/cosmos.group.v1.MsgUpdateGroupPolicyDecisionPolicy":
-			var msg types.MsgUpdateDecisionPolicy
+		case "/cosmos.group.v1.MsgUpdateGroupPolicyMetadata":
+			var msg types.MsgUpdateGroupPolicyMetadata
 			if err := json.Unmarshal(msgs[i], &msg); err != nil {
 				return err
 			}
 
-			votingPeriod, err := time.ParseDuration(msg.DecisionPolicy.Windows.VotingPeriod)
-			if err != nil {
+			if err := m.updateGroupPolicyMetadata(dbTx, msg.GroupPolicyAddress, msg.Metadata); err != nil {
+				return err
+			}
+		case "/cosmos.group.v1.MsgUpdateGroupPolicyDecisionPolicy":
+			var msg types.MsgUpdateDecisionPolicy
+			if err := json.Unmarshal(msgs[i], &msg); err != nil {
 				return err
 			}
-			votingPeriodUint := uint64(votingPeriod.Seconds())
 
-			minExecutionPeriod, err := time.ParseDuration(msg.DecisionPolicy.Windows.MinExecutionPeriod)
+			policy, err := parseJSONDecisionPolicy(msg.DecisionPolicy)
 			if err != nil {
 				return err
 			}
-			minExecutionPeriodUint := uint64(minExecutionPeriod.Seconds())
 
-			if err := dbTx.UpdateDecisionPolicy(proposal.GroupID, msg.DecisionPolicy.Threshold, votingPeriodUint, minExecutionPeriodUint); err != nil {
+			if err := m.updateDecisionPolicy(dbTx, msg.GroupPolicyAddress, policy); err != nil {
 				return err
 			}
 		}
@@ -336,6 +444,61 @@ func (m *Module) handleMsgUpdateGroup(dbTx *database.DbTx, tx *juno.Tx, proposal
 	return nil
 }
 
+// Updating a group bumps its version, so all its pending proposals get aborted
+func (m *Module) updateGroupMembers(dbTx *database.DbTx, tx *juno.Tx, groupID uint64, members []*types.Member) error {
+	if err := dbTx.UpdateActiveProposalStatusesByGroup(groupID, group.PROPOSAL_STATUS_ABORTED.String()); err != nil {
+		return err
+	}
+
+	timestamp, err := time.Parse(time.RFC3339, tx.Timestamp)
+	if err != nil {
+		return err
+	}
+
+	return dbTx.SaveMembers(groupID, members, timestamp)
+}
+
+func (m *Module) updateGroupAdmin(dbTx *database.DbTx, groupID uint64, admin string) error {
+	if err := dbTx.UpdateActiveProposalStatusesByGroup(groupID, group.PROPOSAL_STATUS_ABORTED.String()); err != nil {
+		return err
+	}
+
+	return dbTx.UpdateGroupAdmin(groupID, admin)
+}
+
+func (m *Module) updateGroupMetadata(dbTx *database.DbTx, groupID uint64, metadata string) error {
+	if err := dbTx.UpdateActiveProposalStatusesByGroup(groupID, group.PROPOSAL_STATUS_ABORTED.String()); err != nil {
+		return err
+	}
+
+	return dbTx.UpdateGroupMetadata(groupID, metadata)
+}
+
+// Updating a group policy bumps its version, so only the pending proposals of that policy get aborted
+func (m *Module) updateGroupPolicyAdmin(dbTx *database.DbTx, policyAddress string, admin string) error {
+	if err := dbTx.UpdateActiveProposalStatusesByPolicy(policyAddress, group.PROPOSAL_STATUS_ABORTED.String()); err != nil {
+		return err
+	}
+
+	return dbTx.UpdateGroupPolicyAdmin(policyAddress, admin)
+}
+
+func (m *Module) updateGroupPolicyMetadata(dbTx *database.DbTx, policyAddress string, metadata string) error {
+	if err := dbTx.UpdateActiveProposalStatusesByPolicy(policyAddress, group.PROPOSAL_STATUS_ABORTED.String()); err != nil {
+		return err
+	}
+
+	return dbTx.UpdateGroupPolicyMetadata(policyAddress, metadata)
+}
+
+func (m *Module) updateDecisionPolicy(dbTx *database.DbTx, policyAddress string, policy types.GroupDecisionPolicy) error {
+	if err := dbTx.UpdateActiveProposalStatusesByPolicy(policyAddress, group.PROPOSAL_STATUS_ABORTED.String()); err != nil {
+		return err
+	}
+
+	return dbTx.UpdateDecisionPolicy(policyAddress, policy)
+}
+
 func (m *Module) handleMsgWithdrawProposal(dbTx *database.DbTx, tx *juno.Tx, index int, proposalID uint64) error {
 	event := utils.GetValueFromLogs(uint32(index), tx.Logs, "cosmos.group.v1.EventWithdrawProposal", "proposal_id")
 	if event == "" {
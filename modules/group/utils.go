@@ -0,0 +1,158 @@
+package group
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/group"
+	"github.com/forbole/bdjuno/v2/modules/utils"
+	"github.com/forbole/bdjuno/v2/types"
+	juno "github.com/forbole/juno/v2/types"
+)
+
+// getGroupIDFromLogs returns the group_id attribute of the given event emitted by the message at the given index
+func getGroupIDFromLogs(index int, tx *juno.Tx, eventType string) (uint64, error) {
+	groupIDAttr := utils.GetValueFromLogs(uint32(index), tx.Logs, eventType, "group_id")
+	if groupIDAttr == "" {
+		return 0, fmt.Errorf("error while getting %s", eventType)
+	}
+
+	return strconv.ParseUint(groupIDAttr, 10, 64)
+}
+
+// checkGroupPolicyUpdated makes sure the message at the given index has updated a group policy.
+// EventUpdateGroupPolicy carries the admin address rather than the policy one, so only its presence is checked
+func checkGroupPolicyUpdated(index int, tx *juno.Tx) error {
+	address := utils.GetValueFromLogs(uint32(index), tx.Logs, "cosmos.group.v1.EventUpdateGroupPolicy", "address")
+	if address == "" {
+		return errors.New("error while getting EventUpdateGroupPolicy")
+	}
+
+	return nil
+}
+
+func convertMembers(requests []group.MemberRequest) ([]*types.Member, error) {
+	members := make([]*types.Member, len(requests))
+	for i, m := range requests {
+		weight, err := strconv.ParseUint(m.Weight, 10, 64)
+		if err != nil {
+			return nil, err
+		}
+
+		members[i] = types.NewMember(m.Address, weight, m.Metadata)
+	}
+
+	return members, nil
+}
+
+// parseDecisionPolicy converts the decision policy of a message into its stored representation
+func parseDecisionPolicy(decisionPolicy group.DecisionPolicy) (types.GroupDecisionPolicy, error) {
+	switch policy := decisionPolicy.(type) {
+	case *group.ThresholdDecisionPolicy:
+		threshold, err := strconv.ParseUint(policy.Threshold, 10, 64)
+		if err != nil {
+			return types.GroupDecisionPolicy{}, err
+		}
+
+		return types.GroupDecisionPolicy{
+			Threshold:          threshold,
+			VotingPeriod:       uint64(policy.Windows.VotingPeriod.Seconds()),
+			MinExecutionPeriod: uint64(policy.Windows.MinExecutionPeriod.Seconds()),
+		}, nil
+
+	case *group.PercentageDecisionPolicy:
+		percentage, err := sdk.NewDecFromStr(policy.Percentage)
+		if err != nil {
+			return types.GroupDecisionPolicy{}, err
+		}
+
+		return types.GroupDecisionPolicy{
+			Percentage:         percentage.String(),
+			VotingPeriod:       uint64(policy.Windows.VotingPeriod.Seconds()),
+			MinExecutionPeriod: uint64(policy.Windows.MinExecutionPeriod.Seconds()),
+		}, nil
+	}
+
+	return types.GroupDecisionPolicy{}, errors.New("error while parsing decision policy")
+}
+
+// parseJSONDecisionPolicy converts the decision policy of a message stored inside a proposal into its stored representation
+func parseJSONDecisionPolicy(decisionPolicy *types.DecisionPolicy) (types.GroupDecisionPolicy, error) {
+	if decisionPolicy == nil || decisionPolicy.Windows == nil {
+		return types.GroupDecisionPolicy{}, errors.New("error while parsing decision policy")
+	}
+
+	votingPeriod, err := time.ParseDuration(decisionPolicy.Windows.VotingPeriod)
+	if err != nil {
+		return types.GroupDecisionPolicy{}, err
+	}
+
+	minExecutionPeriod, err := time.ParseDuration(decisionPolicy.Windows.MinExecutionPeriod)
+	if err != nil {
+		return types.GroupDecisionPolicy{}, err
+	}
+
+	policy := types.GroupDecisionPolicy{
+		VotingPeriod:       uint64(votingPeriod.Seconds()),
+		MinExecutionPeriod: uint64(minExecutionPeriod.Seconds()),
+	}
+
+	switch decisionPolicy.TypeURL {
+	case "/cosmos.group.v1.PercentageDecisionPolicy":
+		percentage, err := sdk.NewDecFromStr(decisionPolicy.Percentage)
+		if err != nil {
+			return types.GroupDecisionPolicy{}, err
+		}
+		policy.Percentage = percentage.String()
+
+	default:
+		policy.Threshold = decisionPolicy.Threshold
+	}
+
+	return policy, nil
+}
+
+// tallyProposal returns the status a proposal reaches under the given policy, or zero if it is still undecided.
+// A proposal is accepted as soon as its YES weight meets the policy, and rejected as soon as it can no longer do so
+func tallyProposal(policy types.GroupDecisionPolicy, yesWeight, votedWeight, totalWeight int) (group.ProposalStatus, error) {
+	if totalWeight <= 0 {
+		return 0, nil
+	}
+
+	maxYesWeight := yesWeight + totalWeight - votedWeight
+
+	if policy.Percentage != "" {
+		percentage, err := sdk.NewDecFromStr(policy.Percentage)
+		if err != nil {
+			return 0, err
+		}
+
+		total := sdk.NewDec(int64(totalWeight))
+		switch {
+		case sdk.NewDec(int64(yesWeight)).Quo(total).GTE(percentage):
+			return group.PROPOSAL_STATUS_ACCEPTED, nil
+		case sdk.NewDec(int64(maxYesWeight)).Quo(total).LT(percentage):
+			return group.PROPOSAL_STATUS_REJECTED, nil
+		}
+
+		return 0, nil
+	}
+
+	// A threshold above the total weight can be met by all the members voting YES, as in the SDK
+	threshold := int(policy.Threshold)
+	if threshold > totalWeight {
+		threshold = totalWeight
+	}
+
+	switch {
+	case yesWeight >= threshold:
+		return group.PROPOSAL_STATUS_ACCEPTED, nil
+	case maxYesWeight < threshold:
+		return group.PROPOSAL_STATUS_REJECTED, nil
+	}
+
+	return 0, nil
+}
@@ -0,0 +1,97 @@
+package history
+
+import (
+	"fmt"
+
+	"github.com/go-co-op/gocron"
+	"github.com/rs/zerolog/log"
+
+	"github.com/forbole/bdjuno/v2/modules/utils"
+)
+
+const (
+	// balanceSnapshotBatchSize is the number of accounts whose balance history is stored at once
+	balanceSnapshotBatchSize = 100
+)
+
+// RegisterPeriodicOperations implements modules.PeriodicOperationsModule
+func (m *Module) RegisterPeriodicOperations(scheduler *gocron.Scheduler) error {
+	log.Debug().Str("module", "history").Msg("setting up periodic tasks")
+
+	if _, err := scheduler.Every(1).Day().At("00:00").Do(func() {
+		utils.WatchMethod(m.snapshotAccountsBalance)
+	}); err != nil {
+		return err
+	}
+
+	if _, err := scheduler.Every(1).Minute().SingletonMode().Do(func() {
+		utils.WatchMethod(m.updateQueuedAccountsBalanceHistory)
+	}); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// updateQueuedAccountsBalanceHistory stores the balance history of the queued accounts, one batch of
+// accounts queued at the same height at a time, removing them from the queue once stored
+func (m *Module) updateQueuedAccountsBalanceHistory() error {
+	if !m.cfg.IsModuleEnabled(moduleName) {
+		return nil
+	}
+
+	for {
+		height, addresses, err := m.db.GetQueuedAccountsBalanceHistory(balanceSnapshotBatchSize)
+		if err != nil {
+			return err
+		}
+
+		if len(addresses) == 0 {
+			return nil
+		}
+
+		err = m.UpdateAccountsBalanceHistory(height, addresses)
+		if err != nil {
+			return fmt.Errorf("error while updating accounts balance history at height %d: %s", height, err)
+		}
+
+		err = m.db.DeleteQueuedAccountsBalanceHistory(height, addresses)
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// snapshotAccountsBalance stores the balance history of all the known accounts at the latest block,
+// so that portfolio charts have at least one entry per day even for inactive accounts
+func (m *Module) snapshotAccountsBalance() error {
+	if !m.cfg.IsModuleEnabled(moduleName) {
+		return nil
+	}
+
+	block, err := m.db.GetLastBlock()
+	if err != nil {
+		return fmt.Errorf("error while getting last block: %s", err)
+	}
+
+	addresses, err := m.db.GetAccounts()
+	if err != nil {
+		return fmt.Errorf("error while getting accounts: %s", err)
+	}
+
+	log.Debug().Str("module", "history").Int64("height", block.Height).Msg("snapshotting accounts balance")
+
+	for start := 0; start < len(addresses); start += balanceSnapshotBatchSize {
+		end := start + balanceSnapshotBatchSize
+		if end > len(addresses) {
+			end = len(addresses)
+		}
+
+		err = m.updateAccountsBalanceHistory(block.Height, addresses[start:end], block.Timestamp)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
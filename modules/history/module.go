@@ -7,6 +7,9 @@ import (
 	"github.com/forbole/juno/v2/types/config"
 
 	"github.com/forbole/bdjuno/v2/database"
+	banksource "github.com/forbole/bdjuno/v2/modules/bank/source"
+	distrsource "github.com/forbole/bdjuno/v2/modules/distribution/source"
+	stakingsource "github.com/forbole/bdjuno/v2/modules/staking/source"
 )
 
 const (
@@ -14,7 +17,8 @@ const (
 )
 
 var (
-	_ modules.Module = &Module{}
+	_ modules.Module                   = &Module{}
+	_ modules.PeriodicOperationsModule = &Module{}
 )
 
 // Module represents the module that allows to store historic information
@@ -24,15 +28,26 @@ type Module struct {
 	db  *database.Db
 
 	getAddresses messages.MessageAddressesParser
+
+	bankSource    banksource.Source
+	distrSource   distrsource.Source
+	stakingSource stakingsource.Source
 }
 
 // NewModule allows to build a new Module instance
-func NewModule(cfg config.ChainConfig, messagesParser messages.MessageAddressesParser, cdc codec.Codec, db *database.Db) *Module {
+func NewModule(
+	cfg config.ChainConfig, messagesParser messages.MessageAddressesParser,
+	bankSource banksource.Source, distrSource distrsource.Source, stakingSource stakingsource.Source,
+	cdc codec.Codec, db *database.Db,
+) *Module {
 	return &Module{
-		cfg:          cfg,
-		cdc:          cdc,
-		db:           db,
-		getAddresses: messagesParser,
+		cfg:           cfg,
+		cdc:           cdc,
+		db:            db,
+		getAddresses:  messagesParser,
+		bankSource:    bankSource,
+		distrSource:   distrSource,
+		stakingSource: stakingSource,
 	}
 }
 
@@ -0,0 +1,225 @@
+package history
+
+import (
+	"fmt"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/query"
+	stakingtypes "github.com/cosmos/cosmos-sdk/x/staking/types"
+	"github.com/rs/zerolog/log"
+
+	"github.com/forbole/bdjuno/v2/types"
+)
+
+// QueueAccountsBalanceHistory queues the accounts having the given addresses so that their balance history
+// at the given height is stored by the periodic operations, without querying the chain while handling messages
+func (m *Module) QueueAccountsBalanceHistory(height int64, addresses []string) error {
+	if !m.cfg.IsModuleEnabled(moduleName) {
+		return nil
+	}
+
+	return m.db.QueueAccountsBalanceHistory(height, addresses)
+}
+
+// UpdateAccountsBalanceHistory stores the balance history of the accounts having the given addresses,
+// using the timestamp of the block at the given height. Accounts whose history has already been stored
+// for the same block are skipped, as the state they would be read from is the same
+func (m *Module) UpdateAccountsBalanceHistory(height int64, addresses []string) error {
+	if !m.cfg.IsModuleEnabled(moduleName) || len(addresses) == 0 {
+		return nil
+	}
+
+	timestamp, err := m.db.GetBlockTimestamp(height)
+	if err != nil {
+		return fmt.Errorf("error while getting block timestamp: %s", err)
+	}
+
+	stored, err := m.db.GetAccountsWithBalanceHistoryAt(addresses, timestamp)
+	if err != nil {
+		return err
+	}
+
+	isStored := make(map[string]bool, len(stored))
+	for _, address := range stored {
+		isStored[address] = true
+	}
+
+	var toUpdate []string
+	for _, address := range addresses {
+		if !isStored[address] {
+			toUpdate = append(toUpdate, address)
+		}
+	}
+
+	if len(toUpdate) == 0 {
+		return nil
+	}
+
+	return m.updateAccountsBalanceHistory(height, toUpdate, timestamp)
+}
+
+func (m *Module) updateAccountsBalanceHistory(height int64, addresses []string, timestamp time.Time) error {
+	log.Debug().Str("module", "history").Int64("height", height).
+		Int("accounts", len(addresses)).Msg("updating accounts balance history")
+
+	balances, err := m.bankSource.GetBalances(addresses, height)
+	if err != nil {
+		return fmt.Errorf("error while getting account balances: %s", err)
+	}
+
+	params, err := m.stakingSource.GetParams(height)
+	if err != nil {
+		return fmt.Errorf("error while getting staking params: %s", err)
+	}
+
+	entries := make([]types.AccountBalanceHistory, len(balances))
+	for i, balance := range balances {
+		entry, err := m.getAccountBalanceHistory(height, params.BondDenom, balance, timestamp)
+		if err != nil {
+			return err
+		}
+
+		entries[i] = entry
+	}
+
+	return m.db.SaveAccountBalanceHistory(entries)
+}
+
+// getAccountBalanceHistory builds the balance history entry of the account having the given balance
+func (m *Module) getAccountBalanceHistory(height int64, bondDenom string, balance types.AccountBalance, timestamp time.Time) (types.AccountBalanceHistory, error) {
+	address := balance.Address
+
+	delegated, err := m.getDelegated(height, address)
+	if err != nil {
+		return types.AccountBalanceHistory{}, fmt.Errorf("error while getting delegations of %s: %s", address, err)
+	}
+
+	unbonding, err := m.getUnbonding(height, bondDenom, address)
+	if err != nil {
+		return types.AccountBalanceHistory{}, fmt.Errorf("error while getting unbonding delegations of %s: %s", address, err)
+	}
+
+	redelegating, err := m.getRedelegating(height, bondDenom, address)
+	if err != nil {
+		return types.AccountBalanceHistory{}, fmt.Errorf("error while getting redelegations of %s: %s", address, err)
+	}
+
+	reward, err := m.getRewards(height, address)
+	if err != nil {
+		return types.AccountBalanceHistory{}, fmt.Errorf("error while getting rewards of %s: %s", address, err)
+	}
+
+	commission, err := m.getCommission(height, address)
+	if err != nil {
+		return types.AccountBalanceHistory{}, fmt.Errorf("error while getting commission of %s: %s", address, err)
+	}
+
+	return types.NewAccountBalanceHistory(
+		address, balance.Balance, delegated, unbonding, redelegating, commission, reward, timestamp,
+	), nil
+}
+
+func (m *Module) getDelegated(height int64, address string) (sdk.Coins, error) {
+	var delegated sdk.Coins
+	var nextKey []byte
+	var stop = false
+	for !stop {
+		res, err := m.stakingSource.GetDelegationsWithPagination(height, address, &query.PageRequest{
+			Key:   nextKey,
+			Limit: 100, // Query 100 delegations at time
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		for _, delegation := range res.DelegationResponses {
+			delegated = delegated.Add(delegation.Balance)
+		}
+
+		nextKey = res.Pagination.NextKey
+		stop = len(res.Pagination.NextKey) == 0
+	}
+
+	return delegated, nil
+}
+
+func (m *Module) getUnbonding(height int64, bondDenom, address string) (sdk.Coins, error) {
+	var unbonding sdk.Coins
+	var nextKey []byte
+	var stop = false
+	for !stop {
+		res, err := m.stakingSource.GetUnbondingDelegations(height, address, &query.PageRequest{
+			Key:   nextKey,
+			Limit: 100, // Query 100 unbonding delegations at time
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		for _, unbondingDelegation := range res.UnbondingResponses {
+			for _, entry := range unbondingDelegation.Entries {
+				unbonding = unbonding.Add(sdk.NewCoin(bondDenom, entry.Balance))
+			}
+		}
+
+		nextKey = res.Pagination.NextKey
+		stop = len(res.Pagination.NextKey) == 0
+	}
+
+	return unbonding, nil
+}
+
+func (m *Module) getRedelegating(height int64, bondDenom, address string) (sdk.Coins, error) {
+	var redelegating sdk.Coins
+	var nextKey []byte
+	var stop = false
+	for !stop {
+		res, err := m.stakingSource.GetRedelegations(height, &stakingtypes.QueryRedelegationsRequest{
+			DelegatorAddr: address,
+			Pagination: &query.PageRequest{
+				Key:   nextKey,
+				Limit: 100, // Query 100 redelegations at time
+			},
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		for _, redelegation := range res.RedelegationResponses {
+			for _, entry := range redelegation.Entries {
+				redelegating = redelegating.Add(sdk.NewCoin(bondDenom, entry.Balance))
+			}
+		}
+
+		nextKey = res.Pagination.NextKey
+		stop = len(res.Pagination.NextKey) == 0
+	}
+
+	return redelegating, nil
+}
+
+func (m *Module) getRewards(height int64, address string) (sdk.DecCoins, error) {
+	rewards, err := m.distrSource.DelegatorTotalRewards(address, height)
+	if err != nil {
+		return nil, err
+	}
+
+	var reward sdk.DecCoins
+	for _, r := range rewards {
+		reward = reward.Add(r.Reward...)
+	}
+
+	return reward, nil
+}
+
+// getCommission returns the commission of the validator operated by the given account, which is
+// empty when the account does not operate any validator
+func (m *Module) getCommission(height int64, address string) (sdk.DecCoins, error) {
+	accAddr, err := sdk.AccAddressFromBech32(address)
+	if err != nil {
+		return nil, err
+	}
+
+	return m.distrSource.ValidatorCommission(sdk.ValAddress(accAddr).String(), height)
+}
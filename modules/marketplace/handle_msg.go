@@ -9,6 +9,7 @@ import (
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/forbole/bdjuno/v2/database"
 	utils "github.com/forbole/bdjuno/v2/modules/utils"
+	"github.com/forbole/bdjuno/v2/types"
 	generalUtils "github.com/forbole/bdjuno/v2/utils"
 	juno "github.com/forbole/juno/v2/types"
 	"github.com/rs/zerolog/log"
@@ -30,13 +31,13 @@ func (m *Module) HandleMsg(index int, msg sdk.Msg, tx *juno.Tx) error {
 	case *marketplaceTypes.MsgBuyNft:
 		return m.handleMsgBuyNft(index, tx, cosmosMsg)
 	case *marketplaceTypes.MsgRemoveNft:
-		return m.handleMsgRemoveNft(cosmosMsg)
+		return m.handleMsgRemoveNft(index, tx, cosmosMsg)
 	case *marketplaceTypes.MsgVerifyCollection:
 		return m.handleMsgVerifyCollection(cosmosMsg)
 	case *marketplaceTypes.MsgUnverifyCollection:
 		return m.handleMsgUnverifyCollection(cosmosMsg)
 	case *marketplaceTypes.MsgUpdatePrice:
-		return m.handleMsgUpdatePrice(cosmosMsg)
+		return m.handleMsgUpdatePrice(index, tx, cosmosMsg)
 	case *marketplaceTypes.MsgUpdateRoyalties:
 		return m.handleMsgUpdateRoyalties(cosmosMsg)
 	case *marketplaceTypes.MsgCreateCollection:
@@ -65,7 +66,11 @@ func (m *Module) handleMsgPublishCollection(index int, tx *juno.Tx, msg *marketp
 		return err
 	}
 
-	return m.db.SaveMarketplaceCollection(tx.TxHash, collectionID, msg.DenomId, string(mintRoyaltiesJSON), string(resaleRoyaltiesJSON), msg.Creator, false)
+	if err := m.db.SaveMarketplaceCollection(tx.TxHash, collectionID, msg.DenomId, string(mintRoyaltiesJSON), string(resaleRoyaltiesJSON), msg.Creator, false); err != nil {
+		return err
+	}
+
+	return m.db.SaveMarketplaceCollectionRoyalties(collectionID, convertRoyalties(msg.MintRoyalties), convertRoyalties(msg.ResaleRoyalties))
 }
 
 func (m *Module) handleMsgPublishNft(index int, tx *juno.Tx, msg *marketplaceTypes.MsgPublishNft) error {
@@ -82,6 +87,11 @@ func (m *Module) handleMsgPublishNft(index int, tx *juno.Tx, msg *marketplaceTyp
 		return err
 	}
 
+	timestamp, err := generalUtils.ISO8601ToTimestamp(tx.Timestamp)
+	if err != nil {
+		return err
+	}
+
 	return m.db.ExecuteTx(func(dbTx *database.DbTx) error {
 		err := m.db.CheckIfNftExists(tokenID, msg.DenomId)
 
@@ -89,7 +99,11 @@ func (m *Module) handleMsgPublishNft(index int, tx *juno.Tx, msg *marketplaceTyp
 			return err
 		}
 
-		return dbTx.ListNft(tx.TxHash, nftID, tokenID, msg.DenomId, msg.Price.Amount.String())
+		if err := dbTx.ListNft(tx.TxHash, nftID, tokenID, msg.DenomId, msg.Price.Amount.String()); err != nil {
+			return err
+		}
+
+		return dbTx.SaveMarketplaceNftListingEvent(tx.TxHash, index, tx.Height, nftID, types.ListingEventListed, msg.Creator, uint64(timestamp))
 	})
 }
 
@@ -112,14 +126,31 @@ func (m *Module) handleMsgMintNft(index int, tx *juno.Tx, msg *marketplaceTypes.
 		return err
 	}
 
+	cudosPrice, err := m.getCudosPrice(int64(timestamp))
+	if err != nil {
+		return err
+	}
+
 	dataJSON, dataText := utils.GetData(msg.Data)
 
 	return m.db.ExecuteTx(func(dbTx *database.DbTx) error {
+		owner, _, err := dbTx.GetNFTOwner(tokenIDStr, msg.DenomId)
+		if err != nil {
+			return err
+		}
+
 		if err := dbTx.SaveNFT(tx.TxHash, tokenID, msg.DenomId, msg.Name, msg.Uri, utils.SanitizeUTF8(dataJSON), dataText, msg.Recipient, msg.Creator, ""); err != nil {
 			return err
 		}
 
-		if err := dbTx.SaveMarketplaceNftMint(tx.TxHash, tokenID, msg.Recipient, msg.DenomId, msg.Price.Amount.String(), uint64(timestamp), m.cudosPrice.USD, m.cudosPrice.BTC); err != nil {
+		// The nft is already stored when re-parsing the mint
+		if owner == "" {
+			if err := dbTx.UpdateDenomStatisticsOnMint(msg.DenomId, msg.Recipient, tx.Height); err != nil {
+				return err
+			}
+		}
+
+		if err := dbTx.SaveMarketplaceNftMint(tx.TxHash, tokenID, msg.Recipient, msg.DenomId, msg.Price.Amount.String(), uint64(timestamp), cudosPrice.USD, cudosPrice.BTC); err != nil {
 			return err
 		}
 
@@ -127,6 +158,10 @@ func (m *Module) handleMsgMintNft(index int, tx *juno.Tx, msg *marketplaceTypes.
 			return err
 		}
 
+		if err := m.saveMintPayouts(dbTx, tx.TxHash, tokenID, msg.DenomId, msg.Price.Amount.String(), uint64(timestamp)); err != nil {
+			return err
+		}
+
 		return dbTx.UpdateNFTHistory(tx.TxHash, tokenID, msg.DenomId, "0x0", msg.Recipient, uint64(timestamp))
 	})
 }
@@ -150,8 +185,21 @@ func (m *Module) handleMsgBuyNft(index int, tx *juno.Tx, msg *marketplaceTypes.M
 	denomIDStr := utils.GetValueFromLogs(uint32(index), tx.Logs, marketplaceTypes.EventBuyNftType, marketplaceTypes.AttributeKeyDenomID)
 	fromOwner := utils.GetValueFromLogs(uint32(index), tx.Logs, marketplaceTypes.EventBuyNftType, marketplaceTypes.AttributeKeyOwner)
 
+	cudosPrice, err := m.getCudosPrice(int64(timestamp))
+	if err != nil {
+		return err
+	}
+
 	return m.db.ExecuteTx(func(dbTx *database.DbTx) error {
-		if err := dbTx.SaveMarketplaceNftBuy(tx.TxHash, msg.Id, msg.Creator, uint64(timestamp), m.cudosPrice.USD, m.cudosPrice.BTC); err != nil {
+		if err := m.saveBuyPayouts(dbTx, tx.TxHash, msg.Id, uint64(timestamp)); err != nil {
+			return err
+		}
+
+		if err := dbTx.SaveMarketplaceNftBuy(tx.TxHash, msg.Id, msg.Creator, uint64(timestamp), cudosPrice.USD, cudosPrice.BTC); err != nil {
+			return err
+		}
+
+		if err := dbTx.SaveMarketplaceNftListingEvent(tx.TxHash, index, tx.Height, msg.Id, types.ListingEventSold, msg.Creator, uint64(timestamp)); err != nil {
 			return err
 		}
 
@@ -163,10 +211,21 @@ func (m *Module) handleMsgBuyNft(index int, tx *juno.Tx, msg *marketplaceTypes.M
 	})
 }
 
-func (m *Module) handleMsgRemoveNft(msg *marketplaceTypes.MsgRemoveNft) error {
+func (m *Module) handleMsgRemoveNft(index int, tx *juno.Tx, msg *marketplaceTypes.MsgRemoveNft) error {
 	log.Debug().Str("module", "marketplace").Uint64("ID", msg.Id).Msg("handling message remove nft")
 
-	return m.db.UnlistNft(msg.Id)
+	timestamp, err := generalUtils.ISO8601ToTimestamp(tx.Timestamp)
+	if err != nil {
+		return err
+	}
+
+	return m.db.ExecuteTx(func(dbTx *database.DbTx) error {
+		if err := dbTx.SaveMarketplaceNftListingEvent(tx.TxHash, index, tx.Height, msg.Id, types.ListingEventDelisted, msg.Creator, uint64(timestamp)); err != nil {
+			return err
+		}
+
+		return dbTx.UnlistNft(msg.Id)
+	})
 }
 
 func (m *Module) handleMsgVerifyCollection(msg *marketplaceTypes.MsgVerifyCollection) error {
@@ -181,10 +240,21 @@ func (m *Module) handleMsgUnverifyCollection(msg *marketplaceTypes.MsgUnverifyCo
 	return m.db.SetMarketplaceCollectionVerificationStatus(msg.Id, false)
 }
 
-func (m *Module) handleMsgUpdatePrice(msg *marketplaceTypes.MsgUpdatePrice) error {
+func (m *Module) handleMsgUpdatePrice(index int, tx *juno.Tx, msg *marketplaceTypes.MsgUpdatePrice) error {
 	log.Debug().Str("module", "marketplace").Uint64("ID", msg.Id).Str("Price", msg.Price.Amount.String()).Msg("handling message update price")
 
-	return m.db.SetMarketplaceNFTPrice(msg.Id, msg.Price.Amount.String())
+	timestamp, err := generalUtils.ISO8601ToTimestamp(tx.Timestamp)
+	if err != nil {
+		return err
+	}
+
+	return m.db.ExecuteTx(func(dbTx *database.DbTx) error {
+		if err := dbTx.SetMarketplaceNFTPrice(msg.Id, msg.Price.Amount.String()); err != nil {
+			return err
+		}
+
+		return dbTx.SaveMarketplaceNftListingEvent(tx.TxHash, index, tx.Height, msg.Id, types.ListingEventPriceUpdated, msg.Creator, uint64(timestamp))
+	})
 }
 
 func (m *Module) handleMsgUpdateRoyalties(msg *marketplaceTypes.MsgUpdateRoyalties) error {
@@ -200,7 +270,11 @@ func (m *Module) handleMsgUpdateRoyalties(msg *marketplaceTypes.MsgUpdateRoyalti
 		return err
 	}
 
-	return m.db.SetMarketplaceCollectionRoyalties(msg.Id, string(mintRoyaltiesJSON), string(resaleRoyaltiesJSON))
+	if err := m.db.SetMarketplaceCollectionRoyalties(msg.Id, string(mintRoyaltiesJSON), string(resaleRoyaltiesJSON)); err != nil {
+		return err
+	}
+
+	return m.db.SaveMarketplaceCollectionRoyalties(msg.Id, convertRoyalties(msg.MintRoyalties), convertRoyalties(msg.ResaleRoyalties))
 }
 
 func (m *Module) handleMsgCreateCollection(index int, tx *juno.Tx, msg *marketplaceTypes.MsgCreateCollection) error {
@@ -222,5 +296,9 @@ func (m *Module) handleMsgCreateCollection(index int, tx *juno.Tx, msg *marketpl
 		return err
 	}
 
-	return m.db.SaveMarketplaceCollection(tx.TxHash, collectionID, msg.Id, string(mintRoyaltiesJSON), string(resaleRoyaltiesJSON), msg.Creator, msg.Verified)
+	if err := m.db.SaveMarketplaceCollection(tx.TxHash, collectionID, msg.Id, string(mintRoyaltiesJSON), string(resaleRoyaltiesJSON), msg.Creator, msg.Verified); err != nil {
+		return err
+	}
+
+	return m.db.SaveMarketplaceCollectionRoyalties(collectionID, convertRoyalties(msg.MintRoyalties), convertRoyalties(msg.ResaleRoyalties))
 }
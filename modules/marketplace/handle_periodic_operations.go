@@ -1,8 +1,9 @@
 package marketplace
 
 import (
+	"time"
+
 	"github.com/forbole/bdjuno/v2/modules/utils"
-	"github.com/forbole/bdjuno/v2/types"
 
 	"github.com/go-co-op/gocron"
 	"github.com/rs/zerolog/log"
@@ -11,8 +12,8 @@ import (
 func (m *Module) RegisterPeriodicOperations(scheduler *gocron.Scheduler) error {
 	log.Debug().Str("module", "marketplace").Msg("setting up periodic tasks")
 
-	if _, err := scheduler.Every(5).Minute().Do(func() {
-		utils.WatchMethod(m.fetchCudosPrice)
+	if _, err := scheduler.Every(10).Minutes().Do(func() {
+		utils.WatchMethod(m.updateCollectionsStatistics)
 	}); err != nil {
 		return err
 	}
@@ -20,17 +21,8 @@ func (m *Module) RegisterPeriodicOperations(scheduler *gocron.Scheduler) error {
 	return nil
 }
 
-func (m *Module) fetchCudosPrice() error {
-	usdPrice, err := m.ccc.GetCUDOSPrice("usd")
-	if err != nil {
-		return err
-	}
+func (m *Module) updateCollectionsStatistics() error {
+	log.Debug().Str("module", "marketplace").Str("operation", "collections statistics").Msg("updating collections statistics")
 
-	btcPrice, err := m.ccc.GetCUDOSPrice("btc")
-	if err != nil {
-		return err
-	}
-
-	m.cudosPrice = types.CudosPrice{USD: usdPrice, BTC: btcPrice}
-	return nil
+	return m.db.SaveMarketplaceCollectionsStatistics(time.Now().Unix())
 }
@@ -6,7 +6,6 @@ import (
 
 	"github.com/forbole/bdjuno/v2/client/cryptoCompare"
 	"github.com/forbole/bdjuno/v2/database"
-	"github.com/forbole/bdjuno/v2/types"
 )
 
 var (
@@ -17,10 +16,9 @@ var (
 
 // Module represents the nft module
 type Module struct {
-	cdc        codec.Codec
-	db         *database.Db
-	ccc        *cryptoCompare.CryptoCompareClient
-	cudosPrice types.CudosPrice
+	cdc codec.Codec
+	db  *database.Db
+	ccc *cryptoCompare.CryptoCompareClient
 }
 
 // NewModule returns a new Module instance
@@ -0,0 +1,67 @@
+package marketplace
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/forbole/bdjuno/v2/types"
+)
+
+const (
+	cudosUnitName = "cudos"
+	btcUnitName   = "btc"
+
+	// priceHistoryMaxAge is the oldest a stored price can be to be used for a sale,
+	// it matches the interval in which the pricefeed module stores the price history
+	priceHistoryMaxAge = time.Hour
+)
+
+// getCudosPrice returns the USD and BTC prices of CUDOS at the given unix timestamp.
+// Prices are read from the stored price history when available, otherwise they are
+// fetched from the historical CryptoCompare APIs, so that re-parsing old blocks always
+// results in the same values. Prices that cannot be fetched are left empty so that they are saved as NULL
+func (m *Module) getCudosPrice(timestamp int64) (types.CudosPrice, error) {
+	t := time.Unix(timestamp, 0).UTC()
+
+	cudosUSD, found, err := m.db.GetTokenPriceHistoryAt(cudosUnitName, t, priceHistoryMaxAge)
+	if err != nil {
+		return types.CudosPrice{}, err
+	}
+
+	if !found {
+		return types.CudosPrice{
+			USD: m.getHistoricalCudosPrice("usd", t),
+			BTC: m.getHistoricalCudosPrice("btc", t),
+		}, nil
+	}
+
+	price := types.CudosPrice{USD: fmt.Sprintf("%g", cudosUSD)}
+
+	btcUSD, found, err := m.db.GetTokenPriceHistoryAt(btcUnitName, t, priceHistoryMaxAge)
+	if err != nil {
+		return types.CudosPrice{}, err
+	}
+
+	if found && btcUSD > 0 {
+		price.BTC = fmt.Sprintf("%g", cudosUSD/btcUSD)
+		return price, nil
+	}
+
+	price.BTC = m.getHistoricalCudosPrice("btc", t)
+	return price, nil
+}
+
+// getHistoricalCudosPrice returns the CUDOS price in the given currency at the given time as fetched
+// from the historical CryptoCompare APIs, or an empty string if it cannot be fetched
+func (m *Module) getHistoricalCudosPrice(currency string, t time.Time) string {
+	price, err := m.ccc.GetHistoricalCUDOSPrice(currency, t)
+	if err != nil {
+		log.Warn().Str("module", "marketplace").Err(err).Str("currency", currency).Time("timestamp", t).
+			Msg("error while getting historical CUDOS price, storing sale without it")
+		return ""
+	}
+
+	return price
+}
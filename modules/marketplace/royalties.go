@@ -0,0 +1,104 @@
+package marketplace
+
+import (
+	"fmt"
+
+	marketplaceTypes "github.com/CudoVentures/cudos-node/x/marketplace/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/forbole/bdjuno/v2/database"
+	"github.com/forbole/bdjuno/v2/types"
+)
+
+// saveBuyPayouts stores the resale royalties and the seller proceeds of the listed nft with the given id
+func (m *Module) saveBuyPayouts(dbTx *database.DbTx, txHash string, id uint64, timestamp uint64) error {
+	tokenID, denomID, price, seller, err := dbTx.GetListedMarketplaceNft(id)
+	if err != nil {
+		return err
+	}
+
+	royalties, err := dbTx.GetMarketplaceRoyalties(denomID, types.RoyaltyTypeResale)
+	if err != nil {
+		return err
+	}
+
+	payouts, err := computePayouts(price, seller, royalties, types.PayoutTypeResaleRoyalty)
+	if err != nil {
+		return err
+	}
+
+	return dbTx.SaveMarketplaceNftPayouts(txHash, tokenID, denomID, timestamp, payouts)
+}
+
+// saveMintPayouts stores the mint royalties and the denom creator proceeds of a minted nft
+func (m *Module) saveMintPayouts(dbTx *database.DbTx, txHash string, tokenID uint64, denomID string, price string, timestamp uint64) error {
+	royalties, err := dbTx.GetMarketplaceRoyalties(denomID, types.RoyaltyTypeMint)
+	if err != nil {
+		return err
+	}
+
+	// Without mint royalties the chain keeps the whole price inside the marketplace module account
+	if len(royalties) == 0 {
+		return nil
+	}
+
+	creator, err := dbTx.GetDenomOwner(denomID)
+	if err != nil {
+		return err
+	}
+
+	payouts, err := computePayouts(price, creator, royalties, types.PayoutTypeMintRoyalty)
+	if err != nil {
+		return err
+	}
+
+	return dbTx.SaveMarketplaceNftPayouts(txHash, tokenID, denomID, timestamp, payouts)
+}
+
+// convertRoyalties converts the given chain royalties into their database representation
+func convertRoyalties(royalties []marketplaceTypes.Royalty) []types.Royalty {
+	converted := make([]types.Royalty, len(royalties))
+	for i, r := range royalties {
+		converted[i] = types.Royalty{Address: r.Address, Percent: r.Percent.String()}
+	}
+	return converted
+}
+
+// computePayouts splits the given price between the royalty receivers and the seller
+// the same way the marketplace module distributes the royalties on chain.
+// Receivers listed more than once get a single payout with the sum of their royalties
+func computePayouts(price string, seller string, royalties []types.Royalty, royaltyType string) ([]types.MarketplacePayout, error) {
+	priceAmount, ok := sdk.NewIntFromString(price)
+	if !ok {
+		return nil, fmt.Errorf("invalid price %s", price)
+	}
+
+	payouts := []types.MarketplacePayout{}
+	payoutIndexes := map[string]int{}
+	amountLeft := priceAmount
+
+	for _, r := range royalties {
+		percent, err := sdk.NewDecFromStr(r.Percent)
+		if err != nil {
+			return nil, fmt.Errorf("invalid royalty percent %s: %s", r.Percent, err)
+		}
+
+		portion := priceAmount.ToDec().Mul(percent).Quo(sdk.NewDec(100)).TruncateInt()
+		amountLeft = amountLeft.Sub(portion)
+
+		if i, ok := payoutIndexes[r.Address]; ok {
+			amount, _ := sdk.NewIntFromString(payouts[i].Amount)
+			payouts[i].Amount = amount.Add(portion).String()
+			continue
+		}
+
+		payoutIndexes[r.Address] = len(payouts)
+		payouts = append(payouts, types.MarketplacePayout{Address: r.Address, Amount: portion.String(), Type: royaltyType})
+	}
+
+	if amountLeft.IsPositive() {
+		payouts = append(payouts, types.MarketplacePayout{Address: seller, Amount: amountLeft.String(), Type: types.PayoutTypeSeller})
+	}
+
+	return payouts, nil
+}
@@ -0,0 +1,73 @@
+package marketplace
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/forbole/bdjuno/v2/types"
+)
+
+func TestComputePayouts(t *testing.T) {
+	for testName, tc := range map[string]struct {
+		price     string
+		royalties []types.Royalty
+		want      []types.MarketplacePayout
+		wantErr   bool
+	}{
+		"no royalties": {
+			price: "1000",
+			want:  []types.MarketplacePayout{{Address: "seller", Amount: "1000", Type: types.PayoutTypeSeller}},
+		},
+		"royalties are truncated": {
+			price: "1000",
+			royalties: []types.Royalty{
+				{Address: "creator", Percent: "10.05"},
+				{Address: "artist", Percent: "2.5"},
+			},
+			want: []types.MarketplacePayout{
+				{Address: "creator", Amount: "100", Type: types.PayoutTypeResaleRoyalty},
+				{Address: "artist", Amount: "25", Type: types.PayoutTypeResaleRoyalty},
+				{Address: "seller", Amount: "875", Type: types.PayoutTypeSeller},
+			},
+		},
+		"receiver listed more than once": {
+			price: "1000",
+			royalties: []types.Royalty{
+				{Address: "creator", Percent: "10"},
+				{Address: "artist", Percent: "5"},
+				{Address: "creator", Percent: "2.5"},
+			},
+			want: []types.MarketplacePayout{
+				{Address: "creator", Amount: "125", Type: types.PayoutTypeResaleRoyalty},
+				{Address: "artist", Amount: "50", Type: types.PayoutTypeResaleRoyalty},
+				{Address: "seller", Amount: "825", Type: types.PayoutTypeSeller},
+			},
+		},
+		"royalties take the whole price": {
+			price:     "1000",
+			royalties: []types.Royalty{{Address: "creator", Percent: "100"}},
+			want:      []types.MarketplacePayout{{Address: "creator", Amount: "1000", Type: types.PayoutTypeResaleRoyalty}},
+		},
+		"invalid price": {
+			price:   "abc",
+			wantErr: true,
+		},
+		"invalid percent": {
+			price:     "1000",
+			royalties: []types.Royalty{{Address: "creator", Percent: "ten"}},
+			wantErr:   true,
+		},
+	} {
+		t.Run(testName, func(t *testing.T) {
+			have, err := computePayouts(tc.price, "seller", tc.royalties, types.PayoutTypeResaleRoyalty)
+			if tc.wantErr {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tc.want, have)
+		})
+	}
+}
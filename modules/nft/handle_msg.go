@@ -9,6 +9,7 @@ import (
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/forbole/bdjuno/v2/database"
 	utils "github.com/forbole/bdjuno/v2/modules/utils"
+	"github.com/forbole/bdjuno/v2/types"
 	generalUtils "github.com/forbole/bdjuno/v2/utils"
 	juno "github.com/forbole/juno/v2/types"
 	"github.com/rs/zerolog/log"
@@ -24,15 +25,21 @@ func (m *Module) HandleMsg(index int, msg sdk.Msg, tx *juno.Tx) error {
 	case *nftTypes.MsgIssueDenom:
 		return m.handleMsgIssueDenom(tx, cosmosMsg)
 	case *nftTypes.MsgTransferDenom:
-		return m.handleMsgTransferDenom(cosmosMsg)
+		return m.handleMsgTransferDenom(index, tx, cosmosMsg)
 	case *nftTypes.MsgMintNFT:
 		return m.handleMsgMintNFT(index, tx, cosmosMsg)
 	case *nftTypes.MsgEditNFT:
-		return m.handleMsgEditNFT(cosmosMsg)
+		return m.handleMsgEditNFT(index, tx, cosmosMsg)
 	case *nftTypes.MsgTransferNft:
 		return m.handleMsgTransferNFT(tx, cosmosMsg)
 	case *nftTypes.MsgBurnNFT:
 		return m.handleMsgBurnNFT(index, tx, cosmosMsg)
+	case *nftTypes.MsgApproveNft:
+		return m.handleMsgApproveNFT(tx, cosmosMsg)
+	case *nftTypes.MsgApproveAllNft:
+		return m.handleMsgApproveAllNFT(tx, cosmosMsg)
+	case *nftTypes.MsgRevokeNft:
+		return m.handleMsgRevokeNFT(cosmosMsg)
 	case *marketplaceTypes.MsgCreateCollection:
 		return m.handleMsgCreateCollection(tx, cosmosMsg)
 	case *marketplaceTypes.MsgBuyNft:
@@ -51,10 +58,17 @@ func (m *Module) handleMsgIssueDenom(tx *juno.Tx, msg *nftTypes.MsgIssueDenom) e
 		msg.Traits, msg.Minter, msg.Description, dataText, utils.SanitizeUTF8(dataJSON))
 }
 
-func (m *Module) handleMsgTransferDenom(msg *nftTypes.MsgTransferDenom) error {
+func (m *Module) handleMsgTransferDenom(index int, tx *juno.Tx, msg *nftTypes.MsgTransferDenom) error {
 	log.Debug().Str("module", "nft").Str("denomId", msg.Id).Msg("handling message transfer denom")
 
-	return m.db.UpdateDenom(msg.Id, msg.Recipient)
+	timestamp, err := generalUtils.ISO8601ToTimestamp(tx.Timestamp)
+	if err != nil {
+		return err
+	}
+
+	return m.db.ExecuteTx(func(dbTx *database.DbTx) error {
+		return dbTx.UpdateDenom(tx.TxHash, index, tx.Height, msg.Id, msg.Recipient, uint64(timestamp))
+	})
 }
 
 func (m *Module) handleMsgMintNFT(index int, tx *juno.Tx, msg *nftTypes.MsgMintNFT) error {
@@ -78,20 +92,76 @@ func (m *Module) handleMsgMintNFT(index int, tx *juno.Tx, msg *nftTypes.MsgMintN
 	dataJSON, dataText := utils.GetData(msg.Data)
 
 	return m.db.ExecuteTx(func(dbTx *database.DbTx) error {
+		owner, _, err := dbTx.GetNFTOwner(tokenIDStr, msg.DenomId)
+		if err != nil {
+			return err
+		}
+
 		if error := dbTx.UpdateNFTHistory(tx.TxHash, tokenID, msg.DenomId, "0x0", msg.Sender, uint64(timestamp)); error != nil {
 			return error
 		}
 
-		return dbTx.SaveNFT(tx.TxHash, tokenID, msg.DenomId, msg.Name, msg.URI, utils.SanitizeUTF8(dataJSON), dataText, msg.Recipient, msg.Sender, msg.ContractAddressSigner)
+		if err := dbTx.SaveNFT(tx.TxHash, tokenID, msg.DenomId, msg.Name, msg.URI, utils.SanitizeUTF8(dataJSON), dataText, msg.Recipient, msg.Sender, msg.ContractAddressSigner); err != nil {
+			return err
+		}
+
+		// The nft is already stored when re-parsing the mint
+		if owner != "" {
+			return nil
+		}
+
+		return dbTx.UpdateDenomStatisticsOnMint(msg.DenomId, msg.Recipient, tx.Height)
 	})
 }
 
-func (m *Module) handleMsgEditNFT(msg *nftTypes.MsgEditNFT) error {
+func (m *Module) handleMsgEditNFT(index int, tx *juno.Tx, msg *nftTypes.MsgEditNFT) error {
 	log.Debug().Str("module", "nft").Str("denomId", msg.DenomId).Str("tokenId", msg.Id).Msg("handling message edit nft")
 
-	dataJSON, dataText := utils.GetData(msg.Data)
+	tokenID, err := strconv.ParseUint(msg.Id, 10, 64)
+	if err != nil {
+		return err
+	}
 
-	return m.db.UpdateNFT(msg.Id, msg.DenomId, msg.Name, msg.URI, utils.SanitizeUTF8(dataJSON), dataText)
+	return m.db.ExecuteTx(func(dbTx *database.DbTx) error {
+		previous, err := dbTx.GetNFTMetadata(msg.Id, msg.DenomId)
+		if err != nil {
+			return fmt.Errorf("error while getting nft %s/%s: %s", msg.DenomId, msg.Id, err)
+		}
+
+		// Fields set to the do-not-modify placeholder are left untouched by the chain
+		updated := previous
+		if nftTypes.Modified(msg.Name) {
+			updated.Name = msg.Name
+		}
+
+		if nftTypes.Modified(msg.URI) {
+			updated.URI = msg.URI
+		}
+
+		if nftTypes.Modified(msg.Data) {
+			dataJSON, dataText := utils.GetData(msg.Data)
+			updated.DataJSON, updated.DataText = utils.SanitizeUTF8(dataJSON), dataText
+		}
+
+		if err := dbTx.SaveNFTRevision(types.NftRevision{
+			TokenID:  tokenID,
+			DenomID:  msg.DenomId,
+			Previous: previous,
+			New:      updated,
+			Editor:   msg.Sender,
+			Height:   tx.Height,
+			TxHash:   tx.TxHash,
+			MsgIndex: index,
+		}); err != nil {
+			return err
+		}
+
+		if err := dbTx.UpdateNFT(msg.Id, msg.DenomId, updated.Name, updated.URI, updated.DataJSON, updated.DataText); err != nil {
+			return err
+		}
+
+		return dbTx.ResetNftMetadata(msg.Id, msg.DenomId)
+	})
 }
 
 func (m *Module) handleMsgTransferNFT(tx *juno.Tx, msg *nftTypes.MsgTransferNft) error {
@@ -108,11 +178,24 @@ func (m *Module) handleMsgTransferNFT(tx *juno.Tx, msg *nftTypes.MsgTransferNft)
 	}
 
 	return m.db.ExecuteTx(func(dbTx *database.DbTx) error {
+		owner, burned, err := dbTx.GetNFTOwner(msg.TokenId, msg.DenomId)
+		if err != nil {
+			return err
+		}
+
 		if error := dbTx.UpdateNFTHistory(tx.TxHash, tokenID, msg.DenomId, msg.Sender, msg.To, uint64(timestamp)); error != nil {
 			return error
 		}
 
-		return dbTx.UpdateNFTOwner(msg.TokenId, msg.DenomId, msg.To)
+		if err := dbTx.DeleteNFTApprovals(msg.TokenId, msg.DenomId); err != nil {
+			return err
+		}
+
+		if err := dbTx.UpdateNFTOwner(msg.TokenId, msg.DenomId, msg.To); err != nil {
+			return err
+		}
+
+		return updateDenomStatisticsOnTransfer(dbTx, msg.DenomId, owner, burned, msg.To, tx.Height)
 	})
 }
 
@@ -135,14 +218,50 @@ func (m *Module) handleMsgBurnNFT(index int, tx *juno.Tx, msg *nftTypes.MsgBurnN
 	}
 
 	return m.db.ExecuteTx(func(dbTx *database.DbTx) error {
+		owner, burned, err := dbTx.GetNFTOwner(msg.Id, msg.DenomId)
+		if err != nil {
+			return err
+		}
+
 		if error := dbTx.UpdateNFTHistory(tx.TxHash, tokenID, msg.DenomId, msg.Sender, "0x0", uint64(timestamp)); error != nil {
 			return error
 		}
 
-		return dbTx.BurnNFT(msg.Id, msg.DenomId)
+		if err := dbTx.DeleteNFTApprovals(msg.Id, msg.DenomId); err != nil {
+			return err
+		}
+
+		if err := dbTx.BurnNFT(msg.Id, msg.DenomId); err != nil {
+			return err
+		}
+
+		// The nft is already burned when re-parsing the burn
+		if owner == "" || burned {
+			return nil
+		}
+
+		return dbTx.UpdateDenomStatisticsOnBurn(msg.DenomId, owner, tx.Height)
 	})
 }
 
+func (m *Module) handleMsgApproveNFT(tx *juno.Tx, msg *nftTypes.MsgApproveNft) error {
+	log.Debug().Str("module", "nft").Str("denomId", msg.DenomId).Str("tokenId", msg.Id).Str("approved", msg.ApprovedAddress).Msg("handling message approve nft")
+
+	return m.db.SaveNFTApproval(tx.TxHash, tx.Height, msg.Id, msg.DenomId, msg.ApprovedAddress)
+}
+
+func (m *Module) handleMsgApproveAllNFT(tx *juno.Tx, msg *nftTypes.MsgApproveAllNft) error {
+	log.Debug().Str("module", "nft").Str("operator", msg.Operator).Bool("approved", msg.Approved).Msg("handling message approve all nft")
+
+	return m.db.SaveNFTOperator(tx.TxHash, tx.Height, msg.Sender, msg.Operator, msg.Approved)
+}
+
+func (m *Module) handleMsgRevokeNFT(msg *nftTypes.MsgRevokeNft) error {
+	log.Debug().Str("module", "nft").Str("denomId", msg.DenomId).Str("tokenId", msg.TokenId).Str("revoked", msg.AddressToRevoke).Msg("handling message revoke nft")
+
+	return m.db.DeleteNFTApproval(msg.TokenId, msg.DenomId, msg.AddressToRevoke)
+}
+
 func (m *Module) handleMsgCreateCollection(tx *juno.Tx, msg *marketplaceTypes.MsgCreateCollection) error {
 	log.Debug().Str("module", "nft").Str("denomId", msg.Id).Msg("handling message create collection")
 
@@ -166,6 +285,30 @@ func (m *Module) handleMsgBuyNft(index int, tx *juno.Tx, msg *marketplaceTypes.M
 	}
 
 	return m.db.ExecuteTx(func(dbTx *database.DbTx) error {
-		return dbTx.UpdateNFTOwner(tokenID, denomID, msg.Creator)
+		owner, burned, err := dbTx.GetNFTOwner(tokenID, denomID)
+		if err != nil {
+			return err
+		}
+
+		if err := dbTx.DeleteNFTApprovals(tokenID, denomID); err != nil {
+			return err
+		}
+
+		if err := dbTx.UpdateNFTOwner(tokenID, denomID, msg.Creator); err != nil {
+			return err
+		}
+
+		return updateDenomStatisticsOnTransfer(dbTx, denomID, owner, burned, msg.Creator, tx.Height)
 	})
 }
+
+// updateDenomStatisticsOnTransfer updates the statistics of the given denom after one of its nfts, previously owned
+// by owner, has been transferred to the given recipient. Transfers already handled, which are found when re-parsing
+// them, are ignored since the nft is already owned by the recipient
+func updateDenomStatisticsOnTransfer(dbTx *database.DbTx, denomID, owner string, burned bool, recipient string, height int64) error {
+	if owner == "" || burned || owner == recipient {
+		return nil
+	}
+
+	return dbTx.UpdateDenomStatisticsOnTransfer(denomID, owner, recipient, height)
+}
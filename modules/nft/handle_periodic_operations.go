@@ -0,0 +1,74 @@
+package nft
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/go-co-op/gocron"
+	"github.com/rs/zerolog/log"
+
+	"github.com/forbole/bdjuno/v2/modules/utils"
+	"github.com/forbole/bdjuno/v2/types"
+)
+
+// RegisterPeriodicOperations implements modules.PeriodicOperationsModule
+func (m *Module) RegisterPeriodicOperations(scheduler *gocron.Scheduler) error {
+	log.Debug().Str("module", "nft").Msg("setting up periodic tasks")
+
+	if _, err := scheduler.Every(1).Hour().Do(func() {
+		utils.WatchMethod(m.updateRarity)
+	}); err != nil {
+		return fmt.Errorf("error while setting up nft periodic operations: %s", err)
+	}
+
+	if _, err := scheduler.Every(1).Hour().Do(func() {
+		utils.WatchMethod(m.updateDenomsStatistics)
+	}); err != nil {
+		return fmt.Errorf("error while setting up nft periodic operations: %s", err)
+	}
+
+	return nil
+}
+
+// updateDenomsStatistics recomputes the minted, burned, supply, holders and transfers counters of every denom,
+// reconciling any drift of the counters updated while handling the nft messages
+func (m *Module) updateDenomsStatistics() error {
+	log.Debug().Str("module", "nft").Str("operation", "denoms statistics").Msg("updating denoms statistics")
+
+	height, err := m.db.GetLastBlockHeight()
+	if err != nil {
+		return fmt.Errorf("error while getting last block height: %s", err)
+	}
+
+	return m.db.UpdateDenomsStatistics(height)
+}
+
+// updateRarity computes the traits frequency and the nfts rarity of every denom
+func (m *Module) updateRarity() error {
+	log.Debug().Str("module", "nft").Str("operation", "rarity").Msg("updating nfts rarity")
+
+	denomIDs, err := m.db.GetDenomIDs()
+	if err != nil {
+		return fmt.Errorf("error while getting denoms: %s", err)
+	}
+
+	timestamp := time.Now().Unix()
+	for _, denomID := range denomIDs {
+		rows, err := m.db.GetDenomNftsAttributes(denomID)
+		if err != nil {
+			return fmt.Errorf("error while getting nfts attributes of denom %s: %s", denomID, err)
+		}
+
+		nftsTraits := make(map[uint64][]types.NftTrait, len(rows))
+		for _, row := range rows {
+			nftsTraits[row.TokenID] = parseTraits(row.Attributes)
+		}
+
+		traits, rarities := computeRarity(nftsTraits)
+		if err := m.db.SaveDenomRarity(denomID, traits, rarities, timestamp); err != nil {
+			return fmt.Errorf("error while saving rarity of denom %s: %s", denomID, err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,114 @@
+package nft
+
+import (
+	"encoding/json"
+	"sort"
+
+	"github.com/forbole/bdjuno/v2/types"
+)
+
+type nftAttribute struct {
+	TraitType string          `json:"trait_type"`
+	Value     json.RawMessage `json:"value"`
+}
+
+// parseTraits returns the distinct traits contained inside the given JSON array of attributes,
+// ignoring the attributes that have no trait type
+func parseTraits(attributesJSON string) []types.NftTrait {
+	var attributes []nftAttribute
+	if err := json.Unmarshal([]byte(attributesJSON), &attributes); err != nil {
+		return nil
+	}
+
+	seen := make(map[types.NftTrait]bool)
+	var traits []types.NftTrait
+	for _, a := range attributes {
+		if a.TraitType == "" || len(a.Value) == 0 {
+			continue
+		}
+
+		value := string(a.Value)
+		var str string
+		if err := json.Unmarshal(a.Value, &str); err == nil {
+			value = str
+		}
+
+		trait := types.NftTrait{TraitType: a.TraitType, Value: value}
+		if !seen[trait] {
+			seen[trait] = true
+			traits = append(traits, trait)
+		}
+	}
+
+	sort.Slice(traits, func(i, j int) bool {
+		if traits[i].TraitType != traits[j].TraitType {
+			return traits[i].TraitType < traits[j].TraitType
+		}
+		return traits[i].Value < traits[j].Value
+	})
+
+	return traits
+}
+
+// computeRarity returns how many of the given nfts have each trait and the rarity of every nft.
+// The score of an nft is the sum of the inverse frequencies of its traits, so that nfts having rarer
+// traits get higher scores. Nfts having the same score share the same rank
+func computeRarity(nftsTraits map[uint64][]types.NftTrait) ([]types.NftTraitFrequency, []types.NftRarity) {
+	total := len(nftsTraits)
+	if total == 0 {
+		return nil, nil
+	}
+
+	counts := make(map[types.NftTrait]int64)
+	for _, traits := range nftsTraits {
+		for _, trait := range traits {
+			counts[trait]++
+		}
+	}
+
+	if len(counts) == 0 {
+		return nil, nil
+	}
+
+	frequencies := make([]types.NftTraitFrequency, 0, len(counts))
+	for trait, count := range counts {
+		frequencies = append(frequencies, types.NftTraitFrequency{
+			NftTrait:  trait,
+			Count:     count,
+			Frequency: float64(count) / float64(total),
+		})
+	}
+
+	sort.Slice(frequencies, func(i, j int) bool {
+		if frequencies[i].TraitType != frequencies[j].TraitType {
+			return frequencies[i].TraitType < frequencies[j].TraitType
+		}
+		return frequencies[i].Value < frequencies[j].Value
+	})
+
+	rarities := make([]types.NftRarity, 0, total)
+	for tokenID, traits := range nftsTraits {
+		var score float64
+		for _, trait := range traits {
+			score += float64(total) / float64(counts[trait])
+		}
+		rarities = append(rarities, types.NftRarity{TokenID: tokenID, Score: score})
+	}
+
+	sort.Slice(rarities, func(i, j int) bool {
+		if rarities[i].Score != rarities[j].Score {
+			return rarities[i].Score > rarities[j].Score
+		}
+		return rarities[i].TokenID < rarities[j].TokenID
+	})
+
+	for i := range rarities {
+		if i > 0 && rarities[i].Score == rarities[i-1].Score {
+			rarities[i].Rank = rarities[i-1].Rank
+		} else {
+			rarities[i].Rank = int64(i + 1)
+		}
+	}
+
+	return frequencies, rarities
+}
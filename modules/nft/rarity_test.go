@@ -0,0 +1,58 @@
+package nft
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/forbole/bdjuno/v2/types"
+)
+
+func TestParseTraits(t *testing.T) {
+	traits := parseTraits(`[
+		{"trait_type": "color", "value": "red"},
+		{"trait_type": "level", "value": 5},
+		{"trait_type": "color", "value": "red"},
+		{"value": "no type"},
+		{"trait_type": "empty"}
+	]`)
+
+	require.Equal(t, []types.NftTrait{
+		{TraitType: "color", Value: "red"},
+		{TraitType: "level", Value: "5"},
+	}, traits)
+
+	require.Empty(t, parseTraits(`{"not": "an array"}`))
+	require.Empty(t, parseTraits(`[]`))
+}
+
+func TestComputeRarity(t *testing.T) {
+	red := types.NftTrait{TraitType: "color", Value: "red"}
+	blue := types.NftTrait{TraitType: "color", Value: "blue"}
+	hat := types.NftTrait{TraitType: "hat", Value: "yes"}
+
+	frequencies, rarities := computeRarity(map[uint64][]types.NftTrait{
+		1: {red},
+		2: {red},
+		3: {blue, hat},
+		4: {red},
+	})
+
+	require.Equal(t, []types.NftTraitFrequency{
+		{NftTrait: blue, Count: 1, Frequency: 0.25},
+		{NftTrait: red, Count: 3, Frequency: 0.75},
+		{NftTrait: hat, Count: 1, Frequency: 0.25},
+	}, frequencies)
+
+	require.Len(t, rarities, 4)
+	require.Equal(t, types.NftRarity{TokenID: 3, Score: 8, Rank: 1}, rarities[0])
+	for i, tokenID := range []uint64{1, 2, 4} {
+		require.Equal(t, tokenID, rarities[i+1].TokenID)
+		require.InDelta(t, 4.0/3.0, rarities[i+1].Score, 1e-9)
+		require.Equal(t, int64(2), rarities[i+1].Rank)
+	}
+
+	frequencies, rarities = computeRarity(map[uint64][]types.NftTrait{1: nil, 2: nil})
+	require.Empty(t, frequencies)
+	require.Empty(t, rarities)
+}
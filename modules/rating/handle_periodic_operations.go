@@ -0,0 +1,41 @@
+package rating
+
+import (
+	"fmt"
+
+	"github.com/go-co-op/gocron"
+	"github.com/rs/zerolog/log"
+
+	"github.com/forbole/bdjuno/v2/modules/utils"
+)
+
+// RegisterPeriodicOperations implements modules.PeriodicOperationsModule
+func (m *Module) RegisterPeriodicOperations(scheduler *gocron.Scheduler) error {
+	log.Debug().Str("module", moduleName).Msg("setting up periodic tasks")
+
+	if _, err := scheduler.Every(1).Hour().Do(func() {
+		utils.WatchMethod(m.updateValidatorsRatings)
+	}); err != nil {
+		return fmt.Errorf("error while setting up rating periodic operation: %s", err)
+	}
+
+	return nil
+}
+
+// updateValidatorsRatings computes and stores the rating of all the validators
+func (m *Module) updateValidatorsRatings() error {
+	log.Trace().Str("module", moduleName).Str("operation", "validators rating").
+		Msg("updating validators ratings")
+
+	block, err := m.db.GetLastBlock()
+	if err != nil {
+		return fmt.Errorf("error while getting last block: %s", err)
+	}
+
+	data, err := m.db.GetValidatorsRatingData()
+	if err != nil {
+		return fmt.Errorf("error while getting validators rating data: %s", err)
+	}
+
+	return m.db.SaveValidatorsRatings(computeRatings(data, block.Height))
+}
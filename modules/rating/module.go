@@ -0,0 +1,34 @@
+package rating
+
+import (
+	"github.com/forbole/juno/v2/modules"
+
+	"github.com/forbole/bdjuno/v2/database"
+)
+
+const (
+	moduleName = "rating"
+)
+
+var (
+	_ modules.Module                   = &Module{}
+	_ modules.PeriodicOperationsModule = &Module{}
+)
+
+// Module represents the module that periodically rates the validators
+// based on the data stored by the other modules
+type Module struct {
+	db *database.Db
+}
+
+// NewModule builds a new Module instance
+func NewModule(db *database.Db) *Module {
+	return &Module{
+		db: db,
+	}
+}
+
+// Name implements modules.Module
+func (m *Module) Name() string {
+	return moduleName
+}
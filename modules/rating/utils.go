@@ -0,0 +1,57 @@
+package rating
+
+import (
+	"github.com/forbole/bdjuno/v2/types"
+)
+
+// Weights of each component of the validators score, summing up to 1
+const (
+	uptimeWeight           = 0.30
+	notSlashedWeight       = 0.20
+	govParticipationWeight = 0.20
+	selfDelegationWeight   = 0.10
+	delegatorsWeight       = 0.10
+	decentralizationWeight = 0.10
+)
+
+// computeRatings computes the score of each validator, ranging from 0 to 100.
+// The self delegation ratio and the number of delegators are scored relatively to the best validator,
+// while the voting power is scored so that validators holding a smaller share of it rank higher
+func computeRatings(data []types.ValidatorRatingData, height int64) []types.ValidatorRating {
+	var maxSelfDelegationRatio float64
+	var maxDelegatorsCount, totalVotingPower int64
+	for _, validator := range data {
+		if validator.SelfDelegationRatio > maxSelfDelegationRatio {
+			maxSelfDelegationRatio = validator.SelfDelegationRatio
+		}
+		if validator.DelegatorsCount > maxDelegatorsCount {
+			maxDelegatorsCount = validator.DelegatorsCount
+		}
+		totalVotingPower += validator.VotingPower
+	}
+
+	ratings := make([]types.ValidatorRating, len(data))
+	for i, validator := range data {
+		score := uptimeWeight*validator.Uptime + govParticipationWeight*validator.GovParticipation
+
+		if !validator.Slashed {
+			score += notSlashedWeight
+		}
+
+		if maxSelfDelegationRatio > 0 {
+			score += selfDelegationWeight * validator.SelfDelegationRatio / maxSelfDelegationRatio
+		}
+
+		if maxDelegatorsCount > 0 {
+			score += delegatorsWeight * float64(validator.DelegatorsCount) / float64(maxDelegatorsCount)
+		}
+
+		if totalVotingPower > 0 {
+			score += decentralizationWeight * (1 - float64(validator.VotingPower)/float64(totalVotingPower))
+		}
+
+		ratings[i] = types.NewValidatorRating(validator, score*100, height)
+	}
+
+	return ratings
+}
@@ -0,0 +1,36 @@
+package rating
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/forbole/bdjuno/v2/types"
+)
+
+func TestComputeRatings(t *testing.T) {
+	data := []types.ValidatorRatingData{
+		types.NewValidatorRatingData("cudosvalcons1", 0.5, 1, false, 1, 10, 25),
+		types.NewValidatorRatingData("cudosvalcons2", 0.25, 0.5, true, 0, 5, 75),
+	}
+
+	ratings := computeRatings(data, 10)
+	require.Len(t, ratings, 2)
+
+	require.Equal(t, data[0], ratings[0].ValidatorRatingData)
+	require.Equal(t, int64(10), ratings[0].Height)
+	require.InDelta(t, 97.5, ratings[0].Score, 1e-9)
+
+	require.Equal(t, data[1], ratings[1].ValidatorRatingData)
+	require.InDelta(t, 27.5, ratings[1].Score, 1e-9)
+}
+
+func TestComputeRatings_NoData(t *testing.T) {
+	data := []types.ValidatorRatingData{
+		types.NewValidatorRatingData("cudosvalcons1", 0, 0, false, 0, 0, 0),
+	}
+
+	ratings := computeRatings(data, 10)
+	require.Len(t, ratings, 1)
+	require.InDelta(t, 20, ratings[0].Score, 1e-9)
+}
@@ -24,6 +24,7 @@ import (
 
 	wasmkeeper "github.com/CosmWasm/wasmd/x/wasm/keeper"
 	wasmtypes "github.com/CosmWasm/wasmd/x/wasm/types"
+	gravitytypes "github.com/althea-net/cosmos-gravity-bridge/module/x/gravity/types"
 	"github.com/cosmos/cosmos-sdk/codec"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
@@ -44,6 +45,7 @@ import (
 	nodeconfig "github.com/forbole/juno/v2/node/config"
 
 	"github.com/forbole/bdjuno/v2/database"
+	"github.com/forbole/bdjuno/v2/modules/alert"
 	"github.com/forbole/bdjuno/v2/modules/auth"
 	"github.com/forbole/bdjuno/v2/modules/bank"
 	banksource "github.com/forbole/bdjuno/v2/modules/bank/source"
@@ -58,6 +60,10 @@ import (
 	cw20tokensource "github.com/forbole/bdjuno/v2/modules/cw20token/source"
 	localcw20tokensource "github.com/forbole/bdjuno/v2/modules/cw20token/source/local"
 	remotecw20tokensource "github.com/forbole/bdjuno/v2/modules/cw20token/source/remote"
+	"github.com/forbole/bdjuno/v2/modules/cw721token"
+	cw721tokensource "github.com/forbole/bdjuno/v2/modules/cw721token/source"
+	localcw721tokensource "github.com/forbole/bdjuno/v2/modules/cw721token/source/local"
+	remotecw721tokensource "github.com/forbole/bdjuno/v2/modules/cw721token/source/remote"
 	distrsource "github.com/forbole/bdjuno/v2/modules/distribution/source"
 	localdistrsource "github.com/forbole/bdjuno/v2/modules/distribution/source/local"
 	remotedistrsource "github.com/forbole/bdjuno/v2/modules/distribution/source/remote"
@@ -65,9 +71,13 @@ import (
 	govsource "github.com/forbole/bdjuno/v2/modules/gov/source"
 	localgovsource "github.com/forbole/bdjuno/v2/modules/gov/source/local"
 	remotegovsource "github.com/forbole/bdjuno/v2/modules/gov/source/remote"
+	gravitysource "github.com/forbole/bdjuno/v2/modules/gravity/source"
+	localgravitysource "github.com/forbole/bdjuno/v2/modules/gravity/source/local"
+	remotegravitysource "github.com/forbole/bdjuno/v2/modules/gravity/source/remote"
 	"github.com/forbole/bdjuno/v2/modules/group"
 	"github.com/forbole/bdjuno/v2/modules/modules"
 	"github.com/forbole/bdjuno/v2/modules/pricefeed"
+	"github.com/forbole/bdjuno/v2/modules/rating"
 	slashingsource "github.com/forbole/bdjuno/v2/modules/slashing/source"
 	localslashingsource "github.com/forbole/bdjuno/v2/modules/slashing/source/local"
 	remoteslashingsource "github.com/forbole/bdjuno/v2/modules/slashing/source/remote"
@@ -124,28 +134,37 @@ func (r *Registrar) BuildModules(ctx registrar.Context) jmodules.Modules {
 
 	cryptoCompareClient := cryptoCompare.NewClient(&cryptoCompareConfig)
 
-	authModule := auth.NewModule(r.parser, cdc, db)
+	alertConfig, err := alert.ParseConfig(ctx.JunoConfig.GetBytes())
+	if err != nil {
+		panic(fmt.Errorf("failed to parse alert config: %s", err))
+	}
+
+	alertModule := alert.NewModule(alertConfig, db)
+	historyModule := history.NewModule(ctx.JunoConfig.Chain, r.parser, sources.BankSource, sources.DistrSource, sources.StakingSource, cdc, db)
+	authModule := auth.NewModule(r.parser, historyModule, cdc, db)
 	bankModule := bank.NewModule(r.parser, sources.BankSource, cdc, db)
 	consensusModule := consensus.NewModule(db)
 	distrModule := distribution.NewModule(sources.DistrSource, cdc, db)
 	feegrantModule := feegrant.NewModule(cdc, db)
-	historyModule := history.NewModule(ctx.JunoConfig.Chain, r.parser, cdc, db)
 	cudoMintModule := cudomint.NewModule(cdc, db, ctx.JunoConfig.GetBytes())
-	slashingModule := slashing.NewModule(sources.SlashingSource, cdc, db)
-	stakingModule := staking.NewModule(sources.StakingSource, slashingModule, authModule, cdc, db)
-	govModule := gov.NewModule(sources.GovSource, authModule, distrModule, slashingModule, stakingModule, cdc, db)
+	slashingModule := slashing.NewModule(sources.SlashingSource, alertModule, cdc, db)
+	stakingModule := staking.NewModule(sources.StakingSource, slashingModule, authModule, alertModule, cdc, db)
+	govModule := gov.NewModule(sources.GovSource, authModule, distrModule, slashingModule, stakingModule, alertModule, cdc, db)
 	cosmwasmModule := cosmwasm.NewModule(cdc, db)
-	gravityModule := gravity.NewModule(cdc, db)
+	gravityModule := gravity.NewModule(sources.GravitySource, sources.StakingSource, cdc, db)
 	nftModule := nft.NewModule(cdc, db)
 	groupModule := group.NewModule(cdc, db)
 	marketplaceModule := marketplace.NewModule(cdc, db, ctx.JunoConfig.GetBytes(), cryptoCompareClient)
 	cw20tokenModule := cw20token.NewModule(cdc, db, sources.CW20TokenSource)
+	cw721tokenModule := cw721token.NewModule(cdc, db, sources.CW721TokenSource)
+	ratingModule := rating.NewModule(db)
 
 	return []jmodules.Module{
 		messages.NewModule(r.parser, cdc, ctx.Database),
 		telemetry.NewModule(ctx.JunoConfig),
 		pruning.NewModule(ctx.JunoConfig, db, ctx.Logger),
 
+		alertModule,
 		authModule,
 		bankModule,
 		consensusModule,
@@ -164,16 +183,20 @@ func (r *Registrar) BuildModules(ctx registrar.Context) jmodules.Modules {
 		groupModule,
 		marketplaceModule,
 		cw20tokenModule,
+		cw721tokenModule,
+		ratingModule,
 	}
 }
 
 type Sources struct {
-	BankSource      banksource.Source
-	DistrSource     distrsource.Source
-	GovSource       govsource.Source
-	SlashingSource  slashingsource.Source
-	StakingSource   stakingsource.Source
-	CW20TokenSource cw20tokensource.Source
+	BankSource       banksource.Source
+	DistrSource      distrsource.Source
+	GovSource        govsource.Source
+	GravitySource    gravitysource.Source
+	SlashingSource   slashingsource.Source
+	StakingSource    stakingsource.Source
+	CW20TokenSource  cw20tokensource.Source
+	CW721TokenSource cw721tokensource.Source
 }
 
 func BuildSources(nodeCfg nodeconfig.Config, encodingConfig *params.EncodingConfig) (*Sources, error) {
@@ -199,13 +222,18 @@ func buildLocalSources(cfg *local.Details, encodingConfig *params.EncodingConfig
 		cfg.Home, 0, simapp.MakeTestEncodingConfig(), simapp.EmptyAppOptions{},
 	)
 
+	wasmQuerier := wasmkeeper.Querier(cw20token.GetWasmKeeper(cfg.Home, source.StoreDB))
+	gravityKeeper := gravity.GetGravityKeeper(cfg.Home, source.StoreDB)
+
 	sources := &Sources{
-		BankSource:      localbanksource.NewSource(source, banktypes.QueryServer(app.BankKeeper)),
-		DistrSource:     localdistrsource.NewSource(source, distrtypes.QueryServer(app.DistrKeeper)),
-		GovSource:       localgovsource.NewSource(source, govtypes.QueryServer(app.GovKeeper)),
-		SlashingSource:  localslashingsource.NewSource(source, slashingtypes.QueryServer(app.SlashingKeeper)),
-		StakingSource:   localstakingsource.NewSource(source, stakingkeeper.Querier{Keeper: app.StakingKeeper}),
-		CW20TokenSource: localcw20tokensource.NewSource(source, wasmkeeper.Querier(cw20token.GetWasmKeeper(cfg.Home, source.StoreDB))),
+		BankSource:       localbanksource.NewSource(source, banktypes.QueryServer(app.BankKeeper)),
+		DistrSource:      localdistrsource.NewSource(source, distrtypes.QueryServer(app.DistrKeeper)),
+		GovSource:        localgovsource.NewSource(source, govtypes.QueryServer(app.GovKeeper)),
+		GravitySource:    localgravitysource.NewSource(source, gravityKeeper),
+		SlashingSource:   localslashingsource.NewSource(source, slashingtypes.QueryServer(app.SlashingKeeper)),
+		StakingSource:    localstakingsource.NewSource(source, stakingkeeper.Querier{Keeper: app.StakingKeeper}),
+		CW20TokenSource:  localcw20tokensource.NewSource(source, wasmQuerier),
+		CW721TokenSource: localcw721tokensource.NewSource(source, wasmQuerier),
 	}
 
 	// Mount and initialize the stores
@@ -239,11 +267,13 @@ func buildRemoteSources(cfg *remote.Details) (*Sources, error) {
 	}
 
 	return &Sources{
-		BankSource:      remotebanksource.NewSource(source, banktypes.NewQueryClient(source.GrpcConn)),
-		DistrSource:     remotedistrsource.NewSource(source, distrtypes.NewQueryClient(source.GrpcConn)),
-		GovSource:       remotegovsource.NewSource(source, govtypes.NewQueryClient(source.GrpcConn)),
-		SlashingSource:  remoteslashingsource.NewSource(source, slashingtypes.NewQueryClient(source.GrpcConn)),
-		StakingSource:   remotestakingsource.NewSource(source, stakingtypes.NewQueryClient(source.GrpcConn)),
-		CW20TokenSource: remotecw20tokensource.NewSource(source, wasmtypes.NewQueryClient(source.GrpcConn)),
+		BankSource:       remotebanksource.NewSource(source, banktypes.NewQueryClient(source.GrpcConn)),
+		DistrSource:      remotedistrsource.NewSource(source, distrtypes.NewQueryClient(source.GrpcConn)),
+		GovSource:        remotegovsource.NewSource(source, govtypes.NewQueryClient(source.GrpcConn)),
+		GravitySource:    remotegravitysource.NewSource(source, gravitytypes.NewQueryClient(source.GrpcConn)),
+		SlashingSource:   remoteslashingsource.NewSource(source, slashingtypes.NewQueryClient(source.GrpcConn)),
+		StakingSource:    remotestakingsource.NewSource(source, stakingtypes.NewQueryClient(source.GrpcConn)),
+		CW20TokenSource:  remotecw20tokensource.NewSource(source, wasmtypes.NewQueryClient(source.GrpcConn)),
+		CW721TokenSource: remotecw721tokensource.NewSource(source, wasmtypes.NewQueryClient(source.GrpcConn)),
 	}, nil
 }
@@ -0,0 +1,13 @@
+package slashing
+
+import (
+	"time"
+
+	"github.com/forbole/bdjuno/v2/types"
+)
+
+type AlertModule interface {
+	NotifySigningInfoUpdated(
+		previous *types.ValidatorSigningInfo, current types.ValidatorSigningInfo, signedBlocksWindow int64, timestamp time.Time,
+	)
+}
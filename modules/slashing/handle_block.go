@@ -2,9 +2,12 @@ package slashing
 
 import (
 	"fmt"
+	"time"
 
 	juno "github.com/forbole/juno/v2/types"
 
+	"github.com/forbole/bdjuno/v2/types"
+
 	"github.com/rs/zerolog/log"
 	tmctypes "github.com/tendermint/tendermint/rpc/core/types"
 )
@@ -14,7 +17,7 @@ func (m *Module) HandleBlock(
 	block *tmctypes.ResultBlock, results *tmctypes.ResultBlockResults, _ []*juno.Tx, _ *tmctypes.ResultValidators,
 ) error {
 	// Update the signing infos
-	err := m.updateSigningInfo(block.Block.Height)
+	err := m.updateSigningInfo(block.Block.Height, block.Block.Time)
 	if err != nil {
 		return fmt.Errorf("error while updating signing info: %s", err)
 	}
@@ -23,7 +26,7 @@ func (m *Module) HandleBlock(
 }
 
 // updateSigningInfo reads from the LCD the current staking pool and stores its value inside the database
-func (m *Module) updateSigningInfo(height int64) error {
+func (m *Module) updateSigningInfo(height int64, timestamp time.Time) error {
 	log.Debug().Str("module", "slashing").Int64("height", height).Msg("updating signing info")
 
 	signingInfos, err := m.getSigningInfos(height)
@@ -31,5 +34,47 @@ func (m *Module) updateSigningInfo(height int64) error {
 		return err
 	}
 
-	return m.db.SaveValidatorsSigningInfos(signingInfos)
+	// Get the stored signing infos before replacing them, so that the alerts can be raised based on their changes
+	previousInfos, err := m.db.GetValidatorsSigningInfos()
+	if err != nil {
+		return fmt.Errorf("error while getting stored signing infos: %s", err)
+	}
+
+	err = m.db.SaveValidatorsSigningInfos(signingInfos)
+	if err != nil {
+		return err
+	}
+
+	return m.notifySigningInfos(previousInfos, signingInfos, timestamp)
+}
+
+// notifySigningInfos notifies the alert module about the given signing infos, along with the previous ones
+func (m *Module) notifySigningInfos(
+	previousInfos []types.ValidatorSigningInfo, signingInfos []types.ValidatorSigningInfo, timestamp time.Time,
+) error {
+	params, err := m.db.GetSlashingParams()
+	if err != nil {
+		return fmt.Errorf("error while getting slashing params: %s", err)
+	}
+
+	var signedBlocksWindow int64
+	if params != nil {
+		signedBlocksWindow = params.SignedBlocksWindow
+	}
+
+	previousInfosMap := make(map[string]types.ValidatorSigningInfo, len(previousInfos))
+	for _, info := range previousInfos {
+		previousInfosMap[info.ValidatorAddress] = info
+	}
+
+	for _, info := range signingInfos {
+		var previous *types.ValidatorSigningInfo
+		if previousInfo, ok := previousInfosMap[info.ValidatorAddress]; ok {
+			previous = &previousInfo
+		}
+
+		m.alertModule.NotifySigningInfoUpdated(previous, info, signedBlocksWindow, timestamp)
+	}
+
+	return nil
 }
@@ -1,6 +1,8 @@
 package staking
 
 import (
+	"time"
+
 	sdk "github.com/cosmos/cosmos-sdk/types"
 
 	"github.com/forbole/bdjuno/v2/types"
@@ -13,3 +15,8 @@ type SlashingModule interface {
 type AuthModule interface {
 	RefreshAccounts(height int64, addresses []string) error
 }
+
+type AlertModule interface {
+	NotifyDelegation(height int64, timestamp time.Time, delegator string, validator string, amount sdk.Coin)
+	NotifyUndelegation(height int64, timestamp time.Time, delegator string, validator string, amount sdk.Coin)
+}
@@ -3,14 +3,16 @@ package staking
 import (
 	"encoding/hex"
 	"fmt"
+	"time"
 
 	"github.com/forbole/bdjuno/v2/types"
 
-	"github.com/cosmos/cosmos-sdk/types/query"
+	slashingtypes "github.com/cosmos/cosmos-sdk/x/slashing/types"
 	stakingtypes "github.com/cosmos/cosmos-sdk/x/staking/types"
 	juno "github.com/forbole/juno/v2/types"
 
 	"github.com/rs/zerolog/log"
+	abci "github.com/tendermint/tendermint/abci/types"
 	tmctypes "github.com/tendermint/tendermint/rpc/core/types"
 	tmtypes "github.com/tendermint/tendermint/types"
 )
@@ -37,8 +39,12 @@ func (m *Module) HandleBlock(
 	// Update the staking pool
 	go m.updateStakingPool(block.Block.Height)
 
-	// TODO: Can be moved eventually as periodic operation to reduce DB usage if its required
-	go m.updateDelegations(block.Block.Height, validators)
+	// Update the delegations of the slashed validators
+	go m.updateSlashedDelegations(block.Block.Height, res.BeginBlockEvents)
+
+	// Remove the unbonding delegations and redelegations that have been completed
+	go m.removeCompletedUnbondingDelegations(block.Block.Height, block.Block.Time, res.EndBlockEvents)
+	go m.removeCompletedRedelegations(block.Block.Height, block.Block.Time, res.EndBlockEvents)
 
 	return nil
 }
@@ -148,52 +154,88 @@ func (m *Module) updateStakingPool(height int64) {
 	}
 }
 
-func (m *Module) updateDelegations(height int64, validators []stakingtypes.Validator) {
-	for i := range validators {
-		response, err := m.source.GetValidatorDelegationsWithPagination(height, validators[i].OperatorAddress, &query.PageRequest{CountTotal: true})
+// updateSlashedDelegations refreshes the delegations of all the validators slashed inside the given events,
+// since slashing reduces the amount of every delegation of a validator
+func (m *Module) updateSlashedDelegations(height int64, events []abci.Event) {
+	for _, event := range juno.FindEventsByType(events, slashingtypes.EventTypeSlash) {
+		consAddr, err := juno.FindAttributeByKey(event, slashingtypes.AttributeKeyAddress)
 		if err != nil {
 			log.Error().Str("module", "staking").Err(err).Int64("height", height).
-				Msg("error while getting validator delegations")
+				Msg("error while getting slashed validator address")
 			continue
 		}
 
-		response, err = m.source.GetValidatorDelegationsWithPagination(height, validators[i].OperatorAddress, &query.PageRequest{Limit: response.Pagination.Total})
+		valAddr, err := m.db.GetValidatorOperatorAddress(string(consAddr.Value))
 		if err != nil {
 			log.Error().Str("module", "staking").Err(err).Int64("height", height).
-				Msg("error while getting validator delegations")
+				Msg("error while getting slashed validator operator address")
 			continue
 		}
 
-		if len(response.DelegationResponses) == 0 {
+		err = m.RefreshValidatorDelegations(height, valAddr.String())
+		if err != nil {
+			log.Error().Str("module", "staking").Err(err).Int64("height", height).
+				Msg("error while refreshing slashed validator delegations")
+		}
+	}
+}
+
+// removeCompletedUnbondingDelegations removes from the database all the unbonding delegations
+// that have been completed inside the block having the given timestamp
+func (m *Module) removeCompletedUnbondingDelegations(height int64, timestamp time.Time, events []abci.Event) {
+	for _, event := range juno.FindEventsByType(events, stakingtypes.EventTypeCompleteUnbonding) {
+		delegator, err := juno.FindAttributeByKey(event, stakingtypes.AttributeKeyDelegator)
+		if err != nil {
+			log.Error().Str("module", "staking").Err(err).Int64("height", height).
+				Msg("error while getting completed unbonding delegator")
 			continue
 		}
 
-		delegators := []string{}
-		for _, response := range response.DelegationResponses {
-			delegator := response.Delegation.DelegatorAddress
+		validator, err := juno.FindAttributeByKey(event, stakingtypes.AttributeKeyValidator)
+		if err != nil {
+			log.Error().Str("module", "staking").Err(err).Int64("height", height).
+				Msg("error while getting completed unbonding validator")
+			continue
+		}
+
+		err = m.db.DeleteCompletedUnbondingDelegations(string(delegator.Value), string(validator.Value), timestamp)
+		if err != nil {
+			log.Error().Str("module", "staking").Err(err).Int64("height", height).
+				Msg("error while deleting completed unbonding delegations")
+		}
+	}
+}
 
-			func() {
-				m.refreshedAccountsMutex.Lock()
-				defer m.refreshedAccountsMutex.Unlock()
+// removeCompletedRedelegations removes from the database all the redelegations
+// that have been completed inside the block having the given timestamp
+func (m *Module) removeCompletedRedelegations(height int64, timestamp time.Time, events []abci.Event) {
+	for _, event := range juno.FindEventsByType(events, stakingtypes.EventTypeCompleteRedelegation) {
+		delegator, err := juno.FindAttributeByKey(event, stakingtypes.AttributeKeyDelegator)
+		if err != nil {
+			log.Error().Str("module", "staking").Err(err).Int64("height", height).
+				Msg("error while getting completed redelegation delegator")
+			continue
+		}
 
-				if !m.refreshedAccounts[delegator] {
-					m.refreshedAccounts[delegator] = true
-					delegators = append(delegators, delegator)
-				}
-			}()
+		srcValidator, err := juno.FindAttributeByKey(event, stakingtypes.AttributeKeySrcValidator)
+		if err != nil {
+			log.Error().Str("module", "staking").Err(err).Int64("height", height).
+				Msg("error while getting completed redelegation source validator")
+			continue
 		}
 
-		if len(delegators) > 0 {
-			if err := m.authModule.RefreshAccounts(height, delegators); err != nil {
-				log.Error().Str("module", "staking").Err(err).Int64("height", height).
-					Msg("error while refreshing accounts")
-				// We ignore the error because the it may not stop the saving of delegations
-			}
+		dstValidator, err := juno.FindAttributeByKey(event, stakingtypes.AttributeKeyDstValidator)
+		if err != nil {
+			log.Error().Str("module", "staking").Err(err).Int64("height", height).
+				Msg("error while getting completed redelegation destination validator")
+			continue
 		}
 
-		if err := m.db.SaveValidatorDelegation(response.DelegationResponses); err != nil {
+		err = m.db.DeleteCompletedRedelegations(
+			string(delegator.Value), string(srcValidator.Value), string(dstValidator.Value), timestamp)
+		if err != nil {
 			log.Error().Str("module", "staking").Err(err).Int64("height", height).
-				Msg("error while saving validator delegation")
+				Msg("error while deleting completed redelegations")
 		}
 	}
 }
@@ -2,11 +2,14 @@ package staking
 
 import (
 	"fmt"
+	"time"
 
 	stakingtypes "github.com/cosmos/cosmos-sdk/x/staking/types"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	juno "github.com/forbole/juno/v2/types"
+
+	"github.com/forbole/bdjuno/v2/types"
 )
 
 // HandleMsg implements MessageModule
@@ -21,6 +24,17 @@ func (m *Module) HandleMsg(index int, msg sdk.Msg, tx *juno.Tx) error {
 
 	case *stakingtypes.MsgEditValidator:
 		return m.handleEditValidator(tx.Height, cosmosMsg)
+
+	// MsgCancelUnbondingDelegation is not part of the SDK version in use, the periodic
+	// reconciliation of the delegations will pick it up once it is
+	case *stakingtypes.MsgDelegate:
+		return m.handleMsgDelegate(tx, cosmosMsg)
+
+	case *stakingtypes.MsgUndelegate:
+		return m.handleMsgUndelegate(tx, index, cosmosMsg)
+
+	case *stakingtypes.MsgBeginRedelegate:
+		return m.handleMsgBeginRedelegate(tx, index, cosmosMsg)
 	}
 
 	return nil
@@ -35,7 +49,9 @@ func (m *Module) handleMsgCreateValidator(height int64, msg *stakingtypes.MsgCre
 	if err != nil {
 		return fmt.Errorf("error while refreshing validator from MsgCreateValidator: %s", err)
 	}
-	return nil
+
+	// Store the self delegation
+	return m.RefreshDelegatorDelegations(height, msg.DelegatorAddress)
 }
 
 // handleEditValidator handles MsgEditValidator utils, updating the validator info
@@ -47,3 +63,117 @@ func (m *Module) handleEditValidator(height int64, msg *stakingtypes.MsgEditVali
 
 	return nil
 }
+
+// handleMsgDelegate handles a MsgDelegate by refreshing the delegator delegations
+func (m *Module) handleMsgDelegate(tx *juno.Tx, msg *stakingtypes.MsgDelegate) error {
+	err := m.RefreshDelegatorDelegations(tx.Height, msg.DelegatorAddress)
+	if err != nil {
+		return err
+	}
+
+	timestamp, err := time.Parse(time.RFC3339, tx.Timestamp)
+	if err != nil {
+		return fmt.Errorf("error while parsing time: %s", err)
+	}
+
+	m.alertModule.NotifyDelegation(tx.Height, timestamp, msg.DelegatorAddress, msg.ValidatorAddress, msg.Amount)
+	return nil
+}
+
+// handleMsgUndelegate handles a MsgUndelegate by refreshing the delegator delegations
+// and storing the unbonding delegation entry that has been created
+func (m *Module) handleMsgUndelegate(tx *juno.Tx, index int, msg *stakingtypes.MsgUndelegate) error {
+	err := m.RefreshDelegatorDelegations(tx.Height, msg.DelegatorAddress)
+	if err != nil {
+		return err
+	}
+
+	completionTime, err := getCompletionTime(tx, index, stakingtypes.EventTypeUnbond)
+	if err != nil {
+		return fmt.Errorf("error while getting unbonding delegation completion time: %s", err)
+	}
+
+	amount, err := getAmount(tx, index, stakingtypes.EventTypeUnbond)
+	if err != nil {
+		return fmt.Errorf("error while getting unbonding delegation amount: %s", err)
+	}
+
+	err = m.db.SaveUnbondingDelegation(types.NewUnbondingDelegation(
+		msg.DelegatorAddress,
+		msg.ValidatorAddress,
+		amount,
+		completionTime,
+		tx.Height,
+	))
+	if err != nil {
+		return err
+	}
+
+	timestamp, err := time.Parse(time.RFC3339, tx.Timestamp)
+	if err != nil {
+		return fmt.Errorf("error while parsing time: %s", err)
+	}
+
+	m.alertModule.NotifyUndelegation(tx.Height, timestamp, msg.DelegatorAddress, msg.ValidatorAddress, amount)
+	return nil
+}
+
+// handleMsgBeginRedelegate handles a MsgBeginRedelegate by refreshing the delegator delegations
+// and storing the redelegation entry that has been created
+func (m *Module) handleMsgBeginRedelegate(tx *juno.Tx, index int, msg *stakingtypes.MsgBeginRedelegate) error {
+	err := m.RefreshDelegatorDelegations(tx.Height, msg.DelegatorAddress)
+	if err != nil {
+		return err
+	}
+
+	completionTime, err := getCompletionTime(tx, index, stakingtypes.EventTypeRedelegate)
+	if err != nil {
+		return fmt.Errorf("error while getting redelegation completion time: %s", err)
+	}
+
+	amount, err := getAmount(tx, index, stakingtypes.EventTypeRedelegate)
+	if err != nil {
+		return fmt.Errorf("error while getting redelegation amount: %s", err)
+	}
+
+	return m.db.SaveRedelegation(types.NewRedelegation(
+		msg.DelegatorAddress,
+		msg.ValidatorSrcAddress,
+		msg.ValidatorDstAddress,
+		amount,
+		completionTime,
+		tx.Height,
+	))
+}
+
+// getCompletionTime returns the completion time contained inside the event of the given type
+// that has been emitted by the message having the given index
+func getCompletionTime(tx *juno.Tx, index int, eventType string) (time.Time, error) {
+	event, err := tx.FindEventByType(index, eventType)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	value, err := tx.FindAttributeByKey(event, stakingtypes.AttributeKeyCompletionTime)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	return time.Parse(time.RFC3339, value)
+}
+
+// getAmount returns the amount contained inside the event of the given type
+// that has been emitted by the message having the given index
+func getAmount(tx *juno.Tx, index int, eventType string) (sdk.Coin, error) {
+	event, err := tx.FindEventByType(index, eventType)
+	if err != nil {
+		return sdk.Coin{}, err
+	}
+
+	value, err := tx.FindAttributeByKey(event, sdk.AttributeKeyAmount)
+	if err != nil {
+		return sdk.Coin{}, err
+	}
+
+	return sdk.ParseCoinNormalized(value)
+}
@@ -0,0 +1,48 @@
+package staking
+
+import (
+	"fmt"
+
+	"github.com/go-co-op/gocron"
+	"github.com/rs/zerolog/log"
+
+	"github.com/forbole/bdjuno/v2/modules/utils"
+)
+
+// RegisterPeriodicOperations implements modules.PeriodicOperationsModule
+func (m *Module) RegisterPeriodicOperations(scheduler *gocron.Scheduler) error {
+	log.Debug().Str("module", "staking").Msg("setting up periodic tasks")
+
+	// Delegations are updated by messages and slashing events, this only catches up with the rest
+	if _, err := scheduler.Every(1).Day().Do(func() {
+		utils.WatchMethod(m.reconcileDelegations)
+	}); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// reconcileDelegations replaces the stored delegations of every validator with the ones present on chain
+func (m *Module) reconcileDelegations() error {
+	height, err := m.db.GetLastBlockHeight()
+	if err != nil {
+		return fmt.Errorf("error while getting last block height: %s", err)
+	}
+
+	validators, err := m.db.GetValidators()
+	if err != nil {
+		return fmt.Errorf("error while getting validators: %s", err)
+	}
+
+	log.Debug().Str("module", "staking").Int64("height", height).Msg("reconciling delegations")
+
+	for _, validator := range validators {
+		err = m.RefreshValidatorDelegations(height, validator.GetOperator())
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -11,10 +11,11 @@ import (
 )
 
 var (
-	_ modules.Module        = &Module{}
-	_ modules.GenesisModule = &Module{}
-	_ modules.BlockModule   = &Module{}
-	_ modules.MessageModule = &Module{}
+	_ modules.Module                   = &Module{}
+	_ modules.GenesisModule            = &Module{}
+	_ modules.BlockModule              = &Module{}
+	_ modules.MessageModule            = &Module{}
+	_ modules.PeriodicOperationsModule = &Module{}
 )
 
 // Module represents the x/staking module
@@ -24,13 +25,14 @@ type Module struct {
 	source                 stakingsource.Source
 	slashingModule         SlashingModule
 	authModule             AuthModule
+	alertModule            AlertModule
 	refreshedAccounts      map[string]bool
 	refreshedAccountsMutex sync.Mutex
 }
 
 // NewModule returns a new Module instance
 func NewModule(
-	source stakingsource.Source, slashingModule SlashingModule, authModule AuthModule,
+	source stakingsource.Source, slashingModule SlashingModule, authModule AuthModule, alertModule AlertModule,
 	cdc codec.Codec, db *database.Db,
 ) *Module {
 	return &Module{
@@ -39,6 +41,7 @@ func NewModule(
 		source:            source,
 		slashingModule:    slashingModule,
 		authModule:        authModule,
+		alertModule:       alertModule,
 		refreshedAccounts: make(map[string]bool),
 	}
 }
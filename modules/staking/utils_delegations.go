@@ -0,0 +1,111 @@
+package staking
+
+import (
+	"fmt"
+
+	"github.com/cosmos/cosmos-sdk/types/query"
+	stakingtypes "github.com/cosmos/cosmos-sdk/x/staking/types"
+	"github.com/rs/zerolog/log"
+
+	"github.com/forbole/bdjuno/v2/types"
+)
+
+// RefreshDelegatorDelegations queries the chain for all the delegations of the given delegator at the given height,
+// and replaces the stored ones with them
+func (m *Module) RefreshDelegatorDelegations(height int64, delegator string) error {
+	log.Debug().Str("module", "staking").Int64("height", height).
+		Str("delegator", delegator).Msg("refreshing delegator delegations")
+
+	var delegations []types.Delegation
+	var nextKey []byte
+	var stop = false
+	for !stop {
+		res, err := m.source.GetDelegationsWithPagination(height, delegator, &query.PageRequest{
+			Key:   nextKey,
+			Limit: 100, // Query 100 delegations at time
+		})
+		if err != nil {
+			return fmt.Errorf("error while getting delegator delegations: %s", err)
+		}
+
+		delegations = append(delegations, convertDelegationResponses(height, res.DelegationResponses)...)
+		nextKey = res.Pagination.NextKey
+		stop = len(res.Pagination.NextKey) == 0
+	}
+
+	return m.db.ReplaceDelegatorDelegations(delegator, delegations, height)
+}
+
+// RefreshValidatorDelegations queries the chain for all the delegations of the given validator at the given height,
+// and replaces the stored ones with them
+func (m *Module) RefreshValidatorDelegations(height int64, validator string) error {
+	log.Debug().Str("module", "staking").Int64("height", height).
+		Str("validator", validator).Msg("refreshing validator delegations")
+
+	var delegations []types.Delegation
+	var delegators []string
+	var nextKey []byte
+	var stop = false
+	for !stop {
+		res, err := m.source.GetValidatorDelegationsWithPagination(height, validator, &query.PageRequest{
+			Key:   nextKey,
+			Limit: 100, // Query 100 delegations at time
+		})
+		if err != nil {
+			return fmt.Errorf("error while getting validator delegations: %s", err)
+		}
+
+		for _, response := range res.DelegationResponses {
+			delegators = append(delegators, response.Delegation.DelegatorAddress)
+		}
+
+		delegations = append(delegations, convertDelegationResponses(height, res.DelegationResponses)...)
+		nextKey = res.Pagination.NextKey
+		stop = len(res.Pagination.NextKey) == 0
+	}
+
+	err := m.refreshDelegatorsAccounts(height, delegators)
+	if err != nil {
+		return err
+	}
+
+	return m.db.ReplaceValidatorDelegations(validator, delegations, height)
+}
+
+// refreshDelegatorsAccounts refreshes the accounts of the given delegators that have not been refreshed yet,
+// so that their delegations can reference them
+func (m *Module) refreshDelegatorsAccounts(height int64, delegators []string) error {
+	m.refreshedAccountsMutex.Lock()
+	var toRefresh []string
+	for _, delegator := range delegators {
+		if !m.refreshedAccounts[delegator] {
+			m.refreshedAccounts[delegator] = true
+			toRefresh = append(toRefresh, delegator)
+		}
+	}
+	m.refreshedAccountsMutex.Unlock()
+
+	if len(toRefresh) == 0 {
+		return nil
+	}
+
+	err := m.authModule.RefreshAccounts(height, toRefresh)
+	if err != nil {
+		return fmt.Errorf("error while refreshing delegators accounts: %s", err)
+	}
+
+	return nil
+}
+
+func convertDelegationResponses(height int64, responses stakingtypes.DelegationResponses) []types.Delegation {
+	delegations := make([]types.Delegation, len(responses))
+	for i, response := range responses {
+		delegations[i] = types.NewDelegation(
+			response.Delegation.DelegatorAddress,
+			response.Delegation.ValidatorAddress,
+			response.Balance,
+			height,
+		)
+	}
+	return delegations
+}
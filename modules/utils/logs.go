@@ -5,6 +5,7 @@ import (
 	"strconv"
 	"strings"
 
+	wasm "github.com/CosmWasm/wasmd/x/wasm/types"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 )
 
@@ -43,3 +44,40 @@ func GetUint64FromLogs(index int, logs sdk.ABCIMessageLogs, txHash, eventType, a
 
 	return value, nil
 }
+
+// WasmEvent contains the attributes emitted by a single contract execution inside the wasm event
+type WasmEvent struct {
+	Contract   string
+	Attributes map[string]string
+}
+
+// GetWasmEventsFromLogs splits the wasm event of the message with the given index into the
+// events emitted by each contract execution, including the ones triggered by sub-messages
+func GetWasmEventsFromLogs(index uint32, logs sdk.ABCIMessageLogs) []WasmEvent {
+	events := []WasmEvent{}
+
+	for _, log := range logs {
+		if log.MsgIndex != index {
+			continue
+		}
+
+		for _, event := range log.Events {
+			if event.Type != wasm.WasmModuleEventType {
+				continue
+			}
+
+			for _, attr := range event.Attributes {
+				if attr.Key == wasm.AttributeKeyContractAddr {
+					events = append(events, WasmEvent{Contract: attr.Value, Attributes: map[string]string{}})
+					continue
+				}
+
+				if len(events) > 0 {
+					events[len(events)-1].Attributes[attr.Key] = strings.ReplaceAll(attr.Value, "\"", "")
+				}
+			}
+		}
+	}
+
+	return events
+}
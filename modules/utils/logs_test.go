@@ -0,0 +1,48 @@
+package utils_test
+
+import (
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/require"
+
+	"github.com/forbole/bdjuno/v2/modules/utils"
+)
+
+func TestGetWasmEventsFromLogs(t *testing.T) {
+	logs := sdk.ABCIMessageLogs{
+		{
+			MsgIndex: 0,
+			Events: sdk.StringEvents{
+				{
+					Type: "wasm",
+					Attributes: []sdk.Attribute{
+						{Key: "_contract_address", Value: "router"},
+						{Key: "action", Value: "swap"},
+						{Key: "_contract_address", Value: "token"},
+						{Key: "action", Value: "transfer"},
+						{Key: "from", Value: "router"},
+						{Key: "to", Value: "cudos1"},
+						{Key: "amount", Value: "10"},
+					},
+				},
+			},
+		},
+		{
+			MsgIndex: 1,
+			Events: sdk.StringEvents{
+				{
+					Type:       "wasm",
+					Attributes: []sdk.Attribute{{Key: "_contract_address", Value: "other"}},
+				},
+			},
+		},
+	}
+
+	require.Equal(t, []utils.WasmEvent{
+		{Contract: "router", Attributes: map[string]string{"action": "swap"}},
+		{Contract: "token", Attributes: map[string]string{"action": "transfer", "from": "router", "to": "cudos1", "amount": "10"}},
+	}, utils.GetWasmEventsFromLogs(0, logs))
+
+	require.Empty(t, utils.GetWasmEventsFromLogs(2, logs))
+}
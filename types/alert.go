@@ -0,0 +1,35 @@
+package types
+
+import (
+	"time"
+)
+
+const (
+	AlertProposalCreated       = "proposal_created"
+	AlertProposalVotingStarted = "proposal_voting_started"
+	AlertProposalVotingEnded   = "proposal_voting_ended"
+	AlertValidatorSlashed      = "validator_slashed"
+	AlertValidatorLowUptime    = "validator_low_uptime"
+	AlertHugeDelegation        = "huge_delegation"
+	AlertHugeUndelegation      = "huge_undelegation"
+)
+
+// Alert represents an alert that has been raised for an event happened on chain
+type Alert struct {
+	Type      string
+	Message   string
+	Data      map[string]string
+	Height    int64
+	Timestamp time.Time
+}
+
+// NewAlert allows to build a new Alert instance
+func NewAlert(alertType string, message string, data map[string]string, height int64, timestamp time.Time) Alert {
+	return Alert{
+		Type:      alertType,
+		Message:   message,
+		Data:      data,
+		Height:    height,
+		Timestamp: timestamp,
+	}
+}
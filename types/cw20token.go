@@ -1,6 +1,9 @@
 package types
 
-import "encoding/json"
+import (
+	"encoding/json"
+	"math/big"
+)
 
 type Mint struct {
 	Minter    string
@@ -19,6 +22,13 @@ type TokenBalance struct {
 	Amount  string
 }
 
+type Allowance struct {
+	Owner   string
+	Spender string
+	Amount  string          `json:"allowance"`
+	Expires json.RawMessage `json:"expires"`
+}
+
 type TokenInfo struct {
 	Name          string
 	Symbol        string
@@ -34,19 +44,78 @@ type TokenInfo struct {
 	Creator       string
 }
 
+const (
+	TokenTypeStandard  = "standard"
+	TokenTypeMintable  = "mintable"
+	TokenTypeBurnable  = "burnable"
+	TokenTypeUnlimited = "unlimited"
+)
+
+type MsgInstantiate struct {
+	Name            string         `json:"name"`
+	Symbol          string         `json:"symbol"`
+	Decimals        int8           `json:"decimals"`
+	InitialBalances []TokenBalance `json:"initial_balances"`
+	Mint            *Mint          `json:"mint"`
+	Marketing       *Marketing     `json:"marketing"`
+}
+
+// InitialSupply returns the sum of all the initial balances
+func (m MsgInstantiate) InitialSupply() string {
+	supply := new(big.Int)
+	for _, b := range m.InitialBalances {
+		if amount, ok := new(big.Int).SetString(b.Amount, 10); ok {
+			supply.Add(supply, amount)
+		}
+	}
+
+	return supply.String()
+}
+
+// TokenType derives the token type from the mint settings of the instantiate message:
+// tokens without a minter have a fixed supply, tokens capped at their initial supply
+// can only be burned and re-minted up to it, any other cap makes the token mintable
+// and no cap at all makes it unlimited
+func (m MsgInstantiate) TokenType() string {
+	switch {
+	case m.Mint == nil || m.Mint.Minter == "":
+		return TokenTypeStandard
+	case m.Mint.MaxSupply == "":
+		return TokenTypeUnlimited
+	}
+
+	maxSupply, ok := new(big.Int).SetString(m.Mint.MaxSupply, 10)
+	if ok && maxSupply.String() == m.InitialSupply() {
+		return TokenTypeBurnable
+	}
+
+	return TokenTypeMintable
+}
+
+// SetType sets the type of the token instantiated with the given message. Tokens that cannot be minted
+// above their total supply have it as max supply, while the other ones keep the cap of their minter
+func (t *TokenInfo) SetType(msg MsgInstantiate) {
+	t.Type = msg.TokenType()
+	if t.Type == TokenTypeStandard || t.Type == TokenTypeBurnable {
+		t.Mint.MaxSupply = t.TotalSupply
+	}
+}
+
 type TypeMsgExecute string
 
 const (
-	TypeTransfer        TypeMsgExecute = "transfer"
-	TypeTransferFrom    TypeMsgExecute = "transfer_from"
-	TypeSend            TypeMsgExecute = "send"
-	TypeSendFrom        TypeMsgExecute = "send_from"
-	TypeBurn            TypeMsgExecute = "burn"
-	TypeBurnFrom        TypeMsgExecute = "burn_from"
-	TypeMint            TypeMsgExecute = "mint"
-	TypeUpdateMinter    TypeMsgExecute = "update_minter"
-	TypeUpdateMarketing TypeMsgExecute = "update_marketing"
-	TypeUploadLogo      TypeMsgExecute = "upload_logo"
+	TypeTransfer          TypeMsgExecute = "transfer"
+	TypeTransferFrom      TypeMsgExecute = "transfer_from"
+	TypeSend              TypeMsgExecute = "send"
+	TypeSendFrom          TypeMsgExecute = "send_from"
+	TypeBurn              TypeMsgExecute = "burn"
+	TypeBurnFrom          TypeMsgExecute = "burn_from"
+	TypeMint              TypeMsgExecute = "mint"
+	TypeUpdateMinter      TypeMsgExecute = "update_minter"
+	TypeUpdateMarketing   TypeMsgExecute = "update_marketing"
+	TypeUploadLogo        TypeMsgExecute = "upload_logo"
+	TypeIncreaseAllowance TypeMsgExecute = "increase_allowance"
+	TypeDecreaseAllowance TypeMsgExecute = "decrease_allowance"
 )
 
 type MsgTransfer struct {
@@ -98,15 +167,40 @@ type MsgUpdateMarketing struct {
 
 type MsgUploadLogo json.RawMessage
 
+type MsgIncreaseAllowance struct {
+	Spender string
+	Amount  string
+	Expires json.RawMessage
+}
+
+type MsgDecreaseAllowance struct {
+	Spender string
+	Amount  string
+	Expires json.RawMessage
+}
+
 type MsgExecute struct {
-	Transfer        MsgTransfer        `json:"transfer"`
-	TransferFrom    MsgTransferFrom    `json:"transfer_from"`
-	Send            MsgSend            `json:"send"`
-	SendFrom        MsgSendFrom        `json:"send_from"`
-	Burn            MsgBurn            `json:"burn"`
-	BurnFrom        MsgBurnFrom        `json:"burn_from"`
-	Mint            MsgMint            `json:"mint"`
-	UpdateMinter    MsgUpdateMinter    `json:"update_minter"`
-	UpdateMarketing MsgUpdateMarketing `json:"update_marketing"`
-	UploadLogo      json.RawMessage    `json:"upload_logo"`
+	Transfer          MsgTransfer          `json:"transfer"`
+	TransferFrom      MsgTransferFrom      `json:"transfer_from"`
+	Send              MsgSend              `json:"send"`
+	SendFrom          MsgSendFrom          `json:"send_from"`
+	Burn              MsgBurn              `json:"burn"`
+	BurnFrom          MsgBurnFrom          `json:"burn_from"`
+	Mint              MsgMint              `json:"mint"`
+	UpdateMinter      MsgUpdateMinter      `json:"update_minter"`
+	UpdateMarketing   MsgUpdateMarketing   `json:"update_marketing"`
+	UploadLogo        json.RawMessage      `json:"upload_logo"`
+	IncreaseAllowance MsgIncreaseAllowance `json:"increase_allowance"`
+	DecreaseAllowance MsgDecreaseAllowance `json:"decrease_allowance"`
+}
+
+type TokenTransfer struct {
+	Type       string
+	From       string
+	To         string
+	Amount     string
+	Height     int64
+	TxHash     string
+	MsgIndex   int
+	EventIndex int
 }
@@ -0,0 +1,85 @@
+package types_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/forbole/bdjuno/v2/types"
+)
+
+func TestMsgInstantiate_TokenType(t *testing.T) {
+	balances := []types.TokenBalance{{Address: "cudos1", Amount: "60"}, {Address: "cudos2", Amount: "40"}}
+
+	for testName, tc := range map[string]struct {
+		msg  types.MsgInstantiate
+		want string
+	}{
+		"no mint": {
+			msg:  types.MsgInstantiate{InitialBalances: balances},
+			want: types.TokenTypeStandard,
+		},
+		"mint without minter": {
+			msg:  types.MsgInstantiate{InitialBalances: balances, Mint: &types.Mint{MaxSupply: "200"}},
+			want: types.TokenTypeStandard,
+		},
+		"cap equal to initial supply": {
+			msg:  types.MsgInstantiate{InitialBalances: balances, Mint: &types.Mint{Minter: "cudos1", MaxSupply: "100"}},
+			want: types.TokenTypeBurnable,
+		},
+		"cap above initial supply": {
+			msg:  types.MsgInstantiate{InitialBalances: balances, Mint: &types.Mint{Minter: "cudos1", MaxSupply: "200"}},
+			want: types.TokenTypeMintable,
+		},
+		"no cap": {
+			msg:  types.MsgInstantiate{InitialBalances: balances, Mint: &types.Mint{Minter: "cudos1"}},
+			want: types.TokenTypeUnlimited,
+		},
+	} {
+		t.Run(testName, func(t *testing.T) {
+			require.Equal(t, tc.want, tc.msg.TokenType())
+		})
+	}
+}
+
+func TestTokenInfo_SetType(t *testing.T) {
+	balances := []types.TokenBalance{{Address: "cudos1", Amount: "60"}, {Address: "cudos2", Amount: "40"}}
+
+	for testName, tc := range map[string]struct {
+		msg           types.MsgInstantiate
+		wantType      string
+		wantMaxSupply string
+	}{
+		"standard": {
+			msg:           types.MsgInstantiate{InitialBalances: balances},
+			wantType:      types.TokenTypeStandard,
+			wantMaxSupply: "100",
+		},
+		"burnable": {
+			msg:           types.MsgInstantiate{InitialBalances: balances, Mint: &types.Mint{Minter: "cudos1", MaxSupply: "100"}},
+			wantType:      types.TokenTypeBurnable,
+			wantMaxSupply: "100",
+		},
+		"mintable": {
+			msg:           types.MsgInstantiate{InitialBalances: balances, Mint: &types.Mint{Minter: "cudos1", MaxSupply: "200"}},
+			wantType:      types.TokenTypeMintable,
+			wantMaxSupply: "200",
+		},
+	} {
+		t.Run(testName, func(t *testing.T) {
+			info := types.TokenInfo{TotalSupply: "100"}
+			if tc.msg.Mint != nil {
+				info.Mint = *tc.msg.Mint
+			}
+
+			info.SetType(tc.msg)
+			require.Equal(t, tc.wantType, info.Type)
+			require.Equal(t, tc.wantMaxSupply, info.Mint.MaxSupply)
+		})
+	}
+}
+
+func TestMsgInstantiate_InitialSupply(t *testing.T) {
+	msg := types.MsgInstantiate{InitialBalances: []types.TokenBalance{{Address: "cudos1", Amount: "18446744073709551615"}, {Address: "cudos2", Amount: "1"}}}
+	require.Equal(t, "18446744073709551616", msg.InitialSupply())
+}
@@ -0,0 +1,109 @@
+package types
+
+import "encoding/json"
+
+type CW721Collection struct {
+	Address   string `json:"address,omitempty"`
+	Name      string `json:"name"`
+	Symbol    string `json:"symbol"`
+	Minter    string `json:"minter"`
+	NumTokens uint64 `json:"count"`
+	CodeID    uint64 `json:"code_id"`
+	Creator   string `json:"creator"`
+}
+
+type CW721Approval struct {
+	Spender string          `json:"spender"`
+	Expires json.RawMessage `json:"expires"`
+}
+
+type CW721Nft struct {
+	TokenID   string          `json:"token_id"`
+	Owner     string          `json:"owner"`
+	TokenURI  string          `json:"token_uri"`
+	Extension json.RawMessage `json:"extension"`
+	Approvals []CW721Approval `json:"approvals"`
+}
+
+type CW721Operator struct {
+	Owner    string
+	Operator string
+	Expires  json.RawMessage
+}
+
+type TypeCW721MsgExecute string
+
+const (
+	TypeCW721TransferNft TypeCW721MsgExecute = "transfer_nft"
+	TypeCW721SendNft     TypeCW721MsgExecute = "send_nft"
+	TypeCW721Mint        TypeCW721MsgExecute = "mint"
+	TypeCW721Burn        TypeCW721MsgExecute = "burn"
+	TypeCW721Approve     TypeCW721MsgExecute = "approve"
+	TypeCW721Revoke      TypeCW721MsgExecute = "revoke"
+	TypeCW721ApproveAll  TypeCW721MsgExecute = "approve_all"
+	TypeCW721RevokeAll   TypeCW721MsgExecute = "revoke_all"
+)
+
+type CW721MsgTransferNft struct {
+	Recipient string `json:"recipient"`
+	TokenID   string `json:"token_id"`
+}
+
+type CW721MsgSendNft struct {
+	Contract string          `json:"contract"`
+	TokenID  string          `json:"token_id"`
+	Msg      json.RawMessage `json:"msg"`
+}
+
+type CW721MsgMint struct {
+	TokenID   string          `json:"token_id"`
+	Owner     string          `json:"owner"`
+	TokenURI  string          `json:"token_uri"`
+	Extension json.RawMessage `json:"extension"`
+}
+
+type CW721MsgBurn struct {
+	TokenID string `json:"token_id"`
+}
+
+type CW721MsgApprove struct {
+	Spender string          `json:"spender"`
+	TokenID string          `json:"token_id"`
+	Expires json.RawMessage `json:"expires"`
+}
+
+type CW721MsgRevoke struct {
+	Spender string `json:"spender"`
+	TokenID string `json:"token_id"`
+}
+
+type CW721MsgApproveAll struct {
+	Operator string          `json:"operator"`
+	Expires  json.RawMessage `json:"expires"`
+}
+
+type CW721MsgRevokeAll struct {
+	Operator string `json:"operator"`
+}
+
+type CW721MsgExecute struct {
+	TransferNft CW721MsgTransferNft `json:"transfer_nft"`
+	SendNft     CW721MsgSendNft     `json:"send_nft"`
+	Mint        CW721MsgMint        `json:"mint"`
+	Burn        CW721MsgBurn        `json:"burn"`
+	Approve     CW721MsgApprove     `json:"approve"`
+	Revoke      CW721MsgRevoke      `json:"revoke"`
+	ApproveAll  CW721MsgApproveAll  `json:"approve_all"`
+	RevokeAll   CW721MsgRevokeAll   `json:"revoke_all"`
+}
+
+type CW721History struct {
+	TokenID  string
+	Type     string
+	Sender   string
+	From     string
+	To       string
+	Height   int64
+	TxHash   string
+	MsgIndex int
+}
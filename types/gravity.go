@@ -0,0 +1,88 @@
+package types
+
+import (
+	gravitytypes "github.com/althea-net/cosmos-gravity-bridge/module/x/gravity/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+const (
+	GravityTransferStatusPending   = "pending"
+	GravityTransferStatusBatched   = "batched"
+	GravityTransferStatusCancelled = "cancelled"
+	GravityTransferStatusExecuted  = "executed"
+
+	GravityBatchStatusPending   = "pending"
+	GravityBatchStatusCancelled = "cancelled"
+	GravityBatchStatusExecuted  = "executed"
+
+	// GravityOutgoingTxBatchSize is the maximum number of transfers the chain puts inside a single batch
+	GravityOutgoingTxBatchSize = 100
+)
+
+// GravityOrchestrator represents the orchestrator a validator has registered to sign and attest on its behalf
+type GravityOrchestrator struct {
+	Address          string
+	ValidatorAddress string
+	EthAddress       string
+	Height           int64
+	TxHash           string
+}
+
+// GravityAttestationVote represents the vote of an orchestrator over an attestation, weighted by the power
+// its validator had at the time of the vote
+type GravityAttestationVote struct {
+	AttestationID    string
+	Orchestrator     string
+	ValidatorAddress string
+	Power            int64
+	Height           int64
+	TxHash           string
+}
+
+// GravityValsetUpdate represents the validator set the orchestrators claim to have been updated on Ethereum
+type GravityValsetUpdate struct {
+	ValsetNonce    uint64
+	AttestationID  string
+	Members        []*gravitytypes.BridgeValidator
+	RewardAmount   string
+	RewardToken    string
+	EthBlockHeight uint64
+	Height         int64
+	TxHash         string
+}
+
+// GravityBatch represents a batch of outgoing transfers as it is stored on chain
+type GravityBatch struct {
+	Nonce         uint64
+	Denom         string
+	TokenContract string
+	TransferIDs   []uint64
+	Height        int64
+}
+
+// NewGravityBatch returns a new GravityBatch instance from the given chain batch
+func NewGravityBatch(batch *gravitytypes.OutgoingTxBatch, denom string, height int64) GravityBatch {
+	transferIDs := make([]uint64, len(batch.Transactions))
+	for i, transfer := range batch.Transactions {
+		transferIDs[i] = transfer.Id
+	}
+
+	return GravityBatch{
+		Nonce:         batch.BatchNonce,
+		Denom:         denom,
+		TokenContract: batch.TokenContract,
+		TransferIDs:   transferIDs,
+		Height:        height,
+	}
+}
+
+// GravityOutgoingTransfer represents a transfer from Cosmos to Ethereum waiting inside the gravity outgoing pool
+type GravityOutgoingTransfer struct {
+	ID        uint64
+	Sender    string
+	EthDest   string
+	Amount    sdk.Coin
+	BridgeFee sdk.Coin
+	Height    int64
+	TxHash    string
+}
@@ -5,38 +5,60 @@ import (
 )
 
 type Group struct {
-	ID                 uint64
-	Address            string
-	GroupMetadata      string
-	PolicyMetadata     string
-	Threshold          uint64
-	VotingPeriod       uint64
-	MinExecutionPeriod uint64
+	ID       uint64
+	Admin    string
+	Metadata string
 }
 
 func NewGroup(
 	id uint64,
-	address string,
-	groupMetadata string,
-	policyMetadata string,
-	threshold uint64,
-	votingPeriod uint64,
-	minExecutionPeriod uint64,
+	admin string,
+	metadata string,
 ) *Group {
 	return &Group{
-		ID:                 id,
-		Address:            address,
-		GroupMetadata:      groupMetadata,
-		PolicyMetadata:     policyMetadata,
-		Threshold:          threshold,
-		VotingPeriod:       votingPeriod,
-		MinExecutionPeriod: minExecutionPeriod,
+		ID:       id,
+		Admin:    admin,
+		Metadata: metadata,
+	}
+}
+
+// GroupDecisionPolicy contains the rules a group policy uses to decide whether a proposal passes.
+// Percentage is only set for percentage decision policies, in which case Threshold is zero
+type GroupDecisionPolicy struct {
+	Threshold          uint64
+	Percentage         string
+	VotingPeriod       uint64
+	MinExecutionPeriod uint64
+}
+
+type GroupPolicy struct {
+	Address  string
+	GroupID  uint64
+	Admin    string
+	Metadata string
+	GroupDecisionPolicy
+}
+
+func NewGroupPolicy(
+	address string,
+	groupID uint64,
+	admin string,
+	metadata string,
+	decisionPolicy GroupDecisionPolicy,
+) *GroupPolicy {
+	return &GroupPolicy{
+		Address:             address,
+		GroupID:             groupID,
+		Admin:               admin,
+		Metadata:            metadata,
+		GroupDecisionPolicy: decisionPolicy,
 	}
 }
 
 type GroupProposal struct {
 	ID             uint64
 	GroupID        uint64
+	PolicyAddress  string
 	Metadata       string
 	Proposer       string
 	Status         string
@@ -50,6 +72,7 @@ type GroupProposal struct {
 func NewGroupProposal(
 	id uint64,
 	groupID uint64,
+	policyAddress string,
 	metadata string,
 	proposer string,
 	status string,
@@ -62,6 +85,7 @@ func NewGroupProposal(
 	return &GroupProposal{
 		ID:             id,
 		GroupID:        groupID,
+		PolicyAddress:  policyAddress,
 		Metadata:       metadata,
 		Proposer:       proposer,
 		Status:         status,
@@ -111,13 +135,16 @@ type MsgType struct {
 }
 
 type MsgUpdateDecisionPolicy struct {
-	GroupPolicyAddress string                   `json:"group_policy_address,omitempty"`
-	DecisionPolicy     *ThresholdDecisionPolicy `json:"decision_policy,omitempty"`
+	GroupPolicyAddress string          `json:"group_policy_address,omitempty"`
+	DecisionPolicy     *DecisionPolicy `json:"decision_policy,omitempty"`
 }
 
-type ThresholdDecisionPolicy struct {
-	Threshold uint64                 `json:"threshold,omitempty,string"`
-	Windows   *DecisionPolicyWindows `json:"windows,omitempty"`
+// DecisionPolicy represents either a threshold or a percentage decision policy, depending on its type
+type DecisionPolicy struct {
+	TypeURL    string                 `json:"@type,omitempty"`
+	Threshold  uint64                 `json:"threshold,omitempty,string"`
+	Percentage string                 `json:"percentage,omitempty"`
+	Windows    *DecisionPolicyWindows `json:"windows,omitempty"`
 }
 
 type DecisionPolicyWindows struct {
@@ -125,6 +152,21 @@ type DecisionPolicyWindows struct {
 	MinExecutionPeriod string `json:"min_execution_period,omitempty"`
 }
 
+type MsgUpdateGroupPolicyMetadata struct {
+	GroupPolicyAddress string `json:"group_policy_address,omitempty"`
+	Metadata           string `json:"metadata,omitempty"`
+}
+
+type MsgUpdateGroupAdmin struct {
+	GroupID  uint64 `json:"group_id,omitempty,string"`
+	NewAdmin string `json:"new_admin,omitempty"`
+}
+
+type MsgUpdateGroupPolicyAdmin struct {
+	GroupPolicyAddress string `json:"group_policy_address,omitempty"`
+	NewAdmin           string `json:"new_admin,omitempty"`
+}
+
 type MsgUpdateMembers struct {
 	GroupID       uint64    `json:"group_id,omitempty,string"`
 	MemberUpdates []*Member `json:"member_updates"`
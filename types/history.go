@@ -0,0 +1,35 @@
+package types
+
+import (
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// AccountBalanceHistory represents the whole balance of an account at a given moment in time
+type AccountBalanceHistory struct {
+	Address      string
+	Balance      sdk.Coins
+	Delegated    sdk.Coins
+	Unbonding    sdk.Coins
+	Redelegating sdk.Coins
+	Commission   sdk.DecCoins
+	Reward       sdk.DecCoins
+	Timestamp    time.Time
+}
+
+// NewAccountBalanceHistory allows to build a new AccountBalanceHistory instance
+func NewAccountBalanceHistory(
+	address string, balance, delegated, unbonding, redelegating sdk.Coins, commission, reward sdk.DecCoins, timestamp time.Time,
+) AccountBalanceHistory {
+	return AccountBalanceHistory{
+		Address:      address,
+		Balance:      balance,
+		Delegated:    delegated,
+		Unbonding:    unbonding,
+		Redelegating: redelegating,
+		Commission:   commission,
+		Reward:       reward,
+		Timestamp:    timestamp,
+	}
+}
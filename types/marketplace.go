@@ -4,3 +4,28 @@ type CudosPrice struct {
 	BTC string
 	USD string
 }
+
+const (
+	RoyaltyTypeMint   = "mint"
+	RoyaltyTypeResale = "resale"
+
+	PayoutTypeMintRoyalty   = "mint_royalty"
+	PayoutTypeResaleRoyalty = "resale_royalty"
+	PayoutTypeSeller        = "seller"
+
+	ListingEventListed       = "listed"
+	ListingEventPriceUpdated = "price_updated"
+	ListingEventDelisted     = "delisted"
+	ListingEventSold         = "sold"
+)
+
+type Royalty struct {
+	Address string
+	Percent string
+}
+
+type MarketplacePayout struct {
+	Address string
+	Amount  string
+	Type    string
+}
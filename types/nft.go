@@ -0,0 +1,62 @@
+package types
+
+// NftMetadata contains the editable fields of a Cudos nft
+type NftMetadata struct {
+	Name     string
+	URI      string
+	DataJSON string
+	DataText string
+}
+
+// NftRevision represents a single edit of the metadata of a Cudos nft
+type NftRevision struct {
+	TokenID  uint64
+	DenomID  string
+	Previous NftMetadata
+	New      NftMetadata
+	Editor   string
+	Height   int64
+	TxHash   string
+	MsgIndex int
+}
+
+const (
+	NftMetadataStatusPending = "pending"
+	NftMetadataStatusFetched = "fetched"
+	NftMetadataStatusFailed  = "failed"
+	NftMetadataStatusInvalid = "invalid"
+)
+
+// NftOffChainMetadata contains the resolved document the uri of a Cudos nft points to
+type NftOffChainMetadata struct {
+	TokenID    uint64
+	DenomID    string
+	URI        string
+	Metadata   string
+	Attributes string
+	Status     string
+	Retries    int
+	Error      string
+	Timestamp  int64
+	NextRetry  int64
+}
+
+// NftTrait represents a single trait_type/value attribute of an nft
+type NftTrait struct {
+	TraitType string
+	Value     string
+}
+
+// NftTraitFrequency contains how many nfts of a denom have a given trait
+type NftTraitFrequency struct {
+	NftTrait
+	Count     int64
+	Frequency float64
+}
+
+// NftRarity contains the rarity score of an nft and its rank inside its denom, where rank 1 is the rarest nft
+type NftRarity struct {
+	TokenID uint64
+	Score   float64
+	Rank    int64
+}
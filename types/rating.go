@@ -0,0 +1,45 @@
+package types
+
+// ValidatorRatingData contains the data about a validator that is used to compute its rating
+type ValidatorRatingData struct {
+	ValidatorAddress    string
+	SelfDelegationRatio float64
+	Uptime              float64
+	Slashed             bool
+	GovParticipation    float64
+	DelegatorsCount     int64
+	VotingPower         int64
+}
+
+// NewValidatorRatingData allows to build a new ValidatorRatingData instance
+func NewValidatorRatingData(
+	validatorAddress string, selfDelegationRatio, uptime float64, slashed bool, govParticipation float64,
+	delegatorsCount, votingPower int64,
+) ValidatorRatingData {
+	return ValidatorRatingData{
+		ValidatorAddress:    validatorAddress,
+		SelfDelegationRatio: selfDelegationRatio,
+		Uptime:              uptime,
+		Slashed:             slashed,
+		GovParticipation:    govParticipation,
+		DelegatorsCount:     delegatorsCount,
+		VotingPower:         votingPower,
+	}
+}
+
+// ValidatorRating represents the rating of a validator at a given height,
+// along with the data that has been used to compute it
+type ValidatorRating struct {
+	ValidatorRatingData
+	Score  float64
+	Height int64
+}
+
+// NewValidatorRating allows to build a new ValidatorRating instance
+func NewValidatorRating(data ValidatorRatingData, score float64, height int64) ValidatorRating {
+	return ValidatorRating{
+		ValidatorRatingData: data,
+		Score:               score,
+		Height:              height,
+	}
+}
@@ -0,0 +1,71 @@
+package types
+
+import (
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// Delegation represents the amount delegated by a delegator to a validator at a given height
+type Delegation struct {
+	DelegatorAddress string
+	ValidatorAddress string
+	Amount           sdk.Coin
+	Height           int64
+}
+
+// NewDelegation allows to build a new Delegation instance
+func NewDelegation(delegator string, validator string, amount sdk.Coin, height int64) Delegation {
+	return Delegation{
+		DelegatorAddress: delegator,
+		ValidatorAddress: validator,
+		Amount:           amount,
+		Height:           height,
+	}
+}
+
+// UnbondingDelegation represents a single unbonding delegation entry, that will be completed at the given time
+type UnbondingDelegation struct {
+	DelegatorAddress    string
+	ValidatorAddress    string
+	Amount              sdk.Coin
+	CompletionTimestamp time.Time
+	Height              int64
+}
+
+// NewUnbondingDelegation allows to build a new UnbondingDelegation instance
+func NewUnbondingDelegation(
+	delegator string, validator string, amount sdk.Coin, completionTimestamp time.Time, height int64,
+) UnbondingDelegation {
+	return UnbondingDelegation{
+		DelegatorAddress:    delegator,
+		ValidatorAddress:    validator,
+		Amount:              amount,
+		CompletionTimestamp: completionTimestamp,
+		Height:              height,
+	}
+}
+
+// Redelegation represents a single redelegation entry, that will be completed at the given time
+type Redelegation struct {
+	DelegatorAddress string
+	SrcValidator     string
+	DstValidator     string
+	Amount           sdk.Coin
+	CompletionTime   time.Time
+	Height           int64
+}
+
+// NewRedelegation allows to build a new Redelegation instance
+func NewRedelegation(
+	delegator string, srcValidator string, dstValidator string, amount sdk.Coin, completionTime time.Time, height int64,
+) Redelegation {
+	return Redelegation{
+		DelegatorAddress: delegator,
+		SrcValidator:     srcValidator,
+		DstValidator:     dstValidator,
+		Amount:           amount,
+		CompletionTime:   completionTime,
+		Height:           height,
+	}
+}
@@ -41,6 +41,16 @@ var interfaces = map[cwStandard]contract{
 			`{"balance":{"address":"cudos1"}}`,
 		},
 	},
+	CW721: {
+		msgs: []msg{
+			{`{"approve_all":{"operator":"cudos1"}}`, ""},
+			{`{"revoke_all":{"operator":"cudos1"}}`, ""},
+		},
+		queries: []string{
+			`{"num_tokens":{}}`,
+			`{"all_tokens":{}}`,
+		},
+	},
 }
 
 const (
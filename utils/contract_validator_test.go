@@ -30,3 +30,30 @@ func TestContractValidator_CW20(t *testing.T) {
 		})
 	}
 }
+
+func TestContractValidator_CW721(t *testing.T) {
+	for testName, tc := range map[string]struct {
+		wasmPath string
+		wantErr  error
+	}{
+		"valid": {
+			wasmPath: "../testdata/cw721_base.wasm",
+		},
+		"cw20": {
+			wasmPath: "../testdata/cw20_base.wasm",
+			wantErr:  fmt.Errorf("Error parsing into type cw20::msg::Cw20ExecuteMsg: unknown variant `approve_all`, expected one of `transfer`, `burn`, `send`, `increase_allowance`, `decrease_allowance`, `transfer_from`, `send_from`, `burn_from`, `mint`, `update_minter`, `update_marketing`, `upload_logo`"),
+		},
+		"invalid": {
+			wasmPath: "../testdata/alpha.wasm",
+			wantErr:  fmt.Errorf("Error parsing into type alpha::msg::ExecuteMsg: unknown variant `approve_all`, expected `increment` or `reset`"),
+		},
+	} {
+		t.Run(testName, func(t *testing.T) {
+			contractWasm, err := os.ReadFile(tc.wasmPath)
+			require.NoError(t, err)
+
+			haveErr := ValidateContract(contractWasm, CW721)
+			require.Equal(t, tc.wantErr, haveErr)
+		})
+	}
+}
@@ -38,6 +38,40 @@ func (b *MockTxBuilder) WithEventCreateGroup(groupID uint64, address string) *Mo
 	return b
 }
 
+func (b *MockTxBuilder) WithEventCreateGroupPolicy(address string) *MockTxBuilder {
+	require.NotEmpty(b.t, address)
+	e, err := sdk.TypedEventToEvent(&group.EventCreateGroupPolicy{Address: address})
+	require.NoError(b.t, err)
+
+	b.events = append(b.events, abcitypes.Event(e))
+	return b
+}
+
+func (b *MockTxBuilder) WithEventUpdateGroup(groupID uint64) *MockTxBuilder {
+	e, err := sdk.TypedEventToEvent(&group.EventUpdateGroup{GroupId: groupID})
+	require.NoError(b.t, err)
+
+	b.events = append(b.events, abcitypes.Event(e))
+	return b
+}
+
+func (b *MockTxBuilder) WithEventUpdateGroupPolicy(address string) *MockTxBuilder {
+	require.NotEmpty(b.t, address)
+	e, err := sdk.TypedEventToEvent(&group.EventUpdateGroupPolicy{Address: address})
+	require.NoError(b.t, err)
+
+	b.events = append(b.events, abcitypes.Event(e))
+	return b
+}
+
+func (b *MockTxBuilder) WithEventLeaveGroup(groupID uint64, address string) *MockTxBuilder {
+	e, err := sdk.TypedEventToEvent(&group.EventLeaveGroup{GroupId: groupID, Address: address})
+	require.NoError(b.t, err)
+
+	b.events = append(b.events, abcitypes.Event(e))
+	return b
+}
+
 func (b *MockTxBuilder) WithEventSubmitProposal(proposalID uint64) *MockTxBuilder {
 	e, err := sdk.TypedEventToEvent(&group.EventSubmitProposal{ProposalId: proposalID})
 	require.NoError(b.t, err)
@@ -82,6 +116,13 @@ func (b *MockTxBuilder) WithEventWasmAction(msgType string) *MockTxBuilder {
 	return b
 }
 
+func (b *MockTxBuilder) WithEventWasm(contractAddr string, msgType string, attributes ...sdk.Attribute) *MockTxBuilder {
+	attrs := []sdk.Attribute{sdk.NewAttribute(wasm.AttributeKeyContractAddr, contractAddr), sdk.NewAttribute("action", msgType)}
+	e := sdk.NewEvent(wasm.WasmModuleEventType, append(attrs, attributes...)...)
+	b.events = append(b.events, abcitypes.Event(e))
+	return b
+}
+
 func (b *MockTxBuilder) Build() *juno.Tx {
 	txLog := sdk.ABCIMessageLogs{{MsgIndex: 0, Events: sdk.StringifyEvents(b.events)}}
 	txResponse := sdk.TxResponse{
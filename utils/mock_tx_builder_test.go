@@ -19,9 +19,10 @@ var (
 
 func TestMockTxBuilder_Build(t *testing.T) {
 	timestamp := time.Now()
-	tx := NewMockTxBuilder(t, timestamp, str, num).WithEventCreateGroup(num, str).WithEventSubmitProposal(num).WithEventExec(resultDefault).WithEventVote().WithEventWithdrawProposal().WithEventInstantiateContract(str).WithEventWasmAction(str).Build()
+	tx := NewMockTxBuilder(t, timestamp, str, num).WithEventCreateGroup(num, str).WithEventSubmitProposal(num).WithEventExec(resultDefault).WithEventVote().WithEventWithdrawProposal().WithEventInstantiateContract(str).WithEventWasmAction(str).
+		WithEventUpdateGroup(num).WithEventUpdateGroupPolicy(str).WithEventLeaveGroup(num, str).Build()
 
-	expectedEventCount := 8
+	expectedEventCount := 11
 	actualEventCount := len(tx.Logs[0].Events)
 	require.Equal(t, expectedEventCount, actualEventCount)
 
@@ -43,6 +44,15 @@ func TestMockTxBuilder_Build(t *testing.T) {
 	withdrawEvent := utils.GetValueFromLogs(uint32(index), tx.Logs, "cosmos.group.v1.EventWithdrawProposal", "proposal_id")
 	require.Equal(t, str, withdrawEvent)
 
+	updateGroupEvent := utils.GetValueFromLogs(index, tx.Logs, "cosmos.group.v1.EventUpdateGroup", "group_id")
+	require.Equal(t, str, updateGroupEvent)
+
+	updateGroupPolicyEvent := utils.GetValueFromLogs(index, tx.Logs, "cosmos.group.v1.EventUpdateGroupPolicy", "address")
+	require.Equal(t, str, updateGroupPolicyEvent)
+
+	leaveGroupEvent := utils.GetValueFromLogs(index, tx.Logs, "cosmos.group.v1.EventLeaveGroup", "address")
+	require.Equal(t, str, leaveGroupEvent)
+
 	instantiateContractEvent := utils.GetValueFromLogs(uint32(index), tx.Logs, wasm.EventTypeInstantiate, wasm.AttributeKeyContractAddr)
 	require.Equal(t, str, instantiateContractEvent)
 
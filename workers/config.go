@@ -9,6 +9,9 @@ import (
 type workerConfig struct {
 	Name     string `yaml:"name"`
 	Interval string `yaml:"interval"`
+
+	// IPFSGateway is the gateway ipfs:// uris are fetched from by the nft metadata worker
+	IPFSGateway string `yaml:"ipfs_gateway,omitempty"`
 }
 
 type workersConfig struct {
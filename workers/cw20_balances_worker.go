@@ -0,0 +1,173 @@
+package workers
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/forbole/juno/v2/cmd/parse"
+	"github.com/rs/zerolog/log"
+
+	"github.com/forbole/bdjuno/v2/database"
+	"github.com/forbole/bdjuno/v2/modules/cw20token"
+	"github.com/forbole/bdjuno/v2/modules/cw20token/source"
+	"github.com/forbole/bdjuno/v2/types"
+)
+
+// cw20BalancesDb represents the database operations needed to reconcile the cw20 balances
+type cw20BalancesDb interface {
+	GetTokenAddresses() ([]string, error)
+	GetBalances(token string) ([]types.TokenBalance, error)
+	SaveBalances(token string, balances []types.TokenBalance, height int64) error
+}
+
+// cw20BalancesDatabase implements cw20BalancesDb on top of the database
+type cw20BalancesDatabase struct {
+	*database.Db
+}
+
+func (db cw20BalancesDatabase) SaveBalances(token string, balances []types.TokenBalance, height int64) error {
+	return db.ExecuteTx(func(dbTx *database.DbTx) error {
+		return dbTx.SaveBalances(token, balances, height)
+	})
+}
+
+type cw20BalancesWorker struct {
+	baseWorker
+	source source.Source
+}
+
+func (cbw cw20BalancesWorker) Name() string {
+	return "cw20_balances_worker"
+}
+
+func (cbw cw20BalancesWorker) Start(ctx context.Context, parseCfg *parse.Config, parseCtx *parse.Context, storage keyValueStorage, interval time.Duration) {
+	source, err := getCw20TokenSource(parseCtx)
+	if err != nil {
+		parseCfg.GetLogger().Error(fmt.Errorf("error while getting source for worker '%s': %s", cbw.Name(), err).Error(), cbw.Name())
+		return
+	}
+
+	cbw.source = source
+	cbw.baseWorker.Start(ctx, cbw.Name(), cbw.reconcileBalances, parseCfg, parseCtx, storage, interval)
+}
+
+// getCw20TokenSource returns the source of the cw20token module used while parsing
+func getCw20TokenSource(parseCtx *parse.Context) (source.Source, error) {
+	for _, module := range parseCtx.Modules {
+		if cw20Module, ok := module.(*cw20token.Module); ok {
+			return cw20Module.Source(), nil
+		}
+	}
+
+	return nil, fmt.Errorf("cw20token module is not enabled")
+}
+
+func (cbw cw20BalancesWorker) reconcileBalances(parseCfg *parse.Config, parseCtx *parse.Context, storage keyValueStorage) error {
+	roundHeightVal, err := storage.GetOrDefaultValue(cw20BalancesRoundHeightKey, "0")
+	if err != nil {
+		return fmt.Errorf("error while getting worker storage key '%s': %s", cw20BalancesRoundHeightKey, err)
+	}
+
+	roundHeight, err := strconv.ParseInt(roundHeightVal, 10, 64)
+	if err != nil {
+		return fmt.Errorf("error while parsing round height value '%s': %s", roundHeightVal, err)
+	}
+
+	// Start a new round at the latest height unless the previous one has not been completed
+	if roundHeight == 0 {
+		roundHeight, err = getLatestStoredBlockHeight(parseCtx)
+		if err != nil {
+			return fmt.Errorf("error while getting latest stored block height: %s", err)
+		}
+
+		roundHeightVal = strconv.FormatInt(roundHeight, 10)
+		if err := storage.SetValue(cw20BalancesRoundHeightKey, roundHeightVal); err != nil {
+			return fmt.Errorf("error while storing round height value '%s': %s", roundHeightVal, err)
+		}
+	}
+
+	return cbw.reconcileRound(cw20BalancesDatabase{database.Cast(parseCtx.Database)}, storage, roundHeight)
+}
+
+// reconcileRound reconciles the balances of all the tokens at the given height, skipping the tokens
+// that have already been reconciled at it so that an interrupted round is resumed where it stopped
+func (cbw cw20BalancesWorker) reconcileRound(db cw20BalancesDb, storage keyValueStorage, height int64) error {
+	tokens, err := db.GetTokenAddresses()
+	if err != nil {
+		return fmt.Errorf("error while getting cw20 tokens: %s", err)
+	}
+
+	heightVal := strconv.FormatInt(height, 10)
+	for _, token := range tokens {
+		key := cw20BalancesReconciledHeightKey + token
+
+		reconciledHeightVal, err := storage.GetOrDefaultValue(key, "0")
+		if err != nil {
+			return fmt.Errorf("error while getting worker storage key '%s': %s", key, err)
+		}
+
+		if reconciledHeightVal == heightVal {
+			continue
+		}
+
+		if err := cbw.reconcileToken(db, token, height); err != nil {
+			return fmt.Errorf("error while reconciling balances of token '%s': %s", token, err)
+		}
+
+		if err := storage.SetValue(key, heightVal); err != nil {
+			return fmt.Errorf("error while storing reconciled height value '%s': %s", heightVal, err)
+		}
+	}
+
+	// The round is completed, the next one will start from the latest height
+	if err := storage.SetValue(cw20BalancesRoundHeightKey, "0"); err != nil {
+		return fmt.Errorf("error while resetting round height value: %s", err)
+	}
+
+	return nil
+}
+
+func (cbw cw20BalancesWorker) reconcileToken(db cw20BalancesDb, token string, height int64) error {
+	chainBalances, err := cbw.source.AllBalances(token, height)
+	if err != nil {
+		return fmt.Errorf("error while getting balances from chain: %s", err)
+	}
+
+	storedBalances, err := db.GetBalances(token)
+	if err != nil {
+		return fmt.Errorf("error while getting stored balances: %s", err)
+	}
+
+	stored := make(map[string]string, len(storedBalances))
+	for _, b := range storedBalances {
+		stored[b.Address] = b.Amount
+	}
+
+	divergent := []types.TokenBalance{}
+	for _, b := range chainBalances {
+		if amount, ok := stored[b.Address]; !ok || amount != b.Amount {
+			divergent = append(divergent, b)
+		}
+		delete(stored, b.Address)
+	}
+
+	// Addresses left in the database but no longer holding the token on chain
+	for address := range stored {
+		divergent = append(divergent, types.TokenBalance{Address: address, Amount: "0"})
+	}
+
+	if len(divergent) == 0 {
+		return nil
+	}
+
+	log.Debug().Str("worker", cbw.Name()).Msg(fmt.Sprintf("Repairing %d balances of token %s at height %d", len(divergent), token, height))
+
+	return db.SaveBalances(token, divergent, height)
+}
+
+const (
+	cw20BalancesRoundHeightKey      = "round_height"
+	cw20BalancesReconciledHeightKey = "reconciled_height_"
+)
@@ -0,0 +1,107 @@
+package workers
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/forbole/juno/v2/cmd/parse"
+	"github.com/stretchr/testify/require"
+
+	"github.com/forbole/bdjuno/v2/modules/cw20token/source"
+	mocksource "github.com/forbole/bdjuno/v2/modules/cw20token/source/mock"
+	"github.com/forbole/bdjuno/v2/types"
+)
+
+// mockStorage represents an in-memory keyValueStorage
+type mockStorage map[string]string
+
+func (s mockStorage) SetValue(key, value string) error {
+	s[key] = value
+	return nil
+}
+
+func (s mockStorage) GetValue(key string) (string, error) {
+	value, ok := s[key]
+	if !ok {
+		return "", ErrKeyNotFound
+	}
+	return value, nil
+}
+
+func (s mockStorage) GetOrDefaultValue(key, defaultValue string) (string, error) {
+	value, err := s.GetValue(key)
+	if err == ErrKeyNotFound {
+		return defaultValue, nil
+	}
+	return value, err
+}
+
+// mockCw20BalancesDb represents an in-memory cw20BalancesDb recording the saved balances
+type mockCw20BalancesDb struct {
+	balances map[string][]types.TokenBalance
+	saved    map[string][]types.TokenBalance
+}
+
+func (db *mockCw20BalancesDb) GetTokenAddresses() ([]string, error) {
+	return []string{"token1", "token2"}, nil
+}
+
+func (db *mockCw20BalancesDb) GetBalances(token string) ([]types.TokenBalance, error) {
+	return db.balances[token], nil
+}
+
+func (db *mockCw20BalancesDb) SaveBalances(token string, balances []types.TokenBalance, _ int64) error {
+	db.saved[token] = append(db.saved[token], balances...)
+	return nil
+}
+
+// failingSource represents a source that cannot be reached for the given token
+type failingSource struct {
+	source.Source
+	token string
+}
+
+func (s failingSource) AllBalances(tokenAddr string, height int64) ([]types.TokenBalance, error) {
+	if tokenAddr == s.token {
+		return nil, fmt.Errorf("node unavailable")
+	}
+	return s.Source.AllBalances(tokenAddr, height)
+}
+
+func TestCw20BalancesWorker_ReconcileRound(t *testing.T) {
+	chainSource := mocksource.NewMockSource(types.TokenInfo{
+		Balances: []types.TokenBalance{{Address: "cudos1", Amount: "10"}, {Address: "cudos2", Amount: "20"}},
+	})
+
+	db := &mockCw20BalancesDb{
+		balances: map[string][]types.TokenBalance{
+			"token1": {{Address: "cudos1", Amount: "10"}, {Address: "cudos2", Amount: "15"}, {Address: "cudos3", Amount: "5"}},
+			"token2": {{Address: "cudos1", Amount: "10"}, {Address: "cudos2", Amount: "20"}},
+		},
+		saved: map[string][]types.TokenBalance{},
+	}
+	storage := mockStorage{cw20BalancesRoundHeightKey: "100"}
+
+	// The round is interrupted by the second token, keeping the progress of the first one
+	worker := cw20BalancesWorker{source: failingSource{Source: chainSource, token: "token2"}}
+	require.Error(t, worker.reconcileRound(db, storage, 100))
+	require.ElementsMatch(t, []types.TokenBalance{
+		{Address: "cudos2", Amount: "20"},
+		{Address: "cudos3", Amount: "0"},
+	}, db.saved["token1"])
+	require.Equal(t, "100", storage[cw20BalancesReconciledHeightKey+"token1"])
+	require.Equal(t, "100", storage[cw20BalancesRoundHeightKey])
+
+	// Resuming the round skips the already reconciled token and completes the round
+	worker = cw20BalancesWorker{source: failingSource{Source: chainSource, token: "token1"}}
+	require.NoError(t, worker.reconcileRound(db, storage, 100))
+	require.Len(t, db.saved["token1"], 2)
+	require.Empty(t, db.saved["token2"])
+	require.Equal(t, "100", storage[cw20BalancesReconciledHeightKey+"token2"])
+	require.Equal(t, "0", storage[cw20BalancesRoundHeightKey])
+}
+
+func TestGetCw20TokenSource(t *testing.T) {
+	_, err := getCw20TokenSource(&parse.Context{})
+	require.Error(t, err)
+}
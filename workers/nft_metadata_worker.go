@@ -0,0 +1,262 @@
+package workers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/forbole/juno/v2/cmd/parse"
+	"github.com/forbole/juno/v2/types/config"
+	"github.com/rs/zerolog/log"
+
+	"github.com/forbole/bdjuno/v2/database"
+	"github.com/forbole/bdjuno/v2/modules/utils"
+	"github.com/forbole/bdjuno/v2/types"
+)
+
+const (
+	defaultIPFSGateway = "https://ipfs.io/ipfs/"
+
+	nftMetadataMaxRetries   = 5
+	nftMetadataBatchSize    = 100
+	nftMetadataFetchTimeout = 10 * time.Second
+	nftMetadataMaxSize      = 1 << 20
+	nftMetadataMaxRedirects = 5
+
+	// nftMetadataRetryDelay is the delay before the first retry of a failed fetch, doubled at every further attempt
+	nftMetadataRetryDelay = 10 * time.Minute
+)
+
+var errNftMetadataTooLarge = fmt.Errorf("document exceeds %d bytes", nftMetadataMaxSize)
+
+type nftMetadataWorker struct {
+	baseWorker
+	fetcher nftMetadataFetcher
+}
+
+func (nmw nftMetadataWorker) Name() string {
+	return "nft_metadata_worker"
+}
+
+func (nmw nftMetadataWorker) Start(ctx context.Context, parseCfg *parse.Config, parseCtx *parse.Context, storage keyValueStorage, interval time.Duration) {
+	ipfsGateway := defaultIPFSGateway
+
+	cfg, err := parseConfig(config.Cfg.GetBytes())
+	if err == nil {
+		if wcfg, err := getWorkerConfig(cfg, nmw.Name()); err == nil && wcfg.IPFSGateway != "" {
+			ipfsGateway = wcfg.IPFSGateway
+		}
+	}
+
+	nmw.fetcher = newNftMetadataFetcher(newNftMetadataHTTPClient(), ipfsGateway)
+	nmw.baseWorker.Start(ctx, nmw.Name(), nmw.resolveMetadata, parseCfg, parseCtx, storage, interval)
+}
+
+func (nmw nftMetadataWorker) resolveMetadata(parseCfg *parse.Config, parseCtx *parse.Context, storage keyValueStorage) error {
+	db := database.Cast(parseCtx.Database)
+
+	now := time.Now()
+	nfts, err := db.GetNftsToResolveMetadata(nftMetadataMaxRetries, nftMetadataBatchSize, now.Unix())
+	if err != nil {
+		return fmt.Errorf("error while getting nfts to resolve metadata: %s", err)
+	}
+
+	for _, nft := range nfts {
+		metadata := nmw.fetcher.Fetch(nft.URI)
+		metadata.TokenID = nft.TokenID
+		metadata.DenomID = nft.DenomID
+		metadata.Timestamp = time.Now().Unix()
+
+		if metadata.Status == types.NftMetadataStatusFailed {
+			metadata.Retries = nft.Retries + 1
+			metadata.NextRetry = now.Add(nftMetadataRetryBackoff(metadata.Retries)).Unix()
+		}
+
+		if metadata.Status != types.NftMetadataStatusFetched {
+			log.Debug().Str("worker", nmw.Name()).Str("denomId", nft.DenomID).Uint64("tokenId", nft.TokenID).
+				Str("uri", nft.URI).Str("error", metadata.Error).Msg("error while resolving nft metadata")
+		}
+
+		if err := db.SaveNftMetadata(metadata); err != nil {
+			return fmt.Errorf("error while saving metadata of nft %s/%d: %s", nft.DenomID, nft.TokenID, err)
+		}
+	}
+
+	return nil
+}
+
+// nftMetadataRetryBackoff returns how long to wait before fetching again a document that failed the given number of times
+func nftMetadataRetryBackoff(retries int) time.Duration {
+	if retries < 1 {
+		return 0
+	}
+
+	return nftMetadataRetryDelay << (retries - 1)
+}
+
+// newNftMetadataHTTPClient returns an HTTP client that refuses to connect to loopback, private and link-local
+// addresses, so that nft uris can not be used to reach the internal network the worker runs in. Addresses are
+// checked once resolved, which covers every redirect as well
+func newNftMetadataHTTPClient() *http.Client {
+	dialer := &net.Dialer{
+		Timeout: nftMetadataFetchTimeout,
+		Control: checkNftMetadataDialAddress,
+	}
+
+	return &http.Client{
+		Timeout: nftMetadataFetchTimeout,
+		Transport: &http.Transport{
+			DialContext:         dialer.DialContext,
+			TLSHandshakeTimeout: nftMetadataFetchTimeout,
+			MaxIdleConns:        10,
+			IdleConnTimeout:     time.Minute,
+		},
+		CheckRedirect: checkNftMetadataRedirect,
+	}
+}
+
+// checkNftMetadataDialAddress is a net.Dialer control function rejecting connections to non public addresses
+func checkNftMetadataDialAddress(network, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+
+	ip := net.ParseIP(host)
+	if ip == nil {
+		return fmt.Errorf("invalid address %s", address)
+	}
+
+	if !isPublicIP(ip) {
+		return fmt.Errorf("connections to %s are not allowed", ip)
+	}
+
+	return nil
+}
+
+// checkNftMetadataRedirect validates every redirect target before following it
+func checkNftMetadataRedirect(req *http.Request, via []*http.Request) error {
+	if len(via) >= nftMetadataMaxRedirects {
+		return fmt.Errorf("stopped after %d redirects", nftMetadataMaxRedirects)
+	}
+
+	if req.URL.Scheme != "http" && req.URL.Scheme != "https" {
+		return fmt.Errorf("unsupported redirect scheme %s", req.URL.Scheme)
+	}
+
+	if ip := net.ParseIP(req.URL.Hostname()); ip != nil && !isPublicIP(ip) {
+		return fmt.Errorf("redirects to %s are not allowed", ip)
+	}
+
+	return nil
+}
+
+func isPublicIP(ip net.IP) bool {
+	return !ip.IsLoopback() && !ip.IsPrivate() && !ip.IsUnspecified() && !ip.IsMulticast() &&
+		!ip.IsLinkLocalUnicast() && !ip.IsLinkLocalMulticast() && !ip.IsInterfaceLocalMulticast()
+}
+
+// nftMetadataFetcher resolves the off-chain documents nfts uris point to
+type nftMetadataFetcher struct {
+	client      *http.Client
+	ipfsGateway string
+}
+
+func newNftMetadataFetcher(client *http.Client, ipfsGateway string) nftMetadataFetcher {
+	if !strings.HasSuffix(ipfsGateway, "/") {
+		ipfsGateway += "/"
+	}
+
+	return nftMetadataFetcher{client: client, ipfsGateway: ipfsGateway}
+}
+
+// ResolveURL returns the HTTP(S) url the given uri can be fetched from, mapping ipfs:// uris to the configured gateway
+func (f nftMetadataFetcher) ResolveURL(uri string) (string, error) {
+	uri = strings.TrimSpace(uri)
+
+	switch {
+	case strings.HasPrefix(uri, "ipfs://"):
+		path := strings.TrimPrefix(strings.TrimPrefix(uri, "ipfs://"), "ipfs/")
+		if path == "" {
+			return "", fmt.Errorf("invalid ipfs uri %s", uri)
+		}
+		return f.ipfsGateway + path, nil
+
+	case strings.HasPrefix(uri, "http://"), strings.HasPrefix(uri, "https://"):
+		return uri, nil
+
+	default:
+		return "", fmt.Errorf("unsupported uri scheme %s", uri)
+	}
+}
+
+// Fetch downloads the document behind the given uri and validates it as a JSON object. Documents that can not be
+// downloaded are returned with the failed status so that they are retried, while unsupported uris and documents
+// that are not JSON objects are returned with the invalid status
+func (f nftMetadataFetcher) Fetch(uri string) types.NftOffChainMetadata {
+	metadata := types.NftOffChainMetadata{URI: uri}
+
+	url, err := f.ResolveURL(uri)
+	if err != nil {
+		metadata.Status, metadata.Error = types.NftMetadataStatusInvalid, err.Error()
+		return metadata
+	}
+
+	bz, err := f.get(url)
+	if err == errNftMetadataTooLarge {
+		metadata.Status, metadata.Error = types.NftMetadataStatusInvalid, err.Error()
+		return metadata
+	}
+	if err != nil {
+		metadata.Status, metadata.Error = types.NftMetadataStatusFailed, err.Error()
+		return metadata
+	}
+
+	var document map[string]json.RawMessage
+	if err := json.Unmarshal(bz, &document); err != nil {
+		metadata.Status, metadata.Error = types.NftMetadataStatusInvalid, fmt.Sprintf("invalid JSON document: %s", err)
+		return metadata
+	}
+
+	metadata.Status = types.NftMetadataStatusFetched
+	metadata.Metadata = utils.SanitizeUTF8(string(bz))
+	metadata.Attributes = "[]"
+
+	var attributes []json.RawMessage
+	if raw, ok := document["attributes"]; ok && json.Unmarshal(raw, &attributes) == nil && attributes != nil {
+		metadata.Attributes = utils.SanitizeUTF8(string(raw))
+	}
+
+	return metadata
+}
+
+func (f nftMetadataFetcher) get(url string) ([]byte, error) {
+	resp, err := f.client.Get(url)
+	if err != nil {
+		return nil, err
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code %d", resp.StatusCode)
+	}
+
+	bz, err := ioutil.ReadAll(io.LimitReader(resp.Body, nftMetadataMaxSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("error while reading response body: %s", err)
+	}
+
+	if len(bz) > nftMetadataMaxSize {
+		return nil, errNftMetadataTooLarge
+	}
+
+	return bz, nil
+}
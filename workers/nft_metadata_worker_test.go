@@ -0,0 +1,132 @@
+package workers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/forbole/bdjuno/v2/types"
+)
+
+func TestNftMetadataFetcher_ResolveURL(t *testing.T) {
+	f := newNftMetadataFetcher(http.DefaultClient, "https://gateway.test/ipfs")
+
+	for uri, expected := range map[string]string{
+		"ipfs://QmHash/1.json":      "https://gateway.test/ipfs/QmHash/1.json",
+		"ipfs://ipfs/QmHash/1.json": "https://gateway.test/ipfs/QmHash/1.json",
+		"https://host.test/1.json":  "https://host.test/1.json",
+		"http://host.test/1.json":   "http://host.test/1.json",
+	} {
+		url, err := f.ResolveURL(uri)
+		require.NoError(t, err)
+		require.Equal(t, expected, url)
+	}
+
+	for _, uri := range []string{"", "ipfs://", "ar://hash", "data:application/json,{}"} {
+		_, err := f.ResolveURL(uri)
+		require.Error(t, err)
+	}
+}
+
+func TestNftMetadataFetcher_Fetch(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/ipfs/QmHash/metadata.json":
+			w.Write([]byte(`{"name":"nft","attributes":[{"trait_type":"color","value":"red"}]}`))
+		case "/no-attributes.json":
+			w.Write([]byte(`{"name":"nft"}`))
+		case "/not-json":
+			w.Write([]byte(`<html></html>`))
+		case "/too-large.json":
+			w.Write([]byte(`{"name":"` + strings.Repeat("a", nftMetadataMaxSize) + `"}`))
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}))
+	defer server.Close()
+
+	f := newNftMetadataFetcher(server.Client(), server.URL+"/ipfs/")
+
+	metadata := f.Fetch("ipfs://QmHash/metadata.json")
+	require.Equal(t, types.NftMetadataStatusFetched, metadata.Status)
+	require.Equal(t, "ipfs://QmHash/metadata.json", metadata.URI)
+	require.JSONEq(t, `{"name":"nft","attributes":[{"trait_type":"color","value":"red"}]}`, metadata.Metadata)
+	require.JSONEq(t, `[{"trait_type":"color","value":"red"}]`, metadata.Attributes)
+
+	metadata = f.Fetch(server.URL + "/no-attributes.json")
+	require.Equal(t, types.NftMetadataStatusFetched, metadata.Status)
+	require.Equal(t, "[]", metadata.Attributes)
+
+	metadata = f.Fetch(server.URL + "/not-json")
+	require.Equal(t, types.NftMetadataStatusInvalid, metadata.Status)
+	require.NotEmpty(t, metadata.Error)
+
+	metadata = f.Fetch(server.URL + "/too-large.json")
+	require.Equal(t, types.NftMetadataStatusInvalid, metadata.Status)
+
+	metadata = f.Fetch(server.URL + "/unavailable.json")
+	require.Equal(t, types.NftMetadataStatusFailed, metadata.Status)
+	require.NotEmpty(t, metadata.Error)
+
+	metadata = f.Fetch("ar://hash")
+	require.Equal(t, types.NftMetadataStatusInvalid, metadata.Status)
+}
+
+func TestNftMetadataFetcher_RejectsInternalAddresses(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"name":"nft"}`))
+	}))
+	defer server.Close()
+
+	f := newNftMetadataFetcher(newNftMetadataHTTPClient(), "https://gateway.test/ipfs/")
+
+	metadata := f.Fetch(server.URL + "/metadata.json")
+	require.Equal(t, types.NftMetadataStatusFailed, metadata.Status)
+	require.Contains(t, metadata.Error, "not allowed")
+}
+
+func TestCheckNftMetadataDialAddress(t *testing.T) {
+	for _, address := range []string{
+		"127.0.0.1:80", "[::1]:443", "10.0.0.1:80", "172.16.5.4:80", "192.168.1.1:80",
+		"169.254.169.254:80", "[fe80::1]:80", "0.0.0.0:80", "[fd00::1]:80",
+	} {
+		require.Error(t, checkNftMetadataDialAddress("tcp", address, nil), address)
+	}
+
+	for _, address := range []string{"8.8.8.8:443", "[2606:4700::1111]:443"} {
+		require.NoError(t, checkNftMetadataDialAddress("tcp", address, nil), address)
+	}
+}
+
+func TestCheckNftMetadataRedirect(t *testing.T) {
+	for target, valid := range map[string]bool{
+		"https://host.test/1.json":                 true,
+		"http://8.8.8.8/1.json":                    true,
+		"http://169.254.169.254/latest/meta-data/": false,
+		"http://127.0.0.1:26657/status":            false,
+		"file:///etc/passwd":                       false,
+	} {
+		req, err := http.NewRequest(http.MethodGet, target, nil)
+		require.NoError(t, err)
+
+		err = checkNftMetadataRedirect(req, nil)
+		if valid {
+			require.NoError(t, err, target)
+		} else {
+			require.Error(t, err, target)
+		}
+	}
+
+	req, err := http.NewRequest(http.MethodGet, "https://host.test/1.json", nil)
+	require.NoError(t, err)
+	require.Error(t, checkNftMetadataRedirect(req, make([]*http.Request, nftMetadataMaxRedirects)))
+}
+
+func TestNftMetadataRetryBackoff(t *testing.T) {
+	require.Equal(t, nftMetadataRetryDelay, nftMetadataRetryBackoff(1))
+	require.Equal(t, 2*nftMetadataRetryDelay, nftMetadataRetryBackoff(2))
+	require.Equal(t, 8*nftMetadataRetryDelay, nftMetadataRetryBackoff(4))
+}
@@ -25,6 +25,8 @@ var workers = []worker{
 	fixBlocksWorker{},
 	migrateNftsWorker{},
 	blocksMonitoringWorker{},
+	cw20BalancesWorker{},
+	nftMetadataWorker{},
 }
 
 func GetStartWorkersPrerunE(origPreRunE PreRunE, parseCfg *parse.Config) PreRunE {